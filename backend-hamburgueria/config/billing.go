@@ -0,0 +1,20 @@
+package config
+
+// ServiceChargePercent retorna a taxa de serviço (%) cobrada em cada tipo de pedido
+// Salão: SERVICE_CHARGE_PERCENT (padrão 10); retirada e entrega: SERVICE_CHARGE_TAKEAWAY_PERCENT
+// e SERVICE_CHARGE_DELIVERY_PERCENT (padrão 0). A taxa é opcional para o cliente.
+func ServiceChargePercent(orderType string) int {
+	var percent int
+	switch orderType {
+	case "takeaway":
+		percent = getEnvInt("SERVICE_CHARGE_TAKEAWAY_PERCENT", 0)
+	case "delivery":
+		percent = getEnvInt("SERVICE_CHARGE_DELIVERY_PERCENT", 0)
+	default:
+		percent = getEnvInt("SERVICE_CHARGE_PERCENT", 10)
+	}
+	if percent < 0 {
+		percent = 0
+	}
+	return percent
+}
@@ -0,0 +1,21 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Teste da taxa de serviço por tipo de pedido
+func TestServiceChargePercent(t *testing.T) {
+	t.Setenv("SERVICE_CHARGE_PERCENT", "")
+	t.Setenv("SERVICE_CHARGE_DELIVERY_PERCENT", "")
+	assert.Equal(t, 10, ServiceChargePercent("dine_in"))
+	assert.Equal(t, 0, ServiceChargePercent("delivery"))
+
+	t.Setenv("SERVICE_CHARGE_TAKEAWAY_PERCENT", "5")
+	assert.Equal(t, 5, ServiceChargePercent("takeaway"))
+
+	t.Setenv("SERVICE_CHARGE_PERCENT", "-3")
+	assert.Equal(t, 0, ServiceChargePercent("dine_in"))
+}
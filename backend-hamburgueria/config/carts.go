@@ -0,0 +1,13 @@
+package config
+
+import "time"
+
+// CartTTL retorna por quanto tempo um carrinho sem alterações continua válido
+// CART_TTL_MINUTES, padrão 120 minutos
+func CartTTL() time.Duration {
+	minutes := getEnvInt("CART_TTL_MINUTES", 120)
+	if minutes <= 0 {
+		minutes = 120
+	}
+	return time.Duration(minutes) * time.Minute
+}
@@ -0,0 +1,11 @@
+package config
+
+// DriverAverageSpeedKmh retorna a velocidade média dos entregadores no trânsito
+// Usada na previsão de chegada (DRIVER_AVG_SPEED_KMH, padrão 25 km/h)
+func DriverAverageSpeedKmh() float64 {
+	speed := getEnvInt("DRIVER_AVG_SPEED_KMH", 25)
+	if speed <= 0 {
+		speed = 25
+	}
+	return float64(speed)
+}
@@ -0,0 +1,94 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Modos de tratamento quando a cozinha atinge a capacidade
+const (
+	OverloadReject   = "reject"   // Recusa o pedido informando o tempo de espera
+	OverloadSchedule = "schedule" // Aceita o pedido como "scheduled" e libera depois
+)
+
+// KitchenCapacity descreve o modelo de capacidade da cozinha
+// Limites zerados (ou ausentes) desligam a verificação correspondente
+type KitchenCapacity struct {
+	StationLimits      map[string]int // Itens simultâneos por estação (ex: grill: 12)
+	DefaultStationSize int            // Itens por rodada nas estações sem limite (só para a previsão)
+	MaxOrdersPerWindow int            // Pedidos aceitos por janela de tempo
+	Window             time.Duration  // Tamanho da janela (padrão 10 minutos)
+	AvgPrepTime        time.Duration  // Tempo médio de uma "rodada" da cozinha
+	OverloadMode       string         // reject ou schedule
+}
+
+// Enabled indica se algum limite de capacidade está configurado
+func (k KitchenCapacity) Enabled() bool {
+	return k.MaxOrdersPerWindow > 0 || len(k.StationLimits) > 0
+}
+
+// StationSize retorna quantos itens a estação prepara por rodada
+// Sem limite configurado vale DefaultStationSize: a verificação de capacidade fica desligada,
+// mas a previsão de pronto continua considerando a fila
+func (k KitchenCapacity) StationSize(station string) int {
+	if limit := k.StationLimits[station]; limit > 0 {
+		return limit
+	}
+	if k.DefaultStationSize > 0 {
+		return k.DefaultStationSize
+	}
+	return 1
+}
+
+// LoadKitchenCapacity lê o modelo de capacidade das variáveis de ambiente
+//
+//	KITCHEN_STATION_CAPACITY=grill:12,fryer:10,bar:20
+//	KITCHEN_MAX_ORDERS_PER_WINDOW=30
+//	KITCHEN_WINDOW_MINUTES=10
+//	KITCHEN_AVG_PREP_MINUTES=10
+//	KITCHEN_DEFAULT_STATION_SIZE=6
+//	KITCHEN_OVERLOAD_MODE=reject
+func LoadKitchenCapacity() KitchenCapacity {
+	capacity := KitchenCapacity{
+		StationLimits:      parseStationLimits(os.Getenv("KITCHEN_STATION_CAPACITY")),
+		MaxOrdersPerWindow: getEnvInt("KITCHEN_MAX_ORDERS_PER_WINDOW", 0),
+		Window:             time.Duration(getEnvInt("KITCHEN_WINDOW_MINUTES", 10)) * time.Minute,
+		AvgPrepTime:        time.Duration(getEnvInt("KITCHEN_AVG_PREP_MINUTES", 10)) * time.Minute,
+		DefaultStationSize: getEnvInt("KITCHEN_DEFAULT_STATION_SIZE", 6),
+		OverloadMode:       OverloadReject,
+	}
+	if os.Getenv("KITCHEN_OVERLOAD_MODE") == OverloadSchedule {
+		capacity.OverloadMode = OverloadSchedule
+	}
+	return capacity
+}
+
+// parseStationLimits converte "grill:12,fryer:10" em um mapa estação -> limite
+// Entradas malformadas ou com limite <= 0 são ignoradas
+func parseStationLimits(raw string) map[string]int {
+	limits := make(map[string]int)
+	for _, entry := range strings.Split(raw, ",") {
+		parts := strings.SplitN(strings.TrimSpace(entry), ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		limit, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+		if err != nil || limit <= 0 {
+			continue
+		}
+		limits[strings.TrimSpace(parts[0])] = limit
+	}
+	return limits
+}
+
+// getEnvInt lê uma variável de ambiente inteira
+// Se não estiver definida (ou for inválida), usa o valor padrão
+func getEnvInt(name string, fallback int) int {
+	value, err := strconv.Atoi(os.Getenv(name))
+	if err != nil {
+		return fallback
+	}
+	return value
+}
@@ -0,0 +1,39 @@
+package config
+
+import "time"
+
+// ScheduledOrders descreve as regras dos pedidos agendados para mais tarde
+type ScheduledOrders struct {
+	LeadTime     time.Duration // Antecedência com que o pedido entra na fila da cozinha
+	SlotSize     time.Duration // Tamanho de cada horário de retirada
+	SlotCapacity int           // Pedidos por horário (0 = sem limite)
+	MaxDaysAhead int           // Até quantos dias à frente é possível agendar
+}
+
+// LoadScheduledOrders lê as regras dos pedidos agendados das variáveis de ambiente
+//
+//	SCHEDULED_LEAD_MINUTES=30
+//	SCHEDULED_SLOT_MINUTES=15
+//	SCHEDULED_SLOT_MAX_ORDERS=10
+//	SCHEDULED_MAX_DAYS_AHEAD=7
+func LoadScheduledOrders() ScheduledOrders {
+	scheduling := ScheduledOrders{
+		LeadTime:     time.Duration(getEnvInt("SCHEDULED_LEAD_MINUTES", 30)) * time.Minute,
+		SlotSize:     time.Duration(getEnvInt("SCHEDULED_SLOT_MINUTES", 15)) * time.Minute,
+		SlotCapacity: getEnvInt("SCHEDULED_SLOT_MAX_ORDERS", 10),
+		MaxDaysAhead: getEnvInt("SCHEDULED_MAX_DAYS_AHEAD", 7),
+	}
+	if scheduling.LeadTime < 0 {
+		scheduling.LeadTime = 0
+	}
+	if scheduling.SlotSize <= 0 {
+		scheduling.SlotSize = 15 * time.Minute
+	}
+	if scheduling.SlotCapacity < 0 {
+		scheduling.SlotCapacity = 0
+	}
+	if scheduling.MaxDaysAhead < 0 {
+		scheduling.MaxDaysAhead = 0
+	}
+	return scheduling
+}
@@ -0,0 +1,25 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Teste das regras de agendamento: padrões e valores inválidos
+func TestLoadScheduledOrders(t *testing.T) {
+	t.Setenv("SCHEDULED_LEAD_MINUTES", "")
+	t.Setenv("SCHEDULED_SLOT_MINUTES", "")
+	t.Setenv("SCHEDULED_SLOT_MAX_ORDERS", "")
+	scheduling := LoadScheduledOrders()
+	assert.Equal(t, 30*time.Minute, scheduling.LeadTime)
+	assert.Equal(t, 15*time.Minute, scheduling.SlotSize)
+	assert.Equal(t, 10, scheduling.SlotCapacity)
+
+	t.Setenv("SCHEDULED_SLOT_MINUTES", "0")
+	t.Setenv("SCHEDULED_SLOT_MAX_ORDERS", "-1")
+	scheduling = LoadScheduledOrders()
+	assert.Equal(t, 15*time.Minute, scheduling.SlotSize)
+	assert.Equal(t, 0, scheduling.SlotCapacity)
+}
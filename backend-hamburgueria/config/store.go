@@ -0,0 +1,72 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	// Base de fusos horários embutida - garante o fuso da loja mesmo em imagens sem tzdata
+	_ "time/tzdata"
+)
+
+// StoreLocation retorna o fuso horário da loja
+// Definido por STORE_TIMEZONE (padrão America/Sao_Paulo)
+func StoreLocation() *time.Location {
+	name := os.Getenv("STORE_TIMEZONE")
+	if name == "" {
+		name = "America/Sao_Paulo"
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.Local
+	}
+	return loc
+}
+
+// StoreID retorna o identificador desta loja (STORE_ID, padrão 1)
+// Separa as senhas do dia quando várias lojas usam o mesmo banco
+func StoreID() int {
+	return getEnvInt("STORE_ID", 1)
+}
+
+// StoreCoordinates retorna a localização da loja (STORE_LATITUDE / STORE_LONGITUDE)
+// ok = false quando não configurada; zonas de entrega por raio dependem dela
+func StoreCoordinates() (latitude, longitude float64, ok bool) {
+	latitude, errLat := strconv.ParseFloat(os.Getenv("STORE_LATITUDE"), 64)
+	longitude, errLng := strconv.ParseFloat(os.Getenv("STORE_LONGITUDE"), 64)
+	if errLat != nil || errLng != nil {
+		return 0, 0, false
+	}
+	return latitude, longitude, true
+}
+
+// ServiceDayStart retorna o início do dia de operação que contém "now"
+// O dia de operação vira em SERVICE_DAY_START (padrão 04:00, horário da loja),
+// então um pedido às 01:30 ainda pertence ao expediente da noite anterior
+func ServiceDayStart(now time.Time) time.Time {
+	loc := StoreLocation()
+	hour, minute := parseClock(os.Getenv("SERVICE_DAY_START"), 4, 0)
+
+	local := now.In(loc)
+	start := time.Date(local.Year(), local.Month(), local.Day(), hour, minute, 0, 0, loc)
+	if local.Before(start) {
+		start = start.AddDate(0, 0, -1)
+	}
+	return start
+}
+
+// parseClock converte "HH:MM" em hora e minuto
+// Valores vazios ou inválidos usam o padrão informado
+func parseClock(raw string, defaultHour, defaultMinute int) (int, int) {
+	parts := strings.SplitN(strings.TrimSpace(raw), ":", 2)
+	if len(parts) != 2 {
+		return defaultHour, defaultMinute
+	}
+	hour, errHour := strconv.Atoi(parts[0])
+	minute, errMinute := strconv.Atoi(parts[1])
+	if errHour != nil || errMinute != nil || hour < 0 || hour > 23 || minute < 0 || minute > 59 {
+		return defaultHour, defaultMinute
+	}
+	return hour, minute
+}
@@ -0,0 +1,27 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Teste da virada do dia de operação
+func TestServiceDayStart(t *testing.T) {
+	t.Setenv("STORE_TIMEZONE", "America/Sao_Paulo")
+	t.Setenv("SERVICE_DAY_START", "04:00")
+	loc := StoreLocation()
+
+	// 20h: o dia de operação começou às 04h do mesmo dia
+	evening := time.Date(2026, 10, 19, 20, 0, 0, 0, loc)
+	assert.Equal(t, time.Date(2026, 10, 19, 4, 0, 0, 0, loc), ServiceDayStart(evening))
+
+	// 01h30: ainda é o expediente que começou no dia anterior
+	lateNight := time.Date(2026, 10, 20, 1, 30, 0, 0, loc)
+	assert.Equal(t, time.Date(2026, 10, 19, 4, 0, 0, 0, loc), ServiceDayStart(lateNight))
+
+	// Horário inválido volta para o padrão (04:00)
+	t.Setenv("SERVICE_DAY_START", "25:99")
+	assert.Equal(t, time.Date(2026, 10, 19, 4, 0, 0, 0, loc), ServiceDayStart(evening))
+}
@@ -0,0 +1,25 @@
+package config
+
+import (
+	"os"
+	"strings"
+)
+
+// PublicMenuURL retorna o endereço do cardápio aberto pelos clientes (PUBLIC_MENU_URL)
+// É o destino dos QR codes das mesas
+func PublicMenuURL() string {
+	url := strings.TrimRight(os.Getenv("PUBLIC_MENU_URL"), "/")
+	if url == "" {
+		url = "http://localhost:5173"
+	}
+	return url
+}
+
+// TableQRSecret retorna a chave que assina os QR codes das mesas
+// Usa TABLE_QR_SECRET e, se não definida, JWT_SECRET; vazio = QR desativado
+func TableQRSecret() string {
+	if secret := os.Getenv("TABLE_QR_SECRET"); secret != "" {
+		return secret
+	}
+	return os.Getenv("JWT_SECRET")
+}
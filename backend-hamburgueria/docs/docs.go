@@ -15,109 +15,187 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/categories": {
+        "/api/addresses": {
             "get": {
-                "description": "Retorna todas as categorias disponíveis",
+                "description": "Retorna os endereços salvos para o telefone informado",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Categories"
+                    "Delivery"
+                ],
+                "summary": "Lista os endereços do cliente",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Telefone do cliente",
+                        "name": "phone",
+                        "in": "query",
+                        "required": true
+                    }
                 ],
-                "summary": "Lista todas as categorias",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.Category"
+                                "$ref": "#/definitions/models.CustomerAddress"
                             }
                         }
+                    },
+                    "400": {
+                        "description": "Telefone inválido",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro ao buscar endereços",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
                     }
                 }
-            }
-        },
-        "/api/ingredients": {
-            "get": {
-                "description": "Retorna todos os ingredientes disponíveis",
+            },
+            "post": {
+                "description": "Cadastra um endereço do cliente (identificado pelo telefone) com coordenadas",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Ingredients"
+                    "Delivery"
+                ],
+                "summary": "Salva um endereço de entrega",
+                "parameters": [
+                    {
+                        "description": "Endereço",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CustomerAddress"
+                        }
+                    }
                 ],
-                "summary": "Lista todos os ingredientes",
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Ingredient"
-                            }
+                            "$ref": "#/definitions/models.CustomerAddress"
+                        }
+                    },
+                    "400": {
+                        "description": "Dados inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro ao salvar endereço",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/api/orders": {
+        "/api/bill-splits/{id}": {
             "get": {
-                "description": "Retorna todos os pedidos",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Tables"
+                ],
+                "summary": "Detalhes da divisão da conta",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID da divisão",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Lista todos os pedidos",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Order"
-                            }
+                            "$ref": "#/definitions/models.BillSplit"
+                        }
+                    },
+                    "404": {
+                        "description": "Divisão não encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/api/orders/{id}": {
-            "get": {
-                "description": "Retorna todos os detalhes de um pedido específico",
+        "/api/bill-splits/{id}/shares/{share_id}/pay": {
+            "post": {
+                "description": "Marca a parcela como paga; quando todas estão pagas a divisão fica como paid",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Tables"
                 ],
-                "summary": "Detalhes de um pedido",
+                "summary": "Registra o pagamento de uma parcela",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID do pedido",
+                        "description": "ID da divisão",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID da parcela",
+                        "name": "share_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Forma de pagamento",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.PayBillShareRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Order"
+                            "$ref": "#/definitions/models.BillSplit"
                         }
                     },
                     "400": {
-                        "description": "ID do pedido inválido",
+                        "description": "Forma de pagamento inválida",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Pedido não encontrado",
+                        "description": "Parcela não encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Parcela já paga ou divisão cancelada",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -125,9 +203,120 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/orders/{id}/status": {
+        "/api/board": {
+            "get": {
+                "description": "Retorna apenas os números de senha \"Preparando\" e \"Pronto\" do dia de operação atual (sem nomes ou valores)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Board"
+                ],
+                "summary": "Painel de pedidos",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.OrderBoard"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro ao montar painel",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/board/stream": {
+            "get": {
+                "description": "Stream SSE que envia o painel completo (evento \"board\") ao conectar e a cada mudança",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "Board"
+                ],
+                "summary": "Stream do painel de pedidos",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.OrderBoard"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/carts": {
+            "post": {
+                "description": "Cria um carrinho no servidor, compartilhado pelo totem e pelo app; os dados do pedido são opcionais",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Carts"
+                ],
+                "summary": "Cria um carrinho",
+                "parameters": [
+                    {
+                        "description": "Dados do pedido",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.CartDetails"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Cart"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/carts/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Carts"
+                ],
+                "summary": "Detalhes do carrinho",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do carrinho",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Cart"
+                        }
+                    },
+                    "404": {
+                        "description": "Carrinho não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
             "put": {
-                "description": "Atualiza o status de um pedido específico",
+                "description": "Substitui tipo do pedido, cliente, mesa, entrega, observações e gorjeta (o cupom tem rota própria)",
                 "consumes": [
                     "application/json"
                 ],
@@ -135,42 +324,42 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Carts"
                 ],
-                "summary": "Atualiza o status de um pedido",
+                "summary": "Atualiza os dados do pedido no carrinho",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "ID do pedido",
+                        "type": "string",
+                        "description": "ID do carrinho",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Novo status do pedido",
+                        "description": "Dados do pedido",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.UpdateOrderStatusRequest"
+                            "$ref": "#/definitions/models.CartDetails"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Status atualizado com sucesso",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.StatusResponse"
+                            "$ref": "#/definitions/models.Cart"
                         }
                     },
-                    "400": {
-                        "description": "Status inválido",
+                    "404": {
+                        "description": "Carrinho não encontrado",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Erro ao atualizar status",
+                    "410": {
+                        "description": "Carrinho expirado",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -178,232 +367,5547 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/products": {
-            "get": {
-                "description": "Retorna todos os produtos disponíveis",
+        "/api/carts/{id}/checkout": {
+            "post": {
+                "description": "Cria o pedido com os itens e dados do carrinho, com as mesmas regras de CreateOrder; o carrinho fica como checked_out",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Carts"
+                ],
+                "summary": "Fecha o carrinho",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do carrinho",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Lista todos os produtos",
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Mesma resposta de POST /api/orders, com cart_id",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Product"
-                            }
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Carrinho vazio ou incompleto",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Carrinho não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Carrinho já foi fechado",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "Carrinho expirado",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "models.Category": {
-            "type": "object",
-            "properties": {
-                "created_at": {
-                    "description": "Data de criação",
-                    "type": "string"
-                },
-                "description": {
-                    "description": "Descrição da categoria",
-                    "type": "string"
-                },
-                "id": {
-                    "description": "ID único da categoria",
-                    "type": "integer"
-                },
-                "name": {
-                    "description": "Nome da categoria",
-                    "type": "string"
+        },
+        "/api/carts/{id}/coupon": {
+            "post": {
+                "description": "Confere o cupom com o cálculo de preço do pedido antes de guardá-lo",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Carts"
+                ],
+                "summary": "Aplica um cupom ao carrinho",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do carrinho",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Código do cupom",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ApplyCouponRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Cart"
+                        }
+                    },
+                    "400": {
+                        "description": "Cupom inválido",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Carts"
+                ],
+                "summary": "Remove o cupom do carrinho",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do carrinho",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Cart"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/carts/{id}/items": {
+            "post": {
+                "description": "Valida o produto e as escolhas (ingredientes do lanche personalizado, etapas do combo) com o cálculo de preço do pedido; allergies são as alergias de quem vai consumir o item",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Carts"
+                ],
+                "summary": "Adiciona um item ao carrinho",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do carrinho",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Item",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.OrderItemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Cart"
+                        }
+                    },
+                    "400": {
+                        "description": "Item inválido",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Carrinho não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "Carrinho expirado",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/carts/{id}/items/{item_id}": {
+            "put": {
+                "description": "Altera quantidade, ingredientes, escolhas do combo, alergias e observações; o produto do item não muda",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Carts"
+                ],
+                "summary": "Altera um item do carrinho",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do carrinho",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID do item",
+                        "name": "item_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Item",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.OrderItemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Cart"
+                        }
+                    },
+                    "400": {
+                        "description": "Item inválido",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Item não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Carts"
+                ],
+                "summary": "Remove um item do carrinho",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do carrinho",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID do item",
+                        "name": "item_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Cart"
+                        }
+                    },
+                    "404": {
+                        "description": "Item não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/carts/{id}/quote": {
+            "get": {
+                "description": "Preços, descontos, taxas e total do carrinho com o mesmo cálculo e as mesmas verificações do pedido",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Carts"
+                ],
+                "summary": "Cota o carrinho",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do carrinho",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.OrderQuote"
+                        }
+                    },
+                    "400": {
+                        "description": "Carrinho incompleto ou inválido",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Carrinho não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Carrinho já foi fechado, horário agendado esgotado ou alergia não confirmada",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "Carrinho expirado",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Loja fechada ou pedidos pausados",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/categories": {
+            "get": {
+                "description": "Retorna todas as categorias disponíveis",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Categories"
+                ],
+                "summary": "Lista todas as categorias",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Category"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/categories/{id}/schedules": {
+            "get": {
+                "description": "Retorna as janelas em que os produtos da categoria podem ser pedidos (vazio = o dia todo)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Categories"
+                ],
+                "summary": "Horários de venda de uma categoria",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID da categoria",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.AvailabilitySchedule"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "ID inválido",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Substitui as janelas de venda da categoria; valem para todos os produtos dela, junto com as do produto",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Categories"
+                ],
+                "summary": "Define os horários de venda de uma categoria",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID da categoria",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Janelas de venda",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.SetSchedulesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.AvailabilitySchedule"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Dados inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Categoria não encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/coupons": {
+            "get": {
+                "description": "Retorna todos os cupons cadastrados com a quantidade de usos",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Coupons"
+                ],
+                "summary": "Lista os cupons",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Coupon"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erro ao buscar cupons",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Cadastra um cupom percentual ou de valor fixo, com pedido mínimo, limites de uso, validade e itens participantes",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Coupons"
+                ],
+                "summary": "Cria um cupom",
+                "parameters": [
+                    {
+                        "description": "Dados do cupom",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CouponRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Coupon"
+                        }
+                    },
+                    "400": {
+                        "description": "Dados inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Código já cadastrado",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/coupons/{id}": {
+            "put": {
+                "description": "Substitui os dados do cupom; os usos já registrados são mantidos",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Coupons"
+                ],
+                "summary": "Altera um cupom",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID do cupom",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Dados do cupom",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CouponRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Coupon"
+                        }
+                    },
+                    "400": {
+                        "description": "Dados inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Cupom não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Código já cadastrado",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/delivery/quote": {
+            "get": {
+                "description": "Informa a zona, a taxa e o pedido mínimo para um endereço salvo (address_id) ou coordenadas (latitude/longitude)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Delivery"
+                ],
+                "summary": "Calcula a taxa de entrega",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID do endereço salvo",
+                        "name": "address_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Latitude do destino",
+                        "name": "latitude",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Longitude do destino",
+                        "name": "longitude",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.DeliveryQuote"
+                        }
+                    },
+                    "400": {
+                        "description": "Endereço fora da área de entrega",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Endereço de entrega não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/delivery/zones": {
+            "get": {
+                "description": "Retorna as zonas de entrega com taxa e pedido mínimo, na ordem de prioridade",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Delivery"
+                ],
+                "summary": "Lista as zonas de entrega",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.DeliveryZone"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erro ao buscar zonas de entrega",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Cadastra uma zona por raio (km a partir da loja) ou por polígono, com taxa e pedido mínimo",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Delivery"
+                ],
+                "summary": "Cria uma zona de entrega",
+                "parameters": [
+                    {
+                        "description": "Zona de entrega",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.DeliveryZone"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.DeliveryZone"
+                        }
+                    },
+                    "400": {
+                        "description": "Dados inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro ao criar zona de entrega",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/drivers": {
+            "get": {
+                "description": "Retorna os entregadores ativos com status e última posição (filtro opcional ?status=)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Drivers"
+                ],
+                "summary": "Lista os entregadores",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "offline, available ou on_delivery",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Driver"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erro ao buscar entregadores",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Cadastra um entregador (começa offline até ficar disponível)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Drivers"
+                ],
+                "summary": "Cadastra um entregador",
+                "parameters": [
+                    {
+                        "description": "Entregador",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Driver"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Driver"
+                        }
+                    },
+                    "400": {
+                        "description": "Dados inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro ao cadastrar entregador",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/drivers/{id}/position": {
+            "post": {
+                "description": "Atualiza a posição do entregador e notifica o rastreamento dos pedidos em rota",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Drivers"
+                ],
+                "summary": "Envia a posição do entregador",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID do entregador",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Posição atual",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.DriverPositionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.StatusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Coordenadas inválidas",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Entregador não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/drivers/{id}/status": {
+            "put": {
+                "description": "Coloca o entregador como disponível ou offline (não vale durante uma entrega)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Drivers"
+                ],
+                "summary": "Altera a disponibilidade do entregador",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID do entregador",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Novo status",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.DriverStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.StatusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Status inválido",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Entregador não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Entregador em entrega",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/ingredients": {
+            "get": {
+                "description": "Retorna todos os ingredientes disponíveis, com alérgenos, dietas e calorias por porção",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Ingredients"
+                ],
+                "summary": "Lista todos os ingredientes",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Ingredient"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/ingredients/{id}/dietary": {
+            "put": {
+                "description": "Substitui os alérgenos, as dietas atendidas e as calorias por porção; vegan implica vegetarian",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Ingredients"
+                ],
+                "summary": "Define alérgenos, dietas e calorias de um ingrediente",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID do ingrediente",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Alérgenos, dietas e calorias",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.IngredientDietaryRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Ingredient"
+                        }
+                    },
+                    "400": {
+                        "description": "Dados inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Ingrediente não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/kitchen/all-day": {
+            "get": {
+                "description": "Agrupa os itens em aberto (pedidos pendentes/em preparo) por produto e customização; itens com alerta de alergia ficam em grupos separados, no topo",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Kitchen"
+                ],
+                "summary": "Contagem \"all-day\" da cozinha",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.AllDayCount"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erro ao buscar itens em aberto",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/kitchen/all-day/bump": {
+            "post": {
+                "description": "Marca como preparados os itens em aberto de um produto/customização em vários pedidos de uma vez (do mais antigo para o mais novo); um item com mais unidades que o restante do lote é dividido",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Kitchen"
+                ],
+                "summary": "Finaliza um lote de itens iguais",
+                "parameters": [
+                    {
+                        "description": "Lote a finalizar",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.BumpBatchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.BumpBatchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Dados inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Nenhum item em aberto para este lote",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro ao finalizar lote",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/kitchen/wait": {
+            "get": {
+                "description": "Retorna a espera estimada para um pedido novo e a ocupação das estações (público, para a tela do cardápio)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Kitchen"
+                ],
+                "summary": "Espera estimada da cozinha",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.KitchenWaitResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro ao calcular espera",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/orders": {
+            "get": {
+                "description": "Retorna todos os pedidos, com filtros opcionais por status e tipo",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Lista todos os pedidos",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Status do pedido",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tipo do pedido (dine_in, takeaway, delivery)",
+                        "name": "type",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Order"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Tipo de pedido inválido",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/orders/quote": {
+            "post": {
+                "description": "Calcula os preços dos itens, ingredientes, descontos, taxas e o total com o mesmo cálculo e as mesmas verificações de CreateOrder (loja aberta, horário agendado, mesa e alergias), sem gravar nada",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Cota um pedido",
+                "parameters": [
+                    {
+                        "description": "Mesmo corpo da criação do pedido",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateOrderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.OrderQuote"
+                        }
+                    },
+                    "400": {
+                        "description": "Dados inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Horário agendado esgotado ou alergia não confirmada",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Loja fechada ou pedidos pausados",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/orders/ticket/{number}": {
+            "get": {
+                "description": "Retorna os detalhes do pedido com a senha informada no dia de operação atual (ou no dia indicado em ?day=YYYY-MM-DD)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Busca pedido pela senha do dia",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Senha do pedido",
+                        "name": "number",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Dia de operação (YYYY-MM-DD)",
+                        "name": "day",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Order"
+                        }
+                    },
+                    "400": {
+                        "description": "Senha inválida",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Pedido não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/orders/{id}": {
+            "get": {
+                "description": "Retorna todos os detalhes de um pedido específico",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Detalhes de um pedido",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID do pedido",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Order"
+                        }
+                    },
+                    "400": {
+                        "description": "ID do pedido inválido",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Pedido não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/orders/{id}/delivery-status": {
+            "put": {
+                "description": "Marca o pedido como retirado pelo entregador (picked_up) ou entregue (delivered)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Drivers"
+                ],
+                "summary": "Atualiza o andamento da entrega",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID do pedido",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Andamento da entrega",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.DeliveryStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.StatusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Status inválido",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Pedido não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Transição inválida",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/orders/{id}/dispatch": {
+            "post": {
+                "description": "Atribui um pedido pronto ao entregador informado ou, sem driver_id, ao disponível mais próximo da loja",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Drivers"
+                ],
+                "summary": "Atribui um pedido de entrega a um entregador",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID do pedido",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Entregador (opcional)",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.DispatchOrderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Driver"
+                        }
+                    },
+                    "400": {
+                        "description": "Pedido não é de entrega",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Pedido não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Nenhum entregador disponível",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/orders/{id}/history": {
+            "get": {
+                "description": "Retorna os eventos do pedido (criação e mudanças de status) em ordem cronológica",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Histórico de um pedido",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID do pedido",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.OrderHistoryEntry"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "ID do pedido inválido",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro ao buscar histórico",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/orders/{id}/kitchen-ticket": {
+            "get": {
+                "description": "Texto pronto para a impressora térmica, com as alergias do cliente e os itens em conflito em destaque",
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "Kitchen"
+                ],
+                "summary": "Ticket impresso da cozinha",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID do pedido",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ticket em texto",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "ID inválido",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Pedido não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/orders/{id}/split": {
+            "post": {
+                "description": "Divide um pedido fora de comanda em parcelas iguais (equal), por itens (items) ou por valores (custom)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Divide a conta de um pedido",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID do pedido",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Modo e parcelas",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateBillSplitRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.BillSplit"
+                        }
+                    },
+                    "400": {
+                        "description": "Dados inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Pedido não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conta já tem parcelas pagas",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/orders/{id}/status": {
+            "put": {
+                "description": "Atualiza o status de um pedido específico",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Atualiza o status de um pedido",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID do pedido",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Novo status do pedido",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateOrderStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Status atualizado com sucesso",
+                        "schema": {
+                            "$ref": "#/definitions/models.StatusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Status inválido",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Pedido não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro ao atualizar status",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/orders/{id}/tracking": {
+            "get": {
+                "description": "Retorna o entregador, a distância restante e a previsão de chegada do pedido",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Drivers"
+                ],
+                "summary": "Rastreamento da entrega",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID do pedido",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.OrderTracking"
+                        }
+                    },
+                    "400": {
+                        "description": "Pedido não é de entrega",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Pedido não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/orders/{id}/tracking/stream": {
+            "get": {
+                "description": "Stream SSE que envia o rastreamento (evento \"tracking\") ao conectar e a cada mudança; encerra após a entrega",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "Drivers"
+                ],
+                "summary": "Stream do rastreamento da entrega",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID do pedido",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.OrderTracking"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/products": {
+            "get": {
+                "description": "Retorna todos os produtos disponíveis, com alérgenos, dietas e calorias da receita",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Lista todos os produtos",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Alérgenos a evitar, separados por vírgula (ex.: gluten,lactose)",
+                        "name": "exclude_allergens",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Somente produtos da dieta (vegan, vegetarian)",
+                        "name": "diet",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Product"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Filtro inválido",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/products/{id}/bundle": {
+            "get": {
+                "description": "Retorna o produto do combo com as etapas e os produtos aceitos em cada uma (com acréscimo)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Etapas de um combo",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID do produto",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Bundle"
+                        }
+                    },
+                    "404": {
+                        "description": "Combo não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Substitui as etapas do produto e o marca como combo; uma lista vazia volta a ser produto simples",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Define as etapas de um combo",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID do produto",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Etapas do combo",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.SetBundleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Bundle"
+                        }
+                    },
+                    "400": {
+                        "description": "Dados inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Produto não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/products/{id}/recipe": {
+            "get": {
+                "description": "Retorna os ingredientes do produto, de onde vêm os alérgenos, dietas e calorias exibidos no cardápio",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Receita de um produto",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID do produto",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.RecipeIngredient"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "ID inválido",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Substitui os ingredientes do produto; lista vazia = produto sem alérgenos e dietas declarados",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Define a receita de um produto",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID do produto",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Ingredientes da receita",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.SetRecipeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.RecipeIngredient"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Dados inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Produto não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/products/{id}/schedules": {
+            "get": {
+                "description": "Retorna as janelas em que o produto aparece no cardápio e pode ser pedido (vazio = o dia todo)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Horários de venda de um produto",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID do produto",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.AvailabilitySchedule"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "ID inválido",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Substitui as janelas de venda do produto (dias da semana, horário e período); lista vazia = o dia todo",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Define os horários de venda de um produto",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID do produto",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Janelas de venda",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.SetSchedulesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.AvailabilitySchedule"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Dados inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Produto não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/products/{id}/variants": {
+            "get": {
+                "description": "Retorna os tamanhos/versões do produto, incluindo os indisponíveis",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Variações de um produto",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID do produto",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.ProductVariant"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "ID inválido",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Cadastra um tamanho/versão do produto; a partir daí o pedido precisa informar a variação",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Cria uma variação",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID do produto",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Dados da variação",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ProductVariantRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.ProductVariant"
+                        }
+                    },
+                    "400": {
+                        "description": "Dados inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Produto não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Nome ou SKU já cadastrado",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/products/{id}/variants/{variant_id}": {
+            "put": {
+                "description": "Substitui nome, SKU, preço, disponibilidade e ordem da variação; pedidos já feitos mantêm os dados da época",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Altera uma variação",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID do produto",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID da variação",
+                        "name": "variant_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Dados da variação",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ProductVariantRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ProductVariant"
+                        }
+                    },
+                    "400": {
+                        "description": "Dados inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Variação não encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Nome ou SKU já cadastrado",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/promotions": {
+            "get": {
+                "description": "Retorna as promoções automáticas cadastradas",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Promotions"
+                ],
+                "summary": "Lista as promoções",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Promotion"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erro ao buscar promoções",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Cadastra uma promoção automática: percentual, preço promocional ou leve X ganhe Y, com dias e horário",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Promotions"
+                ],
+                "summary": "Cria uma promoção",
+                "parameters": [
+                    {
+                        "description": "Promoção",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Promotion"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Promotion"
+                        }
+                    },
+                    "400": {
+                        "description": "Dados inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro ao criar promoção",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/promotions/{id}": {
+            "put": {
+                "description": "Substitui as regras da promoção (is_active=false desativa)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Promotions"
+                ],
+                "summary": "Altera uma promoção",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID da promoção",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Promoção",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Promotion"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Promotion"
+                        }
+                    },
+                    "400": {
+                        "description": "Dados inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Promoção não encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/store/exceptions/{date}": {
+            "put": {
+                "description": "Fecha a loja numa data (feriado) ou define um horário especial, substituindo o horário semanal do dia",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Store"
+                ],
+                "summary": "Define uma exceção de horário",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Data (YYYY-MM-DD)",
+                        "name": "date",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Exceção",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.StoreException"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.StoreException"
+                        }
+                    },
+                    "400": {
+                        "description": "Dados inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "A data volta a seguir o horário semanal",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Store"
+                ],
+                "summary": "Remove uma exceção de horário",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Data (YYYY-MM-DD)",
+                        "name": "date",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.StatusResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Exceção não encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/store/hours": {
+            "get": {
+                "description": "Retorna o horário semanal da loja e as exceções (feriados, horários especiais) de hoje em diante",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Store"
+                ],
+                "summary": "Horário de funcionamento",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.StoreHoursConfig"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro ao buscar horário da loja",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Substitui os períodos de funcionamento de cada dia da semana; lista vazia = aberta o dia todo",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Store"
+                ],
+                "summary": "Define o horário semanal",
+                "parameters": [
+                    {
+                        "description": "Horário semanal",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.SetStoreHoursRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.StoreHoursConfig"
+                        }
+                    },
+                    "400": {
+                        "description": "Dados inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/store/pause": {
+            "put": {
+                "description": "Para de aceitar pedidos (cozinha cheia, falta de insumo...), com motivo e retomada automática opcional",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Store"
+                ],
+                "summary": "Pausa os pedidos",
+                "parameters": [
+                    {
+                        "description": "Motivo e retomada",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.PauseStoreRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.StoreStatus"
+                        }
+                    },
+                    "400": {
+                        "description": "Dados inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Encerra a pausa manual; a loja volta a aceitar pedidos se estiver no horário",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Store"
+                ],
+                "summary": "Retoma os pedidos",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.StoreStatus"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/store/status": {
+            "get": {
+                "description": "Informa se a loja está aberta, fechada ou com os pedidos pausados, com o próximo horário de abertura ou fechamento",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Store"
+                ],
+                "summary": "Situação da loja",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.StoreStatus"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro ao buscar horário da loja",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/table-sessions": {
+            "get": {
+                "description": "Retorna as comandas com consumo acumulado (padrão: somente as abertas; ?status=closed ou ?status=merged)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tables"
+                ],
+                "summary": "Lista as comandas de mesa",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "open (padrão), closed ou merged",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.TableSession"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Status inválido",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro ao buscar comandas",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Abre a comanda manualmente; pedidos no salão também abrem a comanda automaticamente",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tables"
+                ],
+                "summary": "Abre a comanda de uma mesa",
+                "parameters": [
+                    {
+                        "description": "Mesa",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.OpenTableSessionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.TableSession"
+                        }
+                    },
+                    "400": {
+                        "description": "Número da mesa inválido",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Mesa já possui comanda aberta",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/table-sessions/{id}": {
+            "get": {
+                "description": "Retorna a comanda com todos os pedidos (rodadas) e o consumo acumulado",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tables"
+                ],
+                "summary": "Detalhes da comanda",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID da comanda",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.TableSession"
+                        }
+                    },
+                    "400": {
+                        "description": "ID da comanda inválido",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Comanda não encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/table-sessions/{id}/close": {
+            "post": {
+                "description": "Fecha a comanda e grava o valor final; recusa enquanto houver pedidos não entregues ou divisão da conta pendente",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tables"
+                ],
+                "summary": "Fecha a conta da mesa",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID da comanda",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.TableSession"
+                        }
+                    },
+                    "404": {
+                        "description": "Comanda não encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Comanda já fechada, com pedidos pendentes ou com divisão da conta pendente",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/table-sessions/{id}/merge": {
+            "post": {
+                "description": "Incorpora os pedidos de outra comanda aberta nesta; a comanda de origem fica como merged",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tables"
+                ],
+                "summary": "Junta duas mesas",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID da comanda que recebe os pedidos",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Comanda incorporada",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.MergeTableSessionsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.TableSession"
+                        }
+                    },
+                    "400": {
+                        "description": "Dados inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Comanda não encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Comanda não está aberta ou conta com parcelas pagas",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/table-sessions/{id}/split": {
+            "get": {
+                "description": "Retorna a divisão em vigor da comanda, com o andamento dos pagamentos",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tables"
+                ],
+                "summary": "Divisão atual da conta da mesa",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID da comanda",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.BillSplit"
+                        }
+                    },
+                    "404": {
+                        "description": "Conta não dividida",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Divide a comanda em parcelas iguais (equal), por itens (items) ou por valores (custom), com taxa de serviço e centavos distribuídos",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tables"
+                ],
+                "summary": "Divide a conta da mesa",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID da comanda",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Modo e parcelas",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateBillSplitRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.BillSplit"
+                        }
+                    },
+                    "400": {
+                        "description": "Dados inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Comanda não encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conta já tem parcelas pagas",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/table-sessions/{id}/transfer": {
+            "post": {
+                "description": "Move a comanda inteira, pedidos selecionados (order_ids) ou itens selecionados (items; combos vão inteiros) para outra mesa",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tables"
+                ],
+                "summary": "Transfere a mesa",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID da comanda de origem",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Destino e o que mover",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.TransferTableRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Comanda de destino",
+                        "schema": {
+                            "$ref": "#/definitions/models.TableSession"
+                        }
+                    },
+                    "400": {
+                        "description": "Dados inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Comanda não encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Mesa de destino já tem comanda aberta ou conta com parcelas pagas",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tables": {
+            "get": {
+                "description": "Retorna as mesas cadastradas com o endereço assinado do QR code de cada uma",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tables"
+                ],
+                "summary": "Lista as mesas",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Table"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Erro ao buscar mesas",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Cadastra uma mesa do salão (número único por loja)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tables"
+                ],
+                "summary": "Cadastra uma mesa",
+                "parameters": [
+                    {
+                        "description": "Mesa",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Table"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Table"
+                        }
+                    },
+                    "400": {
+                        "description": "Dados inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Mesa já cadastrada",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tables/resolve": {
+            "get": {
+                "description": "Valida o token lido do QR code e retorna a mesa (usado pelo cardápio ao abrir pelo QR)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tables"
+                ],
+                "summary": "Identifica a mesa do QR code",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token do QR code",
+                        "name": "token",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Table"
+                        }
+                    },
+                    "400": {
+                        "description": "QR da mesa inválido",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tables/{id}": {
+            "put": {
+                "description": "Altera área, lugares ou ativa/desativa a mesa",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tables"
+                ],
+                "summary": "Atualiza uma mesa",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID da mesa",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Campos a alterar",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateTableRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Table"
+                        }
+                    },
+                    "400": {
+                        "description": "Dados inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Mesa não encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tables/{id}/qr": {
+            "get": {
+                "description": "Gera o QR code (PNG ou SVG) com o endereço assinado do cardápio para a mesa",
+                "produces": [
+                    "image/png",
+                    "image/svg+xml"
+                ],
+                "tags": [
+                    "Tables"
+                ],
+                "summary": "QR code da mesa",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID da mesa",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "png (padrão) ou svg",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Tamanho em pixels (padrão 256, máx. 1024)",
+                        "name": "size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Formato inválido",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Mesa não encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "QR das mesas não configurado",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "models.AllDayCount": {
+            "type": "object",
+            "properties": {
+                "allergen_conflicts": {
+                    "description": "Alérgenos declarados pelo cliente presentes no item (preparo separado) - usar no bump",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "customization": {
+                    "description": "Customização legível: \"Pão Brioche, Carne Angus\"",
+                    "type": "string"
+                },
+                "ingredients": {
+                    "description": "Customização normalizada (JSON) - usar no bump",
+                    "type": "string"
+                },
+                "oldest_at": {
+                    "description": "Criação do pedido mais antigo do grupo",
+                    "type": "string"
+                },
+                "order_ids": {
+                    "description": "Pedidos que contêm o item",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "product_id": {
+                    "description": "ID do produto",
+                    "type": "integer"
+                },
+                "product_name": {
+                    "description": "Nome do produto (com a variação)",
+                    "type": "string"
+                },
+                "quantity": {
+                    "description": "Quantidade total em aberto",
+                    "type": "integer"
+                },
+                "variant_id": {
+                    "description": "Variação (tamanho) - usar no bump",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.AppliedDiscount": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "description": "Valor do desconto",
+                    "type": "number"
+                },
+                "code": {
+                    "description": "Código do cupom",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Descrição exibida ao cliente",
+                    "type": "string"
+                },
+                "promotion_id": {
+                    "description": "Promoção automática aplicada",
+                    "type": "integer"
+                },
+                "source": {
+                    "description": "Origem: coupon ou promotion",
+                    "type": "string"
+                }
+            }
+        },
+        "models.ApplyCouponRequest": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "Código do cupom",
+                    "type": "string"
+                }
+            }
+        },
+        "models.AvailabilitySchedule": {
+            "type": "object",
+            "properties": {
+                "end_date": {
+                    "description": "Último dia (YYYY-MM-DD, inclusivo)",
+                    "type": "string"
+                },
+                "end_time": {
+                    "description": "Fim do horário (HH:MM, exclusivo)",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "ID da janela",
+                    "type": "integer"
+                },
+                "start_date": {
+                    "description": "Primeiro dia (YYYY-MM-DD)",
+                    "type": "string"
+                },
+                "start_time": {
+                    "description": "Início do horário (HH:MM, horário da loja)",
+                    "type": "string"
+                },
+                "weekdays": {
+                    "description": "Dias da semana (0 = domingo; vazio = todos)",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "models.BillShare": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "description": "Valor a pagar",
+                    "type": "number"
+                },
+                "id": {
+                    "description": "ID único da parcela",
+                    "type": "integer"
+                },
+                "item_ids": {
+                    "description": "Itens da parcela (modo items)",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "label": {
+                    "description": "Identificação (ex.: \"Pessoa 1\", nome)",
+                    "type": "string"
+                },
+                "paid_at": {
+                    "description": "Pagamento da parcela",
+                    "type": "string"
+                },
+                "payment_method": {
+                    "description": "Forma de pagamento (cash, card, pix)",
+                    "type": "string"
+                },
+                "service_charge": {
+                    "description": "Taxa de serviço da parcela",
+                    "type": "number"
+                },
+                "subtotal": {
+                    "description": "Consumo da parcela",
+                    "type": "number"
+                }
+            }
+        },
+        "models.BillShareRequest": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "description": "Valor do consumo sem taxa (modo custom)",
+                    "type": "number"
+                },
+                "item_ids": {
+                    "description": "Itens consumidos (modo items); item em várias parcelas é dividido entre elas",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "label": {
+                    "description": "Identificação (opcional)",
+                    "type": "string"
+                }
+            }
+        },
+        "models.BillSplit": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "Criação da divisão",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "ID único da divisão",
+                    "type": "integer"
+                },
+                "mode": {
+                    "description": "Modo: equal, items ou custom",
+                    "type": "string"
+                },
+                "order_id": {
+                    "description": "Pedido dividido (fora de comanda)",
+                    "type": "integer"
+                },
+                "paid_amount": {
+                    "description": "Soma das parcelas já pagas",
+                    "type": "number"
+                },
+                "service_charge": {
+                    "description": "Taxa de serviço somada às parcelas",
+                    "type": "number"
+                },
+                "shares": {
+                    "description": "Parcelas",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.BillShare"
+                    }
+                },
+                "status": {
+                    "description": "Status: open, paid ou cancelled",
+                    "type": "string"
+                },
+                "subtotal": {
+                    "description": "Valor da conta sem taxa de serviço",
+                    "type": "number"
+                },
+                "table_session_id": {
+                    "description": "Comanda dividida",
+                    "type": "integer"
+                },
+                "total": {
+                    "description": "Total da conta (soma das parcelas)",
+                    "type": "number"
+                }
+            }
+        },
+        "models.BoardTicket": {
+            "type": "object",
+            "properties": {
+                "label": {
+                    "description": "Texto para a TV: Preparando ou Pronto",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "preparing ou ready",
+                    "type": "string"
+                },
+                "ticket_number": {
+                    "description": "Senha do pedido no dia de operação",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.BumpBatchRequest": {
+            "type": "object",
+            "properties": {
+                "allergen_conflicts": {
+                    "description": "Alerta de alergia do grupo (como retornado no all-day)",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "ingredients": {
+                    "description": "Customização do grupo (como retornada no all-day)",
+                    "type": "string"
+                },
+                "product_id": {
+                    "description": "ID do produto",
+                    "type": "integer"
+                },
+                "quantity": {
+                    "description": "Unidades a finalizar (0 = todas)",
+                    "type": "integer"
+                },
+                "variant_id": {
+                    "description": "Variação do grupo (como retornada no all-day)",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.BumpBatchResponse": {
+            "type": "object",
+            "properties": {
+                "bumped_item_ids": {
+                    "description": "Itens marcados como preparados",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "bumped_units": {
+                    "description": "Unidades finalizadas",
+                    "type": "integer"
+                },
+                "message": {
+                    "description": "Mensagem de sucesso",
+                    "type": "string"
+                },
+                "ready_order_ids": {
+                    "description": "Pedidos que ficaram prontos com o bump",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "models.Bundle": {
+            "type": "object",
+            "properties": {
+                "product": {
+                    "description": "Produto do combo (preço base)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Product"
+                        }
+                    ]
+                },
+                "slots": {
+                    "description": "Etapas de escolha",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.BundleSlot"
+                    }
+                }
+            }
+        },
+        "models.BundleChoice": {
+            "type": "object",
+            "properties": {
+                "product_id": {
+                    "description": "Produto escolhido",
+                    "type": "integer"
+                },
+                "slot_id": {
+                    "description": "Etapa",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.BundleComponent": {
+            "type": "object",
+            "properties": {
+                "product_id": {
+                    "description": "Produto escolhido",
+                    "type": "integer"
+                },
+                "product_name": {
+                    "description": "Nome do produto",
+                    "type": "string"
+                },
+                "slot_id": {
+                    "description": "Etapa",
+                    "type": "integer"
+                },
+                "slot_name": {
+                    "description": "Nome da etapa",
+                    "type": "string"
+                },
+                "unit_price": {
+                    "description": "Parte do preço do combo atribuída ao produto",
+                    "type": "number"
+                },
+                "upcharge": {
+                    "description": "Acréscimo cobrado",
+                    "type": "number"
+                }
+            }
+        },
+        "models.BundleOption": {
+            "type": "object",
+            "properties": {
+                "is_default": {
+                    "description": "Escolha usada quando o cliente não informa",
+                    "type": "boolean"
+                },
+                "product_id": {
+                    "description": "Produto",
+                    "type": "integer"
+                },
+                "product_name": {
+                    "description": "Nome do produto",
+                    "type": "string"
+                },
+                "upcharge": {
+                    "description": "Acréscimo ao preço do combo",
+                    "type": "number"
+                }
+            }
+        },
+        "models.BundleSlot": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "description": "ID da etapa",
+                    "type": "integer"
+                },
+                "is_required": {
+                    "description": "Escolha obrigatória",
+                    "type": "boolean"
+                },
+                "name": {
+                    "description": "Nome exibido (Lanche, Acompanhamento, Bebida)",
+                    "type": "string"
+                },
+                "options": {
+                    "description": "Produtos aceitos na etapa",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.BundleOption"
+                    }
+                },
+                "position": {
+                    "description": "Ordem no combo",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.BundleSlotRequest": {
+            "type": "object",
+            "properties": {
+                "is_required": {
+                    "description": "Obrigatória (padrão: sim)",
+                    "type": "boolean"
+                },
+                "name": {
+                    "description": "Nome da etapa",
+                    "type": "string"
+                },
+                "options": {
+                    "description": "Produtos aceitos",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.BundleOption"
+                    }
+                }
+            }
+        },
+        "models.Cart": {
+            "type": "object",
+            "properties": {
+                "allergies": {
+                    "description": "Alergias do cliente",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "allow_allergy_conflicts": {
+                    "description": "Confirmação dos itens com alérgenos declarados",
+                    "type": "boolean"
+                },
+                "coupon_code": {
+                    "description": "Cupom aplicado",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "Criação do carrinho",
+                    "type": "string"
+                },
+                "customer_name": {
+                    "description": "Nome do cliente",
+                    "type": "string"
+                },
+                "customer_phone": {
+                    "description": "Telefone",
+                    "type": "string"
+                },
+                "delivery_address": {
+                    "description": "Endereço de entrega",
+                    "type": "string"
+                },
+                "delivery_address_id": {
+                    "description": "Endereço salvo",
+                    "type": "integer"
+                },
+                "delivery_latitude": {
+                    "description": "Latitude do endereço avulso",
+                    "type": "number"
+                },
+                "delivery_longitude": {
+                    "description": "Longitude do endereço avulso",
+                    "type": "number"
+                },
+                "expires_at": {
+                    "description": "Expiração (renovada a cada alteração)",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "Identificador do carrinho (token aleatório)",
+                    "type": "string"
+                },
+                "include_service_charge": {
+                    "description": "Cobrar a taxa de serviço",
+                    "type": "boolean"
+                },
+                "items": {
+                    "description": "Itens do carrinho",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CartItem"
+                    }
+                },
+                "notes": {
+                    "description": "Observações do pedido",
+                    "type": "string"
+                },
+                "order_id": {
+                    "description": "Pedido gerado no fechamento",
+                    "type": "integer"
+                },
+                "order_type": {
+                    "description": "dine_in, takeaway ou delivery",
+                    "type": "string"
+                },
+                "pickup_name": {
+                    "description": "Nome para retirada",
+                    "type": "string"
+                },
+                "scheduled_for": {
+                    "description": "Retirada/entrega agendada",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Status: open ou checked_out",
+                    "type": "string"
+                },
+                "table_number": {
+                    "description": "Número da mesa",
+                    "type": "integer"
+                },
+                "table_token": {
+                    "description": "Token assinado do QR da mesa",
+                    "type": "string"
+                },
+                "tip": {
+                    "description": "Gorjeta",
+                    "type": "number"
+                },
+                "updated_at": {
+                    "description": "Última alteração",
+                    "type": "string"
+                }
+            }
+        },
+        "models.CartDetails": {
+            "type": "object",
+            "properties": {
+                "allergies": {
+                    "description": "Alergias do cliente",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "allow_allergy_conflicts": {
+                    "description": "Confirmação dos itens com alérgenos declarados",
+                    "type": "boolean"
+                },
+                "coupon_code": {
+                    "description": "Cupom aplicado",
+                    "type": "string"
+                },
+                "customer_name": {
+                    "description": "Nome do cliente",
+                    "type": "string"
+                },
+                "customer_phone": {
+                    "description": "Telefone",
+                    "type": "string"
+                },
+                "delivery_address": {
+                    "description": "Endereço de entrega",
+                    "type": "string"
+                },
+                "delivery_address_id": {
+                    "description": "Endereço salvo",
+                    "type": "integer"
+                },
+                "delivery_latitude": {
+                    "description": "Latitude do endereço avulso",
+                    "type": "number"
+                },
+                "delivery_longitude": {
+                    "description": "Longitude do endereço avulso",
+                    "type": "number"
+                },
+                "include_service_charge": {
+                    "description": "Cobrar a taxa de serviço",
+                    "type": "boolean"
+                },
+                "notes": {
+                    "description": "Observações do pedido",
+                    "type": "string"
+                },
+                "order_type": {
+                    "description": "dine_in, takeaway ou delivery",
+                    "type": "string"
+                },
+                "pickup_name": {
+                    "description": "Nome para retirada",
+                    "type": "string"
+                },
+                "scheduled_for": {
+                    "description": "Retirada/entrega agendada",
+                    "type": "string"
+                },
+                "table_number": {
+                    "description": "Número da mesa",
+                    "type": "integer"
+                },
+                "table_token": {
+                    "description": "Token assinado do QR da mesa",
+                    "type": "string"
+                },
+                "tip": {
+                    "description": "Gorjeta",
+                    "type": "number"
+                }
+            }
+        },
+        "models.CartItem": {
+            "type": "object",
+            "properties": {
+                "allergies": {
+                    "description": "Alergias de quem vai consumir o item",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "choices": {
+                    "description": "Escolhas das etapas (combos)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.BundleChoice"
+                    }
+                },
+                "id": {
+                    "description": "ID do item no carrinho",
+                    "type": "integer"
+                },
+                "ingredients": {
+                    "description": "JSON com os ingredientes escolhidos (lanche personalizado)",
+                    "type": "string"
+                },
+                "notes": {
+                    "description": "Observações do item",
+                    "type": "string"
+                },
+                "product_id": {
+                    "description": "Produto",
+                    "type": "integer"
+                },
+                "quantity": {
+                    "description": "Quantidade",
+                    "type": "integer"
+                },
+                "variant_id": {
+                    "description": "Variação escolhida",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Category": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "Data de criação",
+                    "type": "string"
+                },
+                "description": {
+                    "description": "Descrição da categoria",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "ID único da categoria",
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "Nome da categoria",
+                    "type": "string"
+                }
+            }
+        },
+        "models.Coupon": {
+            "type": "object",
+            "properties": {
+                "category_ids": {
+                    "description": "Categorias participantes (vazio = todas)",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "code": {
+                    "description": "Código digitado pelo cliente (maiúsculas)",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "Data de criação",
+                    "type": "string"
+                },
+                "description": {
+                    "description": "Descrição da campanha",
+                    "type": "string"
+                },
+                "ends_at": {
+                    "description": "Fim da validade",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "ID único do cupom",
+                    "type": "integer"
+                },
+                "is_active": {
+                    "description": "Cupom ativo",
+                    "type": "boolean"
+                },
+                "kind": {
+                    "description": "Tipo: percentage (%) ou fixed (R$)",
+                    "type": "string"
+                },
+                "max_uses": {
+                    "description": "Limite de usos no total (vazio = sem limite)",
+                    "type": "integer"
+                },
+                "max_uses_per_customer": {
+                    "description": "Limite de usos por cliente (pelo telefone)",
+                    "type": "integer"
+                },
+                "min_order": {
+                    "description": "Subtotal mínimo do pedido",
+                    "type": "number"
+                },
+                "product_ids": {
+                    "description": "Produtos participantes (vazio = todos)",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "starts_at": {
+                    "description": "Início da validade",
+                    "type": "string"
+                },
+                "uses_count": {
+                    "description": "Usos registrados",
+                    "type": "integer"
+                },
+                "value": {
+                    "description": "Percentual ou valor do desconto",
+                    "type": "number"
+                },
+                "weekdays": {
+                    "description": "Dias da semana válidos (0 = domingo; vazio = todos)",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "models.CouponRequest": {
+            "type": "object",
+            "properties": {
+                "category_ids": {
+                    "description": "Categorias participantes",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "code": {
+                    "description": "Código (gravado em maiúsculas)",
+                    "type": "string"
+                },
+                "description": {
+                    "description": "Descrição",
+                    "type": "string"
+                },
+                "ends_at": {
+                    "description": "Fim da validade (RFC 3339)",
+                    "type": "string"
+                },
+                "is_active": {
+                    "description": "Ativo (padrão: sim)",
+                    "type": "boolean"
+                },
+                "kind": {
+                    "description": "percentage ou fixed",
+                    "type": "string"
+                },
+                "max_uses": {
+                    "description": "Limite total",
+                    "type": "integer"
+                },
+                "max_uses_per_customer": {
+                    "description": "Limite por cliente",
+                    "type": "integer"
+                },
+                "min_order": {
+                    "description": "Subtotal mínimo",
+                    "type": "number"
+                },
+                "product_ids": {
+                    "description": "Produtos participantes",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "starts_at": {
+                    "description": "Início da validade (RFC 3339)",
+                    "type": "string"
+                },
+                "value": {
+                    "description": "Percentual (0-100) ou valor em R$",
+                    "type": "number"
+                },
+                "weekdays": {
+                    "description": "Dias da semana (0 = domingo)",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "models.CreateBillSplitRequest": {
+            "type": "object",
+            "properties": {
+                "include_service_charge": {
+                    "description": "Cobrar a taxa de serviço dos pedidos (padrão: sim)",
+                    "type": "boolean"
+                },
+                "mode": {
+                    "description": "equal, items ou custom",
+                    "type": "string"
+                },
+                "people": {
+                    "description": "Número de pessoas (modo equal)",
+                    "type": "integer"
+                },
+                "shares": {
+                    "description": "Parcelas (modos items e custom)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.BillShareRequest"
+                    }
+                }
+            }
+        },
+        "models.CreateOrderRequest": {
+            "type": "object",
+            "properties": {
+                "allergies": {
+                    "description": "Alergias do cliente (gluten, lactose, nuts...), conferidas em todos os itens",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "allow_allergy_conflicts": {
+                    "description": "Confirma o pedido mesmo com itens que contêm os alérgenos declarados",
+                    "type": "boolean"
+                },
+                "coupon_code": {
+                    "description": "Cupom de desconto (opcional)",
+                    "type": "string"
+                },
+                "customer_name": {
+                    "description": "Nome do cliente",
+                    "type": "string"
+                },
+                "customer_phone": {
+                    "description": "Telefone (obrigatório na entrega)",
+                    "type": "string"
+                },
+                "delivery_address": {
+                    "description": "Endereço (obrigatório na entrega)",
+                    "type": "string"
+                },
+                "delivery_address_id": {
+                    "description": "Endereço salvo (substitui endereço e coordenadas)",
+                    "type": "integer"
+                },
+                "delivery_latitude": {
+                    "description": "Latitude do endereço avulso",
+                    "type": "number"
+                },
+                "delivery_longitude": {
+                    "description": "Longitude do endereço avulso",
+                    "type": "number"
+                },
+                "include_service_charge": {
+                    "description": "Cobrar a taxa de serviço (padrão: conforme o tipo do pedido)",
+                    "type": "boolean"
+                },
+                "items": {
+                    "description": "Lista de itens do pedido",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.OrderItemRequest"
+                    }
+                },
+                "notes": {
+                    "description": "Observações do pedido",
+                    "type": "string"
+                },
+                "order_type": {
+                    "description": "dine_in, takeaway ou delivery (vazio: salão se houver mesa, senão retirada)",
+                    "type": "string"
+                },
+                "pickup_name": {
+                    "description": "Nome para retirada (padrão: customer_name)",
+                    "type": "string"
+                },
+                "scheduled_for": {
+                    "description": "Retirada/entrega agendada (RFC 3339; vazio = o quanto antes)",
+                    "type": "string"
+                },
+                "table_number": {
+                    "description": "Número da mesa",
+                    "type": "integer"
+                },
+                "table_token": {
+                    "description": "Token assinado do QR da mesa (define a mesa e o tipo dine_in)",
+                    "type": "string"
+                },
+                "tip": {
+                    "description": "Gorjeta opcional",
+                    "type": "number"
+                }
+            }
+        },
+        "models.CustomerAddress": {
+            "type": "object",
+            "properties": {
+                "city": {
+                    "description": "Cidade",
+                    "type": "string"
+                },
+                "complement": {
+                    "description": "Apartamento, bloco...",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "Data de criação",
+                    "type": "string"
+                },
+                "customer_phone": {
+                    "description": "Telefone do cliente dono do endereço",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "ID único do endereço",
+                    "type": "integer"
+                },
+                "label": {
+                    "description": "Apelido: Casa, Trabalho...",
+                    "type": "string"
+                },
+                "latitude": {
+                    "description": "Latitude do endereço",
+                    "type": "number"
+                },
+                "longitude": {
+                    "description": "Longitude do endereço",
+                    "type": "number"
+                },
+                "neighborhood": {
+                    "description": "Bairro",
+                    "type": "string"
+                },
+                "number": {
+                    "description": "Número",
+                    "type": "string"
+                },
+                "reference": {
+                    "description": "Ponto de referência para o entregador",
+                    "type": "string"
+                },
+                "street": {
+                    "description": "Rua / avenida",
+                    "type": "string"
+                }
+            }
+        },
+        "models.DeliveryQuote": {
+            "type": "object",
+            "properties": {
+                "delivery_fee": {
+                    "description": "Taxa de entrega",
+                    "type": "number"
+                },
+                "distance_km": {
+                    "description": "Distância em linha reta até a loja (0 se a loja não tem coordenadas)",
+                    "type": "number"
+                },
+                "min_order": {
+                    "description": "Valor mínimo do pedido",
+                    "type": "number"
+                },
+                "zone_id": {
+                    "description": "Zona que atende o endereço",
+                    "type": "integer"
+                },
+                "zone_name": {
+                    "description": "Nome da zona",
+                    "type": "string"
+                }
+            }
+        },
+        "models.DeliveryStatusRequest": {
+            "type": "object",
+            "properties": {
+                "status": {
+                    "description": "picked_up (saiu da loja) ou delivered (entregue)",
+                    "type": "string"
+                }
+            }
+        },
+        "models.DeliveryZone": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "Data de criação",
+                    "type": "string"
+                },
+                "delivery_fee": {
+                    "description": "Taxa de entrega",
+                    "type": "number"
+                },
+                "id": {
+                    "description": "ID único da zona",
+                    "type": "integer"
+                },
+                "is_active": {
+                    "description": "Se a zona está atendendo",
+                    "type": "boolean"
+                },
+                "kind": {
+                    "description": "radius ou polygon",
+                    "type": "string"
+                },
+                "min_order": {
+                    "description": "Valor mínimo do pedido (sem a taxa)",
+                    "type": "number"
+                },
+                "name": {
+                    "description": "Nome da zona (ex.: Centro, Até 3 km)",
+                    "type": "string"
+                },
+                "polygon": {
+                    "description": "Vértices da área (somente polygon)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.GeoPoint"
+                    }
+                },
+                "radius_km": {
+                    "description": "Raio em km (somente radius)",
+                    "type": "number"
+                },
+                "sort_order": {
+                    "description": "Prioridade quando zonas se sobrepõem (menor primeiro)",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.DispatchOrderRequest": {
+            "type": "object",
+            "properties": {
+                "driver_id": {
+                    "description": "Entregador escolhido (0 = o disponível mais próximo da loja)",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Driver": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "Data de criação",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "ID único do entregador",
+                    "type": "integer"
+                },
+                "is_active": {
+                    "description": "Se o entregador está cadastrado/ativo",
+                    "type": "boolean"
+                },
+                "last_seen_at": {
+                    "description": "Horário da última posição",
+                    "type": "string"
+                },
+                "latitude": {
+                    "description": "Última posição conhecida",
+                    "type": "number"
+                },
+                "longitude": {
+                    "description": "Última posição conhecida",
+                    "type": "number"
+                },
+                "name": {
+                    "description": "Nome do entregador",
+                    "type": "string"
+                },
+                "phone": {
+                    "description": "Telefone (somente dígitos)",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Status: offline, available, on_delivery",
+                    "type": "string"
+                },
+                "vehicle": {
+                    "description": "Veículo: moto, bike, carro...",
+                    "type": "string"
+                }
+            }
+        },
+        "models.DriverPositionRequest": {
+            "type": "object",
+            "properties": {
+                "latitude": {
+                    "description": "Latitude atual",
+                    "type": "number"
+                },
+                "longitude": {
+                    "description": "Longitude atual",
+                    "type": "number"
+                }
+            }
+        },
+        "models.DriverStatusRequest": {
+            "type": "object",
+            "properties": {
+                "status": {
+                    "description": "available ou offline",
+                    "type": "string"
+                }
+            }
+        },
+        "models.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.GeoPoint": {
+            "type": "object",
+            "properties": {
+                "latitude": {
+                    "description": "Latitude (-90 a 90)",
+                    "type": "number"
+                },
+                "longitude": {
+                    "description": "Longitude (-180 a 180)",
+                    "type": "number"
+                }
+            }
+        },
+        "models.Ingredient": {
+            "type": "object",
+            "properties": {
+                "allergens": {
+                    "description": "Alérgenos: gluten, lactose, nuts...",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "calories": {
+                    "description": "Calorias por porção (kcal)",
+                    "type": "integer"
+                },
+                "category": {
+                    "description": "Tipo: pão, carne, queijo, vegetais, molhos",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "Data de criação",
+                    "type": "string"
+                },
+                "diet_tags": {
+                    "description": "Dietas atendidas: vegan, vegetarian",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "description": "ID único do ingrediente",
+                    "type": "integer"
+                },
+                "is_available": {
+                    "description": "Se o ingrediente está disponível",
+                    "type": "boolean"
+                },
+                "name": {
+                    "description": "Nome do ingrediente",
+                    "type": "string"
+                },
+                "price": {
+                    "description": "Preço adicional do ingrediente",
+                    "type": "number"
+                }
+            }
+        },
+        "models.IngredientDietaryRequest": {
+            "type": "object",
+            "properties": {
+                "allergens": {
+                    "description": "Alérgenos (gluten, lactose, nuts...)",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "calories": {
+                    "description": "Calorias por porção (kcal; vazio = não informado)",
+                    "type": "integer"
+                },
+                "diet_tags": {
+                    "description": "Dietas atendidas (vegan, vegetarian)",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.KitchenWaitResponse": {
+            "type": "object",
+            "properties": {
+                "accepting_orders": {
+                    "description": "false quando pedidos novos seriam recusados",
+                    "type": "boolean"
+                },
+                "estimated_wait_minutes": {
+                    "description": "Espera estimada para um pedido novo",
+                    "type": "integer"
+                },
+                "max_orders_per_window": {
+                    "description": "Limite da janela (0 = sem limite)",
+                    "type": "integer"
+                },
+                "overload_mode": {
+                    "description": "reject ou schedule",
+                    "type": "string"
+                },
+                "queue_delay_minutes": {
+                    "description": "Parte da espera causada pela fila",
+                    "type": "integer"
+                },
+                "recent_orders": {
+                    "description": "Pedidos que entraram na janela atual",
+                    "type": "integer"
+                },
+                "stations": {
+                    "description": "Ocupação por estação",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.StationLoad"
+                    }
+                },
+                "window_minutes": {
+                    "description": "Tamanho da janela em minutos",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.LinePromotion": {
+            "type": "object",
+            "properties": {
+                "discount": {
+                    "description": "Desconto no item (zero nas unidades que só ativam a promoção)",
+                    "type": "number"
+                },
+                "name": {
+                    "description": "Nome da promoção",
+                    "type": "string"
+                },
+                "promotion_id": {
+                    "description": "Promoção",
+                    "type": "integer"
+                },
+                "quantity": {
+                    "description": "Unidades do item usadas pela promoção",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.MergeTableSessionsRequest": {
+            "type": "object",
+            "properties": {
+                "source_session_id": {
+                    "description": "Comanda que será incorporada (fica como merged)",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.OpenTableSessionRequest": {
+            "type": "object",
+            "properties": {
+                "notes": {
+                    "description": "Observações",
+                    "type": "string"
+                },
+                "table_number": {
+                    "description": "Número da mesa",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Order": {
+            "type": "object",
+            "properties": {
+                "allergies": {
+                    "description": "Alergias declaradas pelo cliente (valem para todos os itens)",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "allergy_alert": {
+                    "description": "Algum item contém alérgeno declarado (confirmado pelo cliente)",
+                    "type": "boolean"
+                },
+                "created_at": {
+                    "description": "Data de criação",
+                    "type": "string"
+                },
+                "customer_name": {
+                    "description": "Nome do cliente",
+                    "type": "string"
+                },
+                "customer_phone": {
+                    "description": "Telefone do cliente (somente dígitos)",
+                    "type": "string"
+                },
+                "delivery_address": {
+                    "description": "Endereço de entrega",
+                    "type": "string"
+                },
+                "delivery_address_id": {
+                    "description": "Endereço salvo usado na entrega",
+                    "type": "integer"
+                },
+                "delivery_fee": {
+                    "description": "Taxa de entrega (já incluída em total_amount)",
+                    "type": "number"
+                },
+                "delivery_latitude": {
+                    "description": "Coordenadas do destino da entrega",
+                    "type": "number"
+                },
+                "delivery_longitude": {
+                    "type": "number"
+                },
+                "delivery_status": {
+                    "description": "Andamento da entrega: assigned, picked_up, delivered",
+                    "type": "string"
+                },
+                "delivery_zone_id": {
+                    "description": "Zona de entrega aplicada",
+                    "type": "integer"
+                },
+                "driver_id": {
+                    "description": "Entregador atribuído",
+                    "type": "integer"
+                },
+                "estimated_ready_at": {
+                    "description": "Previsão de pronto (refinada a cada mudança de status)",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "ID único do pedido",
+                    "type": "integer"
+                },
+                "items": {
+                    "description": "Itens do pedido (opcional)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.OrderItem"
+                    }
+                },
+                "notes": {
+                    "description": "Observações do pedido",
+                    "type": "string"
+                },
+                "order_type": {
+                    "description": "Tipo: dine_in (salão), takeaway (retirada), delivery (entrega)",
+                    "type": "string"
+                },
+                "pickup_name": {
+                    "description": "Nome para chamar na retirada",
+                    "type": "string"
+                },
+                "pricing": {
+                    "description": "Composição do total (subtotal, descontos, taxas, gorjeta)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.PriceBreakdown"
+                        }
+                    ]
+                },
+                "release_at": {
+                    "description": "Quando um pedido agendado entra na fila da cozinha",
+                    "type": "string"
+                },
+                "scheduled_for": {
+                    "description": "Horário de retirada/entrega escolhido pelo cliente",
+                    "type": "string"
+                },
+                "service_day": {
+                    "description": "Dia de operação da senha (YYYY-MM-DD)",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Status: scheduled, pending, preparing, ready, delivered",
+                    "type": "string"
+                },
+                "table_number": {
+                    "description": "Número da mesa",
+                    "type": "integer"
+                },
+                "table_session_id": {
+                    "description": "Comanda da mesa (pedidos no salão)",
+                    "type": "integer"
+                },
+                "ticket_number": {
+                    "description": "Senha curta do pedido no dia de operação (1, 2, 3...)",
+                    "type": "integer"
+                },
+                "total_amount": {
+                    "description": "Valor total do pedido",
+                    "type": "number"
+                },
+                "updated_at": {
+                    "description": "Data de última atualização",
+                    "type": "string"
+                }
+            }
+        },
+        "models.OrderBoard": {
+            "type": "object",
+            "properties": {
+                "preparing": {
+                    "description": "Senhas em preparo",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.BoardTicket"
+                    }
+                },
+                "ready": {
+                    "description": "Senhas prontas para retirada",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.BoardTicket"
+                    }
+                },
+                "service_day": {
+                    "description": "Dia de operação (YYYY-MM-DD)",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "Momento da consulta",
+                    "type": "string"
+                }
+            }
+        },
+        "models.OrderHistoryEntry": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "Quando o evento aconteceu",
+                    "type": "string"
+                },
+                "details": {
+                    "description": "Detalhes adicionais (texto livre)",
+                    "type": "string"
+                },
+                "event": {
+                    "description": "Tipo do evento: created, status",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "ID único do evento",
+                    "type": "integer"
+                },
+                "order_id": {
+                    "description": "ID do pedido (FK)",
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "Status do pedido após o evento",
+                    "type": "string"
+                }
+            }
+        },
+        "models.OrderItem": {
+            "type": "object",
+            "properties": {
+                "allergen_conflicts": {
+                    "description": "Alérgenos declarados presentes no item (destaque na cozinha)",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "allergies": {
+                    "description": "Alergias declaradas para o item (do pedido e do próprio item)",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "bundle_id": {
+                    "description": "Combo do qual o item faz parte",
+                    "type": "integer"
+                },
+                "bundle_seq": {
+                    "description": "Combo do pedido (itens do mesmo combo têm o mesmo número)",
+                    "type": "integer"
+                },
+                "bundle_slot": {
+                    "description": "Etapa do combo",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "Data de criação",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "ID único do item",
+                    "type": "integer"
+                },
+                "ingredients": {
+                    "description": "JSON string com ingredientes customizados",
+                    "type": "string"
+                },
+                "notes": {
+                    "description": "Observações do item",
+                    "type": "string"
+                },
+                "order_id": {
+                    "description": "ID do pedido (FK)",
+                    "type": "integer"
+                },
+                "prepared_at": {
+                    "description": "Quando a cozinha finalizou o item (nil = em aberto)",
+                    "type": "string"
+                },
+                "product": {
+                    "description": "Produto completo (opcional)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Product"
+                        }
+                    ]
+                },
+                "product_id": {
+                    "description": "ID do produto (FK)",
+                    "type": "integer"
+                },
+                "quantity": {
+                    "description": "Quantidade do item",
+                    "type": "integer"
+                },
+                "total_price": {
+                    "description": "Preço total do item",
+                    "type": "number"
+                },
+                "unit_price": {
+                    "description": "Preço unitário",
+                    "type": "number"
+                },
+                "variant_id": {
+                    "description": "Variação escolhida",
+                    "type": "integer"
+                },
+                "variant_name": {
+                    "description": "Nome da variação no momento do pedido",
+                    "type": "string"
+                },
+                "variant_sku": {
+                    "description": "SKU da variação no momento do pedido",
+                    "type": "string"
+                }
+            }
+        },
+        "models.OrderItemRequest": {
+            "type": "object",
+            "properties": {
+                "allergies": {
+                    "description": "Alergias de quem vai consumir o item (somadas às do pedido)",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "choices": {
+                    "description": "Escolhas das etapas (combos)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.BundleChoice"
+                    }
+                },
+                "ingredients": {
+                    "description": "JSON string com ingredientes customizados",
+                    "type": "string"
+                },
+                "notes": {
+                    "description": "Observações do item",
+                    "type": "string"
+                },
+                "product_id": {
+                    "description": "ID do produto",
+                    "type": "integer"
+                },
+                "quantity": {
+                    "description": "Quantidade",
+                    "type": "integer"
+                },
+                "variant_id": {
+                    "description": "Variação (obrigatória quando o produto tem variações)",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.OrderQuote": {
+            "type": "object",
+            "properties": {
+                "combo_saving": {
+                    "description": "Economia dos itens reagrupados em combos",
+                    "type": "number"
+                },
+                "delivery_zone": {
+                    "description": "Zona de entrega aplicada",
+                    "type": "string"
+                },
+                "discounts": {
+                    "description": "Descontos aplicados (promoções e cupom)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AppliedDiscount"
+                    }
+                },
+                "lines": {
+                    "description": "Itens com preço",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.QuoteLine"
+                    }
+                },
+                "order_type": {
+                    "description": "Tipo do pedido",
+                    "type": "string"
+                },
+                "pricing": {
+                    "description": "Composição do total",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.PriceBreakdown"
+                        }
+                    ]
+                }
+            }
+        },
+        "models.OrderTracking": {
+            "type": "object",
+            "properties": {
+                "delivery_status": {
+                    "description": "\"\", assigned, picked_up ou delivered",
+                    "type": "string"
+                },
+                "destination": {
+                    "description": "Destino da entrega",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.GeoPoint"
+                        }
+                    ]
+                },
+                "distance_km": {
+                    "description": "Distância restante estimada",
+                    "type": "number"
+                },
+                "driver": {
+                    "description": "Entregador atribuído",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.TrackedDriver"
+                        }
+                    ]
+                },
+                "estimated_arrival_at": {
+                    "description": "Previsão de chegada",
+                    "type": "string"
+                },
+                "eta_minutes": {
+                    "description": "Minutos estimados até a chegada",
+                    "type": "integer"
+                },
+                "order_id": {
+                    "description": "ID do pedido",
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "Status do pedido",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "Momento do cálculo",
+                    "type": "string"
+                }
+            }
+        },
+        "models.PauseStoreRequest": {
+            "type": "object",
+            "properties": {
+                "duration_minutes": {
+                    "description": "Alternativa ao resume_at: retomar após N minutos",
+                    "type": "integer"
+                },
+                "reason": {
+                    "description": "Motivo",
+                    "type": "string"
+                },
+                "resume_at": {
+                    "description": "Retomada automática (RFC 3339)",
+                    "type": "string"
+                }
+            }
+        },
+        "models.PayBillShareRequest": {
+            "type": "object",
+            "properties": {
+                "payment_method": {
+                    "description": "cash, card ou pix",
+                    "type": "string"
+                }
+            }
+        },
+        "models.PriceBreakdown": {
+            "type": "object",
+            "properties": {
+                "delivery_fee": {
+                    "description": "Taxa de entrega",
+                    "type": "number"
+                },
+                "discount": {
+                    "description": "Descontos aplicados",
+                    "type": "number"
+                },
+                "service_charge": {
+                    "description": "Taxa de serviço (10% no salão, por padrão)",
+                    "type": "number"
+                },
+                "subtotal": {
+                    "description": "Soma dos itens",
+                    "type": "number"
+                },
+                "tip": {
+                    "description": "Gorjeta",
+                    "type": "number"
+                },
+                "total": {
+                    "description": "Valor final cobrado",
+                    "type": "number"
+                }
+            }
+        },
+        "models.PriceModifier": {
+            "type": "object",
+            "properties": {
+                "ingredient_id": {
+                    "description": "Ingrediente",
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "Nome do ingrediente",
+                    "type": "string"
+                },
+                "price": {
+                    "description": "Preço do ingrediente",
+                    "type": "number"
+                }
+            }
+        },
+        "models.Product": {
+            "type": "object",
+            "properties": {
+                "allergens": {
+                    "description": "Alérgenos dos ingredientes da receita",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "calories": {
+                    "description": "Calorias da receita (kcal; vazio = não informado)",
+                    "type": "integer"
+                },
+                "category": {
+                    "description": "Categoria completa (opcional)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Category"
+                        }
+                    ]
+                },
+                "category_id": {
+                    "description": "ID da categoria (FK)",
+                    "type": "integer"
+                },
+                "created_at": {
+                    "description": "Data de criação",
+                    "type": "string"
+                },
+                "description": {
+                    "description": "Descrição do produto",
+                    "type": "string"
+                },
+                "diet_tags": {
+                    "description": "Dietas atendidas por todos os ingredientes (vegan, vegetarian)",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "description": "ID único do produto",
+                    "type": "integer"
+                },
+                "image_url": {
+                    "description": "URL da imagem do produto",
+                    "type": "string"
+                },
+                "is_available": {
+                    "description": "Se o produto está disponível",
+                    "type": "boolean"
+                },
+                "is_bundle": {
+                    "description": "Combo com etapas de escolha (GET /api/products/:id/bundle)",
+                    "type": "boolean"
+                },
+                "name": {
+                    "description": "Nome do produto",
+                    "type": "string"
+                },
+                "prep_minutes": {
+                    "description": "Tempo de preparo esperado (minutos)",
+                    "type": "integer"
+                },
+                "price": {
+                    "description": "Preço do produto",
+                    "type": "number"
+                },
+                "station": {
+                    "description": "Estação da cozinha: grill, fryer, bar...",
+                    "type": "string"
+                },
+                "variants": {
+                    "description": "Tamanhos/versões disponíveis (escolha obrigatória no pedido)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ProductVariant"
+                    }
+                }
+            }
+        },
+        "models.ProductVariant": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "Data de criação",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "ID único da variação",
+                    "type": "integer"
+                },
+                "is_available": {
+                    "description": "Se a variação está disponível",
+                    "type": "boolean"
+                },
+                "name": {
+                    "description": "Nome exibido (P, M, G, 500ml...)",
+                    "type": "string"
+                },
+                "position": {
+                    "description": "Ordem de exibição",
+                    "type": "integer"
+                },
+                "price": {
+                    "description": "Preço da variação (substitui o preço do produto)",
+                    "type": "number"
+                },
+                "product_id": {
+                    "description": "Produto (FK)",
+                    "type": "integer"
+                },
+                "sku": {
+                    "description": "Código único da variação",
+                    "type": "string"
+                }
+            }
+        },
+        "models.ProductVariantRequest": {
+            "type": "object",
+            "properties": {
+                "is_available": {
+                    "description": "Disponível (padrão: true)",
+                    "type": "boolean"
+                },
+                "name": {
+                    "description": "Nome exibido",
+                    "type": "string"
+                },
+                "position": {
+                    "description": "Ordem de exibição",
+                    "type": "integer"
+                },
+                "price": {
+                    "description": "Preço",
+                    "type": "number"
+                },
+                "sku": {
+                    "description": "Código único (gravado em maiúsculas)",
+                    "type": "string"
+                }
+            }
+        },
+        "models.Promotion": {
+            "type": "object",
+            "properties": {
+                "buy_quantity": {
+                    "description": "buy_x_get_y: quantidade comprada",
+                    "type": "integer"
+                },
+                "category_ids": {
+                    "description": "Categorias participantes (vazio = todas)",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "created_at": {
+                    "description": "Data de criação",
+                    "type": "string"
+                },
+                "end_time": {
+                    "description": "Fim do horário (HH:MM)",
+                    "type": "string"
+                },
+                "ends_at": {
+                    "description": "Fim da campanha",
+                    "type": "string"
+                },
+                "get_quantity": {
+                    "description": "buy_x_get_y: quantidade ganha",
+                    "type": "integer"
+                },
+                "id": {
+                    "description": "ID único da promoção",
+                    "type": "integer"
+                },
+                "is_active": {
+                    "description": "Promoção ativa",
+                    "type": "boolean"
+                },
+                "kind": {
+                    "description": "percentage, fixed_price ou buy_x_get_y",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Nome exibido ao cliente",
+                    "type": "string"
+                },
+                "product_ids": {
+                    "description": "Produtos participantes (vazio = todos)",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "reward_category_ids": {
+                    "description": "buy_x_get_y: categorias do brinde",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "reward_product_ids": {
+                    "description": "buy_x_get_y: produtos do brinde (vazio = participantes)",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "start_time": {
+                    "description": "Início do horário (HH:MM, horário da loja)",
+                    "type": "string"
+                },
+                "starts_at": {
+                    "description": "Início da campanha",
+                    "type": "string"
+                },
+                "value": {
+                    "description": "Percentual, preço promocional ou % de desconto no brinde",
+                    "type": "number"
+                },
+                "weekdays": {
+                    "description": "Dias da semana (0 = domingo; vazio = todos)",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "models.QuoteLine": {
+            "type": "object",
+            "properties": {
+                "allergen_conflicts": {
+                    "description": "Alérgenos do item que o cliente declarou ter alergia",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "allergens": {
+                    "description": "Alérgenos do item (receita, ingredientes escolhidos ou produtos do combo)",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "combo_saving": {
+                    "description": "Economia do combo montado automaticamente com itens avulsos",
+                    "type": "number"
+                },
+                "components": {
+                    "description": "Produtos do combo",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.BundleComponent"
+                    }
+                },
+                "discount": {
+                    "description": "Desconto das promoções no item",
+                    "type": "number"
+                },
+                "modifiers": {
+                    "description": "Ingredientes que compõem o preço",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PriceModifier"
+                    }
+                },
+                "product_id": {
+                    "description": "Produto",
+                    "type": "integer"
+                },
+                "promotions": {
+                    "description": "Promoções aplicadas no item",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.LinePromotion"
+                    }
+                },
+                "quantity": {
+                    "description": "Quantidade",
+                    "type": "integer"
+                },
+                "total_price": {
+                    "description": "Preço da linha",
+                    "type": "number"
+                },
+                "unit_price": {
+                    "description": "Preço unitário (com os ingredientes escolhidos)",
+                    "type": "number"
+                },
+                "variant_id": {
+                    "description": "Variação escolhida",
+                    "type": "integer"
+                },
+                "variant_name": {
+                    "description": "Nome da variação",
+                    "type": "string"
                 }
             }
         },
-        "models.ErrorResponse": {
+        "models.RecipeIngredient": {
             "type": "object",
             "properties": {
-                "error": {
+                "allergens": {
+                    "description": "Alérgenos do ingrediente",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "ingredient_id": {
+                    "description": "Ingrediente",
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "Nome do ingrediente",
                     "type": "string"
+                },
+                "quantity": {
+                    "description": "Porções do ingrediente no produto",
+                    "type": "integer"
                 }
             }
         },
-        "models.Ingredient": {
+        "models.SetBundleRequest": {
             "type": "object",
             "properties": {
-                "category": {
-                    "description": "Tipo: pão, carne, queijo, vegetais, molhos",
-                    "type": "string"
+                "slots": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.BundleSlotRequest"
+                    }
+                }
+            }
+        },
+        "models.SetRecipeRequest": {
+            "type": "object",
+            "properties": {
+                "ingredients": {
+                    "description": "Ingredientes (lista vazia = sem receita)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.RecipeIngredient"
+                    }
+                }
+            }
+        },
+        "models.SetSchedulesRequest": {
+            "type": "object",
+            "properties": {
+                "schedules": {
+                    "description": "Janelas (lista vazia = vendido o dia todo)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AvailabilitySchedule"
+                    }
+                }
+            }
+        },
+        "models.SetStoreHoursRequest": {
+            "type": "object",
+            "properties": {
+                "hours": {
+                    "description": "Períodos (lista vazia = aberta o dia todo)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.StoreHours"
+                    }
+                }
+            }
+        },
+        "models.StationLoad": {
+            "type": "object",
+            "properties": {
+                "capacity": {
+                    "description": "Itens simultâneos suportados",
+                    "type": "integer"
                 },
-                "created_at": {
-                    "description": "Data de criação",
+                "open_items": {
+                    "description": "Itens na fila (pendentes, em preparo e agendados)",
+                    "type": "integer"
+                },
+                "station": {
+                    "description": "Estação: grill, fryer, bar...",
+                    "type": "string"
+                }
+            }
+        },
+        "models.StatusResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.StoreException": {
+            "type": "object",
+            "properties": {
+                "close_time": {
+                    "description": "Fechamento especial (HH:MM)",
                     "type": "string"
                 },
-                "id": {
-                    "description": "ID único do ingrediente",
-                    "type": "integer"
+                "date": {
+                    "description": "Data (YYYY-MM-DD)",
+                    "type": "string"
                 },
-                "is_available": {
-                    "description": "Se o ingrediente está disponível",
+                "is_closed": {
+                    "description": "Loja fechada o dia todo",
                     "type": "boolean"
                 },
-                "name": {
-                    "description": "Nome do ingrediente",
+                "open_time": {
+                    "description": "Abertura especial (HH:MM)",
                     "type": "string"
                 },
-                "price": {
-                    "description": "Preço adicional do ingrediente",
-                    "type": "number"
+                "reason": {
+                    "description": "Motivo exibido ao cliente (ex.: \"Natal\")",
+                    "type": "string"
                 }
             }
         },
-        "models.Order": {
+        "models.StoreHours": {
             "type": "object",
             "properties": {
-                "created_at": {
-                    "description": "Data de criação",
+                "close_time": {
+                    "description": "Fechamento (HH:MM; menor ou igual à abertura = depois da meia-noite)",
                     "type": "string"
                 },
-                "customer_name": {
-                    "description": "Nome do cliente",
+                "open_time": {
+                    "description": "Abertura (HH:MM, horário da loja)",
                     "type": "string"
                 },
-                "id": {
-                    "description": "ID único do pedido",
+                "weekday": {
+                    "description": "Dia da semana (0 = domingo)",
                     "type": "integer"
-                },
-                "items": {
-                    "description": "Itens do pedido (opcional)",
+                }
+            }
+        },
+        "models.StoreHoursConfig": {
+            "type": "object",
+            "properties": {
+                "exceptions": {
+                    "description": "Feriados e horários especiais (de hoje em diante)",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.OrderItem"
+                        "$ref": "#/definitions/models.StoreException"
                     }
                 },
-                "notes": {
-                    "description": "Observações do pedido",
+                "hours": {
+                    "description": "Horário semanal",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.StoreHours"
+                    }
+                }
+            }
+        },
+        "models.StorePause": {
+            "type": "object",
+            "properties": {
+                "paused_at": {
+                    "description": "Início da pausa",
                     "type": "string"
                 },
-                "status": {
-                    "description": "Status: pending, preparing, ready, delivered",
+                "reason": {
+                    "description": "Motivo exibido ao cliente",
                     "type": "string"
                 },
-                "table_number": {
-                    "description": "Número da mesa",
-                    "type": "integer"
-                },
-                "total_amount": {
-                    "description": "Valor total do pedido",
-                    "type": "number"
-                },
-                "updated_at": {
-                    "description": "Data de última atualização",
+                "resume_at": {
+                    "description": "Retomada automática (vazio = até retomar manualmente)",
                     "type": "string"
                 }
             }
         },
-        "models.OrderItem": {
+        "models.StoreStatus": {
             "type": "object",
             "properties": {
-                "created_at": {
-                    "description": "Data de criação",
-                    "type": "string"
-                },
-                "id": {
-                    "description": "ID único do item",
-                    "type": "integer"
+                "accepting_orders": {
+                    "description": "Se novos pedidos são aceitos agora",
+                    "type": "boolean"
                 },
-                "ingredients": {
-                    "description": "JSON string com ingredientes customizados",
+                "closes_at": {
+                    "description": "Fechamento do período atual",
                     "type": "string"
                 },
-                "notes": {
-                    "description": "Observações do item",
+                "message": {
+                    "description": "Mensagem para o cliente",
                     "type": "string"
                 },
-                "order_id": {
-                    "description": "ID do pedido (FK)",
-                    "type": "integer"
+                "next_open_at": {
+                    "description": "Próxima abertura (loja fechada)",
+                    "type": "string"
                 },
-                "product": {
-                    "description": "Produto completo (opcional)",
+                "pause": {
+                    "description": "Pausa em andamento",
                     "allOf": [
                         {
-                            "$ref": "#/definitions/models.Product"
+                            "$ref": "#/definitions/models.StorePause"
                         }
                     ]
                 },
-                "product_id": {
-                    "description": "ID do produto (FK)",
+                "reason": {
+                    "description": "Motivo da pausa ou do fechamento especial",
+                    "type": "string"
+                },
+                "resume_at": {
+                    "description": "Retomada automática da pausa",
+                    "type": "string"
+                },
+                "state": {
+                    "description": "open, closed ou paused",
+                    "type": "string"
+                }
+            }
+        },
+        "models.Table": {
+            "type": "object",
+            "properties": {
+                "area": {
+                    "description": "Área: salão, terraço, varanda...",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "Data de criação",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "ID único da mesa",
                     "type": "integer"
                 },
-                "quantity": {
-                    "description": "Quantidade do item",
+                "is_active": {
+                    "description": "Se a mesa está em uso",
+                    "type": "boolean"
+                },
+                "number": {
+                    "description": "Número exibido na mesa",
                     "type": "integer"
                 },
-                "total_price": {
-                    "description": "Preço total do item",
-                    "type": "number"
+                "order_url": {
+                    "description": "Endereço assinado aberto pelo QR code da mesa",
+                    "type": "string"
                 },
-                "unit_price": {
-                    "description": "Preço unitário",
-                    "type": "number"
+                "seats": {
+                    "description": "Lugares",
+                    "type": "integer"
                 }
             }
         },
-        "models.Product": {
+        "models.TableSession": {
             "type": "object",
             "properties": {
-                "category": {
-                    "description": "Categoria completa (opcional)",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/models.Category"
-                        }
-                    ]
+                "closed_at": {
+                    "description": "Fechamento da conta",
+                    "type": "string"
                 },
-                "category_id": {
-                    "description": "ID da categoria (FK)",
+                "id": {
+                    "description": "ID único da comanda",
                     "type": "integer"
                 },
-                "created_at": {
-                    "description": "Data de criação",
+                "merged_into": {
+                    "description": "Comanda que incorporou esta (status merged)",
+                    "type": "integer"
+                },
+                "notes": {
+                    "description": "Observações da comanda",
                     "type": "string"
                 },
-                "description": {
-                    "description": "Descrição do produto",
+                "opened_at": {
+                    "description": "Abertura da comanda",
+                    "type": "string"
+                },
+                "order_count": {
+                    "description": "Quantidade de pedidos (rodadas)",
+                    "type": "integer"
+                },
+                "orders": {
+                    "description": "Pedidos da comanda (somente nos detalhes)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Order"
+                    }
+                },
+                "status": {
+                    "description": "Status: open (aberta), closed (conta fechada) ou merged (juntada a outra)",
                     "type": "string"
                 },
+                "table_number": {
+                    "description": "Número da mesa",
+                    "type": "integer"
+                },
+                "total_amount": {
+                    "description": "Consumo acumulado (valor final após o fechamento)",
+                    "type": "number"
+                }
+            }
+        },
+        "models.TrackedDriver": {
+            "type": "object",
+            "properties": {
                 "id": {
-                    "description": "ID único do produto",
+                    "description": "ID do entregador",
                     "type": "integer"
                 },
-                "image_url": {
-                    "description": "URL da imagem do produto",
+                "last_seen_at": {
+                    "description": "Horário da última posição",
                     "type": "string"
                 },
-                "is_available": {
-                    "description": "Se o produto está disponível",
-                    "type": "boolean"
+                "latitude": {
+                    "description": "Última posição conhecida",
+                    "type": "number"
+                },
+                "longitude": {
+                    "description": "Última posição conhecida",
+                    "type": "number"
                 },
                 "name": {
-                    "description": "Nome do produto",
+                    "description": "Nome do entregador",
                     "type": "string"
                 },
-                "price": {
-                    "description": "Preço do produto",
-                    "type": "number"
+                "vehicle": {
+                    "description": "Veículo",
+                    "type": "string"
                 }
             }
         },
-        "models.StatusResponse": {
+        "models.TransferItem": {
             "type": "object",
             "properties": {
-                "message": {
-                    "type": "string"
+                "order_item_id": {
+                    "description": "Item do pedido de origem",
+                    "type": "integer"
+                },
+                "quantity": {
+                    "description": "Quantidade a mover (até a quantidade do item)",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.TransferTableRequest": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "description": "Itens a mover (opcional)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TransferItem"
+                    }
+                },
+                "order_ids": {
+                    "description": "Pedidos a mover (opcional)",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "to_table_number": {
+                    "description": "Mesa de destino",
+                    "type": "integer"
                 }
             }
         },
@@ -411,8 +5915,25 @@ const docTemplate = `{
             "type": "object",
             "properties": {
                 "status": {
-                    "description": "Novo status: pending, preparing, ready, delivered",
+                    "description": "Novo status: scheduled, pending, preparing, ready, delivered",
+                    "type": "string"
+                }
+            }
+        },
+        "models.UpdateTableRequest": {
+            "type": "object",
+            "properties": {
+                "area": {
+                    "description": "Nova área",
                     "type": "string"
+                },
+                "is_active": {
+                    "description": "Ativar/desativar a mesa",
+                    "type": "boolean"
+                },
+                "seats": {
+                    "description": "Novos lugares",
+                    "type": "integer"
                 }
             }
         }
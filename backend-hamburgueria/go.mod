@@ -17,6 +17,8 @@ require (
 	github.com/joho/godotenv v1.5.1
 	// Driver PostgreSQL para Go
 	github.com/lib/pq v1.10.9
+	// Geração de QR codes das mesas
+	github.com/skip2/go-qrcode v0.0.0-20200617195104-da1b6568686e
 	// Dependências para testes
 	github.com/stretchr/testify v1.10.0
 	// Biblioteca para criptografia (hash de senhas, etc.)
@@ -53,6 +55,12 @@ require (
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
 
+require (
+	github.com/swaggo/files v1.0.1
+	github.com/swaggo/gin-swagger v1.6.0
+	github.com/swaggo/swag v1.16.6
+)
+
 require (
 	github.com/KyleBanks/depth v1.2.1 // indirect
 	github.com/PuerkitoBio/purell v1.2.1 // indirect
@@ -66,9 +74,6 @@ require (
 	github.com/kr/text v0.2.0 // indirect
 	github.com/mailru/easyjson v0.9.0 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	github.com/swaggo/files v1.0.1 // indirect
-	github.com/swaggo/gin-swagger v1.6.0 // indirect
-	github.com/swaggo/swag v1.16.6 // indirect
 	golang.org/x/mod v0.27.0 // indirect
 	golang.org/x/sync v0.16.0 // indirect
 	golang.org/x/tools v0.36.0 // indirect
@@ -0,0 +1,464 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	// Modelos de dados da aplicação
+	"backend-hamburgueria/models"
+
+	// Framework web Gin
+	"github.com/gin-gonic/gin"
+	// Driver do PostgreSQL (arrays)
+	"github.com/lib/pq"
+)
+
+// ===== ALÉRGENOS, DIETAS E RECEITAS =====
+
+// Dietas atendidas pelos ingredientes
+const (
+	dietVegan      = "vegan"
+	dietVegetarian = "vegetarian"
+)
+
+// knownAllergens são os alérgenos aceitos no cadastro dos ingredientes e nos filtros do cardápio
+var knownAllergens = []string{
+	"gluten", "lactose", "egg", "soy", "nuts", "peanuts", "fish", "shellfish", "sesame", "mustard", "celery", "sulfites",
+}
+
+// knownDiets são as dietas aceitas no cadastro dos ingredientes e no filtro ?diet=
+var knownDiets = []string{dietVegan, dietVegetarian}
+
+// ingredientColumns são as colunas lidas por scanIngredient
+const ingredientColumns = `id, name, price, category, is_available, allergens, diet_tags, calories, created_at`
+
+// invalidTagError indica um alérgeno ou dieta fora da lista aceita
+type invalidTagError struct {
+	Label string // Tipo do código: "Alérgeno inválido" ou "Dieta inválida"
+	Value string // Valor recebido
+}
+
+func (e *invalidTagError) Error() string {
+	return e.Label + ": " + e.Value
+}
+
+// menuDietary são os alérgenos, dietas e calorias dos ingredientes e as receitas dos produtos
+type menuDietary struct {
+	Ingredients map[int]models.Ingredient
+	Recipes     map[int][]models.RecipeIngredient
+}
+
+// GetProductRecipe godoc
+// @Summary      Receita de um produto
+// @Description  Retorna os ingredientes do produto, de onde vêm os alérgenos, dietas e calorias exibidos no cardápio
+// @Tags         Products
+// @Produce      json
+// @Param        id   path      int  true  "ID do produto"
+// @Success      200  {array}   models.RecipeIngredient
+// @Failure      400  {object}  models.ErrorResponse "ID inválido"
+// @Router       /api/products/{id}/recipe [get]
+func GetProductRecipe(c *gin.Context, db DBInterface) {
+	productID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do produto inválido"})
+		return
+	}
+
+	rows, err := db.Query(`
+		SELECT i.id, i.name, pi.quantity, i.allergens
+		FROM product_ingredients pi
+		JOIN ingredients i ON i.id = pi.ingredient_id
+		WHERE pi.product_id = $1
+		ORDER BY i.category, i.name
+	`, productID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar receita"})
+		return
+	}
+	defer rows.Close()
+
+	recipe := []models.RecipeIngredient{}
+	for rows.Next() {
+		var entry models.RecipeIngredient
+		if err := rows.Scan(&entry.IngredientID, &entry.Name, &entry.Quantity, pq.Array(&entry.Allergens)); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao ler receita"})
+			return
+		}
+		recipe = append(recipe, entry)
+	}
+	if err := rows.Err(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar receita"})
+		return
+	}
+	c.JSON(http.StatusOK, recipe)
+}
+
+// SetProductRecipe godoc
+// @Summary      Define a receita de um produto
+// @Description  Substitui os ingredientes do produto; lista vazia = produto sem alérgenos e dietas declarados
+// @Tags         Products
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                      true  "ID do produto"
+// @Param        body  body      models.SetRecipeRequest  true  "Ingredientes da receita"
+// @Success      200   {array}   models.RecipeIngredient
+// @Failure      400   {object}  models.ErrorResponse "Dados inválidos"
+// @Failure      404   {object}  models.ErrorResponse "Produto não encontrado"
+// @Router       /api/products/{id}/recipe [put]
+func SetProductRecipe(c *gin.Context, db DBInterface) {
+	// ===== VALIDAR DADOS DE ENTRADA =====
+	productID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do produto inválido"})
+		return
+	}
+	var req models.SetRecipeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos"})
+		return
+	}
+	if err := validateRecipe(productID, req.Ingredients); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// ===== INICIAR TRANSAÇÃO =====
+	tx, err := db.Begin()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao iniciar transação"})
+		return
+	}
+	defer tx.Rollback()
+
+	// Combos têm os alérgenos dos produtos escolhidos em cada etapa
+	var isBundle bool
+	err = tx.QueryRow("SELECT is_bundle FROM products WHERE id = $1", productID).Scan(&isBundle)
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Produto não encontrado"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao salvar receita"})
+		return
+	}
+	if isBundle {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Combos não têm receita: os alérgenos vêm dos produtos escolhidos"})
+		return
+	}
+
+	ids := make([]int, 0, len(req.Ingredients))
+	for _, entry := range req.Ingredients {
+		ids = append(ids, entry.IngredientID)
+	}
+	var found int
+	if err := tx.QueryRow(`SELECT COUNT(*) FROM ingredients WHERE id = ANY($1)`, pq.Array(ids)).Scan(&found); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao salvar receita"})
+		return
+	}
+	if found != len(ids) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Ingrediente não encontrado"})
+		return
+	}
+
+	// ===== SUBSTITUIR RECEITA =====
+	if _, err := tx.Exec(`DELETE FROM product_ingredients WHERE product_id = $1`, productID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao salvar receita"})
+		return
+	}
+	for _, entry := range req.Ingredients {
+		if _, err := tx.Exec(`
+			INSERT INTO product_ingredients (product_id, ingredient_id, quantity) VALUES ($1, $2, $3)
+		`, productID, entry.IngredientID, entry.Quantity); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao salvar receita"})
+			return
+		}
+	}
+
+	// ===== COMMIT DA TRANSAÇÃO =====
+	if err := tx.Commit(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao salvar receita"})
+		return
+	}
+	GetProductRecipe(c, db)
+}
+
+// SetIngredientDietary godoc
+// @Summary      Define alérgenos, dietas e calorias de um ingrediente
+// @Description  Substitui os alérgenos, as dietas atendidas e as calorias por porção; vegan implica vegetarian
+// @Tags         Ingredients
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                              true  "ID do ingrediente"
+// @Param        body  body      models.IngredientDietaryRequest  true  "Alérgenos, dietas e calorias"
+// @Success      200   {object}  models.Ingredient
+// @Failure      400   {object}  models.ErrorResponse "Dados inválidos"
+// @Failure      404   {object}  models.ErrorResponse "Ingrediente não encontrado"
+// @Router       /api/ingredients/{id}/dietary [put]
+func SetIngredientDietary(c *gin.Context, db DBInterface) {
+	// ===== VALIDAR DADOS DE ENTRADA =====
+	ingredientID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do ingrediente inválido"})
+		return
+	}
+	var req models.IngredientDietaryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos"})
+		return
+	}
+	allergens, err := normalizeAllergens(req.Allergens)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	diets, err := normalizeDietTags(req.DietTags)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.Calories != nil && *req.Calories < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Calorias não podem ser negativas"})
+		return
+	}
+
+	// ===== ATUALIZAR INGREDIENTE =====
+	ingredient, err := scanIngredient(db.QueryRow(`
+		UPDATE ingredients SET allergens = $2, diet_tags = $3, calories = $4
+		WHERE id = $1
+		RETURNING `+ingredientColumns,
+		ingredientID, pq.Array(allergens), pq.Array(diets), req.Calories))
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Ingrediente não encontrado"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao atualizar ingrediente"})
+		return
+	}
+
+	c.JSON(http.StatusOK, ingredient)
+}
+
+// ===== FUNÇÕES AUXILIARES =====
+
+// validateRecipe confere os ingredientes da receita; porções não informadas valem 1
+func validateRecipe(productID int, recipe []models.RecipeIngredient) error {
+	if productID == customBurgerProductID {
+		return errors.New("O lanche personalizado não tem receita: os alérgenos vêm dos ingredientes escolhidos")
+	}
+	seen := make(map[int]bool, len(recipe))
+	for i := range recipe {
+		entry := &recipe[i]
+		if entry.IngredientID <= 0 {
+			return errors.New("Ingrediente inválido na receita")
+		}
+		if seen[entry.IngredientID] {
+			return errors.New("Ingrediente repetido na receita")
+		}
+		seen[entry.IngredientID] = true
+		if entry.Quantity == 0 {
+			entry.Quantity = 1
+		}
+		if entry.Quantity < 0 {
+			return errors.New("Quantidade do ingrediente inválida")
+		}
+	}
+	return nil
+}
+
+// normalizeTags padroniza uma lista de códigos (minúsculas, sem repetição, em ordem) e rejeita os desconhecidos
+func normalizeTags(values, known []string, invalid string) ([]string, error) {
+	tags := []string{}
+	for _, value := range values {
+		tag := strings.ToLower(strings.TrimSpace(value))
+		if !containsString(known, tag) {
+			return nil, &invalidTagError{Label: invalid, Value: value}
+		}
+		if !containsString(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	sort.Strings(tags)
+	return tags, nil
+}
+
+// normalizeAllergens padroniza os alérgenos informados
+func normalizeAllergens(values []string) ([]string, error) {
+	return normalizeTags(values, knownAllergens, "Alérgeno inválido")
+}
+
+// normalizeDietTags padroniza as dietas informadas; o que é vegano também é vegetariano
+func normalizeDietTags(values []string) ([]string, error) {
+	tags, err := normalizeTags(values, knownDiets, "Dieta inválida")
+	if err != nil {
+		return nil, err
+	}
+	if containsString(tags, dietVegan) && !containsString(tags, dietVegetarian) {
+		tags = append(tags, dietVegetarian)
+		sort.Strings(tags)
+	}
+	return tags, nil
+}
+
+// parseTagList separa uma lista da query string (?exclude_allergens=gluten,lactose)
+func parseTagList(raw string) []string {
+	var values []string
+	for _, value := range strings.Split(raw, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// containsString indica se o texto está na lista
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// scanIngredient lê um ingrediente nas colunas de ingredientColumns
+func scanIngredient(row rowScanner) (models.Ingredient, error) {
+	var ing models.Ingredient
+	var calories sql.NullInt64
+	err := row.Scan(&ing.ID, &ing.Name, &ing.Price, &ing.Category, &ing.IsAvailable,
+		pq.Array(&ing.Allergens), pq.Array(&ing.DietTags), &calories, &ing.CreatedAt)
+	if calories.Valid {
+		kcal := int(calories.Int64)
+		ing.Calories = &kcal
+	}
+	return ing, err
+}
+
+// loadMenuDietary busca os alérgenos, dietas e calorias de todos os ingredientes e as receitas dos produtos
+func loadMenuDietary(q queryer) (menuDietary, error) {
+	dietary := menuDietary{
+		Ingredients: make(map[int]models.Ingredient),
+		Recipes:     make(map[int][]models.RecipeIngredient),
+	}
+
+	rows, err := q.Query(`SELECT ` + ingredientColumns + ` FROM ingredients`)
+	if err != nil {
+		return dietary, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		ing, err := scanIngredient(rows)
+		if err != nil {
+			return dietary, err
+		}
+		dietary.Ingredients[ing.ID] = ing
+	}
+	if err := rows.Err(); err != nil {
+		return dietary, err
+	}
+
+	recipeRows, err := q.Query(`SELECT product_id, ingredient_id, quantity FROM product_ingredients`)
+	if err != nil {
+		return dietary, err
+	}
+	defer recipeRows.Close()
+	for recipeRows.Next() {
+		var productID int
+		var entry models.RecipeIngredient
+		if err := recipeRows.Scan(&productID, &entry.IngredientID, &entry.Quantity); err != nil {
+			return dietary, err
+		}
+		dietary.Recipes[productID] = append(dietary.Recipes[productID], entry)
+	}
+	return dietary, recipeRows.Err()
+}
+
+// combine junta os ingredientes: alérgenos de qualquer um, dietas atendidas por todos e a soma das calorias
+// Sem ingredientes não há dietas nem calorias; calorias ficam vazias se algum ingrediente não informar
+func (m menuDietary) combine(entries []models.RecipeIngredient) (allergens, diets []string, calories *int) {
+	allergens = []string{}
+	diets = []string{}
+	if len(entries) == 0 {
+		return allergens, diets, nil
+	}
+
+	diets = append(diets, knownDiets...)
+	total, known := 0, true
+	for _, entry := range entries {
+		ing := m.Ingredients[entry.IngredientID]
+		for _, allergen := range ing.Allergens {
+			if !containsString(allergens, allergen) {
+				allergens = append(allergens, allergen)
+			}
+		}
+		var kept []string
+		for _, diet := range diets {
+			if containsString(ing.DietTags, diet) {
+				kept = append(kept, diet)
+			}
+		}
+		diets = kept
+		if ing.Calories == nil {
+			known = false
+		} else {
+			total += *ing.Calories * entry.Quantity
+		}
+	}
+	if diets == nil {
+		diets = []string{}
+	}
+	sort.Strings(allergens)
+	sort.Strings(diets)
+	if known {
+		calories = &total
+	}
+	return allergens, diets, calories
+}
+
+// product retorna os alérgenos, dietas e calorias do produto pela receita
+func (m menuDietary) product(productID int) (allergens, diets []string, calories *int) {
+	return m.combine(m.Recipes[productID])
+}
+
+// lineAllergens são os alérgenos de um item do pedido, considerando a customização:
+// ingredientes escolhidos no lanche personalizado, produtos escolhidos no combo ou a receita do produto
+func (m menuDietary) lineAllergens(line pricedLine) []string {
+	if len(line.Modifiers) > 0 {
+		entries := make([]models.RecipeIngredient, 0, len(line.Modifiers))
+		for _, modifier := range line.Modifiers {
+			entries = append(entries, models.RecipeIngredient{IngredientID: modifier.IngredientID, Quantity: 1})
+		}
+		allergens, _, _ := m.combine(entries)
+		return allergens
+	}
+	if len(line.Components) > 0 {
+		allergens := []string{}
+		for _, part := range line.Components {
+			partAllergens, _, _ := m.product(part.ProductID)
+			for _, allergen := range partAllergens {
+				if !containsString(allergens, allergen) {
+					allergens = append(allergens, allergen)
+				}
+			}
+		}
+		sort.Strings(allergens)
+		return allergens
+	}
+	allergens, _, _ := m.product(line.Item.ProductID)
+	return allergens
+}
+
+// matchesDietaryFilter indica se o produto passa nos filtros do cardápio:
+// nenhum dos alérgenos excluídos e, se informada, a dieta atendida
+func matchesDietaryFilter(p models.Product, excludeAllergens []string, diet string) bool {
+	for _, allergen := range excludeAllergens {
+		if containsString(p.Allergens, allergen) {
+			return false
+		}
+	}
+	return diet == "" || containsString(p.DietTags, diet)
+}
@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"backend-hamburgueria/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// sampleDietary: pão (glúten, vegano), carne, cheddar (lactose, vegetariano) e alface (vegano, sem calorias)
+func sampleDietary() menuDietary {
+	kcal := func(v int) *int { return &v }
+	return menuDietary{
+		Ingredients: map[int]models.Ingredient{
+			1: {ID: 1, Name: "Pão", Allergens: []string{"gluten"}, DietTags: []string{"vegan", "vegetarian"}, Calories: kcal(150)},
+			2: {ID: 2, Name: "Carne", Calories: kcal(250)},
+			3: {ID: 3, Name: "Cheddar", Allergens: []string{"lactose"}, DietTags: []string{"vegetarian"}, Calories: kcal(80)},
+			4: {ID: 4, Name: "Alface", DietTags: []string{"vegan", "vegetarian"}},
+		},
+		Recipes: map[int][]models.RecipeIngredient{
+			5: {{IngredientID: 1, Quantity: 1}, {IngredientID: 2, Quantity: 2}, {IngredientID: 3, Quantity: 1}},
+			6: {{IngredientID: 1, Quantity: 1}, {IngredientID: 3, Quantity: 2}},
+			7: {{IngredientID: 1, Quantity: 1}, {IngredientID: 4, Quantity: 1}},
+		},
+	}
+}
+
+// Teste dos alérgenos, dietas e calorias derivados da receita
+func TestMenuDietaryProduct(t *testing.T) {
+	dietary := sampleDietary()
+
+	allergens, diets, calories := dietary.product(5)
+	assert.Equal(t, []string{"gluten", "lactose"}, allergens)
+	assert.Equal(t, []string{}, diets)
+	assert.Equal(t, 730, *calories)
+
+	_, diets, calories = dietary.product(6)
+	assert.Equal(t, []string{"vegetarian"}, diets)
+	assert.Equal(t, 310, *calories)
+
+	// Alface sem calorias informadas: total desconhecido
+	allergens, diets, calories = dietary.product(7)
+	assert.Equal(t, []string{"gluten"}, allergens)
+	assert.Equal(t, []string{"vegan", "vegetarian"}, diets)
+	assert.Nil(t, calories)
+
+	// Produto sem receita
+	allergens, diets, calories = dietary.product(99)
+	assert.Empty(t, allergens)
+	assert.Empty(t, diets)
+	assert.Nil(t, calories)
+}
+
+// Teste dos alérgenos do item com customização
+func TestMenuDietaryLineAllergens(t *testing.T) {
+	dietary := sampleDietary()
+
+	// Lanche personalizado: ingredientes escolhidos
+	custom := pricedLine{
+		Item:      models.OrderItemRequest{ProductID: customBurgerProductID},
+		Modifiers: []models.PriceModifier{{IngredientID: 2}, {IngredientID: 3}},
+	}
+	assert.Equal(t, []string{"lactose"}, dietary.lineAllergens(custom))
+
+	// Combo: produtos escolhidos
+	combo := pricedLine{
+		Item: models.OrderItemRequest{ProductID: 20},
+		Components: []bundlePart{
+			{BundleComponent: models.BundleComponent{ProductID: 7}},
+			{BundleComponent: models.BundleComponent{ProductID: 6}},
+		},
+	}
+	assert.Equal(t, []string{"gluten", "lactose"}, dietary.lineAllergens(combo))
+
+	// Produto comum: receita
+	assert.Equal(t, []string{"gluten"}, dietary.lineAllergens(pricedLine{Item: models.OrderItemRequest{ProductID: 7}}))
+}
+
+// Teste da padronização de alérgenos e dietas
+func TestNormalizeDietaryTags(t *testing.T) {
+	allergens, err := normalizeAllergens([]string{" Lactose", "gluten", "lactose"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"gluten", "lactose"}, allergens)
+
+	_, err = normalizeAllergens([]string{"pimenta"})
+	assert.Error(t, err)
+
+	diets, err := normalizeDietTags([]string{"vegan"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"vegan", "vegetarian"}, diets)
+
+	_, err = normalizeDietTags([]string{"keto"})
+	assert.Error(t, err)
+
+	assert.Equal(t, []string{"gluten", "nuts"}, parseTagList("gluten, ,nuts"))
+	assert.Nil(t, parseTagList(""))
+}
+
+// Teste dos filtros do cardápio
+func TestMatchesDietaryFilter(t *testing.T) {
+	burger := models.Product{Allergens: []string{"gluten", "lactose"}, DietTags: []string{"vegetarian"}}
+	assert.True(t, matchesDietaryFilter(burger, nil, ""))
+	assert.False(t, matchesDietaryFilter(burger, []string{"lactose"}, ""))
+	assert.True(t, matchesDietaryFilter(burger, []string{"nuts"}, dietVegetarian))
+	assert.False(t, matchesDietaryFilter(burger, nil, dietVegan))
+}
+
+// Teste da validação da receita
+func TestValidateRecipe(t *testing.T) {
+	recipe := []models.RecipeIngredient{{IngredientID: 1}, {IngredientID: 2, Quantity: 2}}
+	assert.NoError(t, validateRecipe(5, recipe))
+	assert.Equal(t, 1, recipe[0].Quantity)
+
+	assert.Error(t, validateRecipe(customBurgerProductID, nil))
+	assert.Error(t, validateRecipe(5, []models.RecipeIngredient{{IngredientID: 0}}))
+	assert.Error(t, validateRecipe(5, []models.RecipeIngredient{{IngredientID: 1}, {IngredientID: 1}}))
+	assert.Error(t, validateRecipe(5, []models.RecipeIngredient{{IngredientID: 1, Quantity: -1}}))
+}
+
+// Teste para GetProducts com filtros inválidos (recusados antes do banco)
+func TestGetProductsInvalidDietaryFilter(t *testing.T) {
+	router, mockDB := setupTest()
+
+	router.GET("/products", func(c *gin.Context) {
+		GetProducts(c, mockDB)
+	})
+
+	for _, query := range []string{"?exclude_allergens=pimenta", "?diet=keto"} {
+		req, _ := http.NewRequest("GET", "/products"+query, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	}
+}
+
+// Teste para SetIngredientDietary com alérgeno desconhecido
+func TestSetIngredientDietaryInvalid(t *testing.T) {
+	router, mockDB := setupTest()
+
+	router.PUT("/ingredients/:id/dietary", func(c *gin.Context) {
+		SetIngredientDietary(c, mockDB)
+	})
+
+	body := `{"allergens": ["pimenta"], "diet_tags": []}`
+	req, _ := http.NewRequest("PUT", "/ingredients/3/dietary", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+
+	// Modelos de dados da aplicação
+	"backend-hamburgueria/models"
+
+	// Framework web Gin
+	"github.com/gin-gonic/gin"
+)
+
+// ===== ALERGIAS DO CLIENTE =====
+
+// errAllergyConflict indica itens com alérgenos declarados pelo cliente em um pedido não confirmado
+var errAllergyConflict = errors.New("O pedido tem itens com alérgenos declarados pelo cliente; confirme com allow_allergy_conflicts")
+
+// normalizeOrderAllergies padroniza as alergias declaradas no pedido e em cada item
+func normalizeOrderAllergies(req *models.CreateOrderRequest) error {
+	allergies, err := normalizeAllergens(req.Allergies)
+	if err != nil {
+		return err
+	}
+	req.Allergies = allergies
+	for i := range req.Items {
+		allergies, err := normalizeAllergens(req.Items[i].Allergies)
+		if err != nil {
+			return err
+		}
+		req.Items[i].Allergies = allergies
+	}
+	return nil
+}
+
+// applyAllergies marca em cada item as alergias declaradas (pedido + item) e os alérgenos em conflito
+// Os produtos dos combos recebem os próprios alérgenos, usados nos itens gravados para a cozinha
+func applyAllergies(lines []pricedLine, dietary menuDietary, orderAllergies []string) {
+	for i := range lines {
+		line := &lines[i]
+		line.Allergens = dietary.lineAllergens(*line)
+		for j := range line.Components {
+			line.Components[j].Allergens, _, _ = dietary.product(line.Components[j].ProductID)
+		}
+		line.Allergies = mergeTags(orderAllergies, line.Item.Allergies)
+		line.AllergenConflicts = intersectTags(line.Allergens, line.Allergies)
+	}
+}
+
+// allergyConflicts lista os itens do pedido que contêm alérgenos declarados pelo cliente
+// As posições são as dos itens enviados: um produto que entrou num combo automático aparece
+// no item avulso de origem, e num combo pedido pelo cliente aparece o produto escolhido na etapa
+func (p orderPricing) allergyConflicts() []models.AllergyConflict {
+	var conflicts []models.AllergyConflict
+	add := func(conflict models.AllergyConflict) {
+		// O mesmo item enviado pode ter sido repartido entre combos e o restante avulso
+		for i := range conflicts {
+			if conflicts[i].Line == conflict.Line && conflicts[i].ProductID == conflict.ProductID &&
+				sameChoice(conflicts[i].Component, conflict.Component) {
+				conflicts[i].Allergens = mergeTags(conflicts[i].Allergens, conflict.Allergens)
+				return
+			}
+		}
+		conflicts = append(conflicts, conflict)
+	}
+
+	for _, line := range p.Lines {
+		if len(line.AllergenConflicts) == 0 {
+			continue
+		}
+		if !componentConflicts(line) {
+			add(models.AllergyConflict{Line: line.Index + 1, ProductID: line.Item.ProductID, Allergens: line.AllergenConflicts})
+			continue
+		}
+		for _, part := range line.Components {
+			allergens := intersectTags(part.Allergens, line.Allergies)
+			if len(allergens) == 0 {
+				continue
+			}
+			if line.Index < 0 {
+				add(models.AllergyConflict{Line: part.Index + 1, ProductID: part.ProductID, Allergens: allergens})
+			} else {
+				add(models.AllergyConflict{
+					Line:      line.Index + 1,
+					ProductID: line.Item.ProductID,
+					Component: &models.BundleChoice{SlotID: part.SlotID, ProductID: part.ProductID},
+					Allergens: allergens,
+				})
+			}
+		}
+	}
+	sort.SliceStable(conflicts, func(i, j int) bool { return conflicts[i].Line < conflicts[j].Line })
+	return conflicts
+}
+
+// componentConflicts indica se os alérgenos em conflito do item vêm dos produtos do combo
+func componentConflicts(line pricedLine) bool {
+	for _, part := range line.Components {
+		if len(intersectTags(part.Allergens, line.Allergies)) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// sameChoice indica se as duas escolhas de combo são o mesmo produto na mesma etapa (ou ambas vazias)
+func sameChoice(a, b *models.BundleChoice) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// respondAllergyConflict recusa o pedido não confirmado, listando os itens em conflito
+func respondAllergyConflict(c *gin.Context, conflicts []models.AllergyConflict) {
+	c.JSON(http.StatusConflict, gin.H{"error": errAllergyConflict.Error(), "allergy_conflicts": conflicts})
+}
+
+// mergeTags junta duas listas de códigos, sem repetição e em ordem
+func mergeTags(a, b []string) []string {
+	merged := []string{}
+	for _, tag := range append(append([]string{}, a...), b...) {
+		if !containsString(merged, tag) {
+			merged = append(merged, tag)
+		}
+	}
+	sort.Strings(merged)
+	return merged
+}
+
+// intersectTags retorna os códigos presentes nas duas listas, em ordem
+func intersectTags(a, b []string) []string {
+	common := []string{}
+	for _, tag := range a {
+		if containsString(b, tag) && !containsString(common, tag) {
+			common = append(common, tag)
+		}
+	}
+	sort.Strings(common)
+	return common
+}
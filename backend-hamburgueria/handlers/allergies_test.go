@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"testing"
+
+	"backend-hamburgueria/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Teste da padronização das alergias do pedido e dos itens
+func TestNormalizeOrderAllergies(t *testing.T) {
+	req := models.CreateOrderRequest{
+		Allergies: []string{"Lactose ", "gluten"},
+		Items:     []models.OrderItemRequest{{ProductID: 2, Allergies: []string{"NUTS"}}, {ProductID: 3}},
+	}
+	assert.NoError(t, normalizeOrderAllergies(&req))
+	assert.Equal(t, []string{"gluten", "lactose"}, req.Allergies)
+	assert.Equal(t, []string{"nuts"}, req.Items[0].Allergies)
+	assert.Equal(t, []string{}, req.Items[1].Allergies)
+
+	req = models.CreateOrderRequest{Items: []models.OrderItemRequest{{ProductID: 2, Allergies: []string{"pimenta"}}}}
+	var invalid *invalidTagError
+	assert.ErrorAs(t, normalizeOrderAllergies(&req), &invalid)
+}
+
+// Teste do cruzamento das alergias declaradas com os alérgenos dos itens
+func TestApplyAllergies(t *testing.T) {
+	dietary := sampleDietary()
+	lines := []pricedLine{
+		// Produto 5: glúten e lactose; alergia só do pedido
+		{Item: models.OrderItemRequest{ProductID: 5, Quantity: 1}, Index: 0},
+		// Produto 7: glúten; alergia a castanhas do item não conflita
+		{Item: models.OrderItemRequest{ProductID: 7, Quantity: 1, Allergies: []string{"nuts"}}, Index: 1},
+		// Combo com os produtos 7 e 6
+		{
+			Item:  models.OrderItemRequest{ProductID: 20, Quantity: 1},
+			Index: 2,
+			Components: []bundlePart{
+				{BundleComponent: models.BundleComponent{SlotID: 1, ProductID: 7}},
+				{BundleComponent: models.BundleComponent{SlotID: 2, ProductID: 6}},
+			},
+		},
+	}
+
+	applyAllergies(lines, dietary, []string{"lactose"})
+
+	assert.Equal(t, []string{"lactose"}, lines[0].AllergenConflicts)
+	assert.Equal(t, []string{"lactose", "nuts"}, lines[1].Allergies)
+	assert.Empty(t, lines[1].AllergenConflicts)
+	assert.Equal(t, []string{"lactose"}, lines[2].AllergenConflicts)
+	assert.Equal(t, []string{"gluten"}, lines[2].Components[0].Allergens)
+	assert.Equal(t, []string{"gluten", "lactose"}, lines[2].Components[1].Allergens)
+
+	conflicts := orderPricing{Lines: lines}.allergyConflicts()
+	assert.Len(t, conflicts, 2)
+	assert.Equal(t, models.AllergyConflict{Line: 1, ProductID: 5, Allergens: []string{"lactose"}}, conflicts[0])
+	assert.Equal(t, models.AllergyConflict{
+		Line:      3,
+		ProductID: 20,
+		Component: &models.BundleChoice{SlotID: 2, ProductID: 6},
+		Allergens: []string{"lactose"},
+	}, conflicts[1])
+}
+
+// Teste das posições do alerta de alergia depois dos combos automáticos: o conflito aponta
+// para o item avulso enviado pelo cliente, não para a posição do combo montado
+func TestAllergyConflictsAutoCombo(t *testing.T) {
+	dietary := sampleDietary()
+	lines := []pricedLine{
+		// Item 1: produto 6 (pão e cheddar: glúten e lactose), 2 unidades
+		{Item: models.OrderItemRequest{ProductID: 6, Quantity: 2}, Index: 0},
+		// Item 2: produto 7 (pão e alface: glúten)
+		{Item: models.OrderItemRequest{ProductID: 7, Quantity: 1}, Index: 1},
+	}
+	bundle := comboBundle{ProductID: 20, Slots: []models.BundleSlot{
+		{ID: 1, Options: []models.BundleOption{{ProductID: 7}}},
+		{ID: 2, Options: []models.BundleOption{{ProductID: 6}}},
+	}}
+	combo := buildComboLine(bundle, comboCandidate{Lines: []int{1, 0}}, lines)
+	// Depois do reagrupamento: uma unidade do item 1 sobra avulsa e o combo fica no fim
+	lines = []pricedLine{{Item: models.OrderItemRequest{ProductID: 6, Quantity: 1}, Index: 0}, combo}
+
+	applyAllergies(lines, dietary, []string{"lactose"})
+	conflicts := orderPricing{Lines: lines}.allergyConflicts()
+	assert.Equal(t, []models.AllergyConflict{{Line: 1, ProductID: 6, Allergens: []string{"lactose"}}}, conflicts)
+}
+
+// Teste da junção e interseção de listas de alérgenos
+func TestMergeAndIntersectTags(t *testing.T) {
+	assert.Equal(t, []string{"gluten", "lactose", "nuts"}, mergeTags([]string{"lactose", "gluten"}, []string{"nuts", "gluten"}))
+	assert.Equal(t, []string{}, mergeTags(nil, nil))
+	assert.Equal(t, []string{"lactose"}, intersectTags([]string{"gluten", "lactose"}, []string{"lactose", "nuts"}))
+	assert.Equal(t, []string{}, intersectTags([]string{"gluten"}, nil))
+}
@@ -0,0 +1,351 @@
+package handlers
+
+import (
+	"strconv"
+	"strings"
+
+	// Modelos de dados da aplicação
+	"backend-hamburgueria/models"
+)
+
+// ===== COMBOS AUTOMÁTICOS =====
+
+// maxComboSearch limita os estados visitados na busca do melhor agrupamento
+// Pedidos muito grandes ficam com o melhor resultado encontrado até o limite
+const maxComboSearch = 20000
+
+// comboBundle é um combo disponível, com as etapas usadas na detecção
+type comboBundle struct {
+	ProductID  int
+	Price      int64 // Preço base em centavos
+	Station    string
+	CategoryID int
+	Slots      []models.BundleSlot
+}
+
+// comboCandidate é uma forma de montar um combo com unidades dos itens avulsos
+type comboCandidate struct {
+	Bundle int   // Índice do combo
+	Lines  []int // Item usado em cada etapa (-1 = etapa opcional vazia)
+	Unit   int64 // Preço do combo (base + acréscimos), em centavos
+	Saving int64 // Economia em relação aos itens avulsos, em centavos
+}
+
+// regroupCombos troca itens avulsos pelos combos disponíveis quando o total fica menor
+// available filtra os combos fora do horário de venda
+// Retorna os itens reagrupados e a economia em centavos
+func regroupCombos(q queryer, lines []pricedLine, available func(productID, categoryID int) bool) ([]pricedLine, int64, error) {
+	eligible := false
+	for _, line := range lines {
+		if comboEligible(line) {
+			eligible = true
+			break
+		}
+	}
+	if !eligible {
+		return lines, 0, nil
+	}
+
+	loaded, err := loadComboBundles(q)
+	if err != nil {
+		return lines, 0, err
+	}
+	var bundles []comboBundle
+	for _, bundle := range loaded {
+		if available(bundle.ProductID, bundle.CategoryID) {
+			bundles = append(bundles, bundle)
+		}
+	}
+	if len(bundles) == 0 {
+		return lines, 0, nil
+	}
+	regrouped, saving := detectCombos(bundles, lines)
+	return regrouped, saving, nil
+}
+
+// comboEligible indica se o item pode entrar num combo automático
+// Lanches personalizados, itens com adicionais ou tamanho escolhido e itens que já são combos ficam como estão
+func comboEligible(line pricedLine) bool {
+	return line.Item.ProductID != customBurgerProductID && len(line.Components) == 0 && len(line.Modifiers) == 0 &&
+		line.Variant == nil && len(line.Item.Allergies) == 0
+}
+
+// loadComboBundles busca os combos disponíveis com as etapas e os produtos disponíveis em cada uma
+func loadComboBundles(q queryer) ([]comboBundle, error) {
+	rows, err := q.Query(`
+		SELECT b.id, b.price, b.station, COALESCE(b.category_id, 0),
+			s.id, s.name, s.position, s.is_required, o.product_id, p.name, o.upcharge, o.is_default
+		FROM products b
+		JOIN bundle_slots s ON s.bundle_id = b.id
+		JOIN bundle_slot_options o ON o.slot_id = s.id
+		JOIN products p ON p.id = o.product_id
+		WHERE b.is_bundle AND b.is_available AND p.is_available
+		ORDER BY b.id, s.position, o.upcharge, p.name
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var bundles []comboBundle
+	for rows.Next() {
+		var bundle comboBundle
+		var price float64
+		var slot models.BundleSlot
+		var option models.BundleOption
+		if err := rows.Scan(&bundle.ProductID, &price, &bundle.Station, &bundle.CategoryID,
+			&slot.ID, &slot.Name, &slot.Position, &slot.IsRequired,
+			&option.ProductID, &option.ProductName, &option.Upcharge, &option.IsDefault); err != nil {
+			return nil, err
+		}
+		bundle.Price = toCents(price)
+		if len(bundles) == 0 || bundles[len(bundles)-1].ProductID != bundle.ProductID {
+			bundles = append(bundles, bundle)
+		}
+		last := &bundles[len(bundles)-1]
+		if len(last.Slots) == 0 || last.Slots[len(last.Slots)-1].ID != slot.ID {
+			last.Slots = append(last.Slots, slot)
+		}
+		current := &last.Slots[len(last.Slots)-1]
+		current.Options = append(current.Options, option)
+	}
+	return bundles, rows.Err()
+}
+
+// detectCombos encontra o agrupamento de itens avulsos em combos com a maior economia
+// Cada combo montado vira um item novo; as unidades usadas saem dos itens originais
+func detectCombos(bundles []comboBundle, lines []pricedLine) ([]pricedLine, int64) {
+	candidates := comboCandidates(bundles, lines)
+	if len(candidates) == 0 {
+		return lines, 0
+	}
+
+	// ===== BUSCA DO MELHOR AGRUPAMENTO =====
+	// Combos escolhidos em ordem não decrescente de candidato, para não repetir agrupamentos
+	remaining := make([]int, len(lines))
+	for i, line := range lines {
+		if comboEligible(line) {
+			remaining[i] = line.Item.Quantity
+		}
+	}
+	type result struct {
+		saving int64
+		picks  []int
+	}
+	memo := make(map[string]result)
+	visited := 0
+	var search func(start int) result
+	search = func(start int) result {
+		key := comboStateKey(remaining, start)
+		if cached, ok := memo[key]; ok {
+			return cached
+		}
+		best := result{}
+		visited++
+		for i := start; i < len(candidates) && visited < maxComboSearch; i++ {
+			candidate := candidates[i]
+			if !takeComboUnits(remaining, candidate.Lines, -1) {
+				continue
+			}
+			next := search(i)
+			takeComboUnits(remaining, candidate.Lines, 1)
+			if saving := candidate.Saving + next.saving; saving > best.saving {
+				best = result{saving: saving, picks: append([]int{i}, next.picks...)}
+			}
+		}
+		memo[key] = best
+		return best
+	}
+	best := search(0)
+	if best.saving <= 0 {
+		return lines, 0
+	}
+
+	// ===== MONTAR OS ITENS =====
+	used := make([]int, len(lines))
+	var combos []pricedLine
+	for n, pick := range best.picks {
+		candidate := candidates[pick]
+		for _, index := range candidate.Lines {
+			if index >= 0 {
+				used[index]++
+			}
+		}
+		// Combos iguais (mesmo combo com os mesmos itens) vêm em sequência e viram um item só
+		if n > 0 && best.picks[n-1] == pick {
+			last := &combos[len(combos)-1]
+			last.Item.Quantity++
+			last.ComboSaving += candidate.Saving
+			continue
+		}
+		combos = append(combos, buildComboLine(bundles[candidate.Bundle], candidate, lines))
+	}
+
+	var regrouped []pricedLine
+	for i, line := range lines {
+		if left := line.Item.Quantity - used[i]; left > 0 {
+			line.Item.Quantity = left
+			line.TotalPrice = fromCents(toCents(line.UnitPrice) * int64(left))
+			regrouped = append(regrouped, line)
+		}
+	}
+	for _, combo := range combos {
+		combo.TotalPrice = fromCents(toCents(combo.UnitPrice) * int64(combo.Item.Quantity))
+		regrouped = append(regrouped, combo)
+	}
+	return regrouped, best.saving
+}
+
+// comboCandidates lista as formas de montar cada combo com os itens do pedido que geram economia
+func comboCandidates(bundles []comboBundle, lines []pricedLine) []comboCandidate {
+	var candidates []comboCandidate
+	for b, bundle := range bundles {
+		// Itens aceitos em cada etapa (-1 = deixar a etapa opcional vazia)
+		options := make([][]int, len(bundle.Slots))
+		upcharges := make([]map[int]int64, len(bundle.Slots))
+		formable := true
+		for s, slot := range bundle.Slots {
+			upcharges[s] = make(map[int]int64)
+			for i, line := range lines {
+				if !comboEligible(line) {
+					continue
+				}
+				for _, option := range slot.Options {
+					if option.ProductID == line.Item.ProductID {
+						options[s] = append(options[s], i)
+						upcharges[s][i] = toCents(option.Upcharge)
+						break
+					}
+				}
+			}
+			if !slot.IsRequired {
+				options[s] = append(options[s], -1)
+			}
+			if len(options[s]) == 0 {
+				formable = false
+				break
+			}
+		}
+		if !formable {
+			continue
+		}
+
+		// Todas as combinações de itens por etapa
+		choice := make([]int, len(bundle.Slots))
+		var walk func(s int)
+		walk = func(s int) {
+			if s == len(bundle.Slots) {
+				unit, separate := bundle.Price, int64(0)
+				filled := false
+				for slot, index := range choice {
+					if index < 0 {
+						continue
+					}
+					filled = true
+					unit += upcharges[slot][index]
+					separate += toCents(lines[index].UnitPrice)
+				}
+				if filled && separate > unit && comboUnitsAvailable(lines, choice) {
+					candidates = append(candidates, comboCandidate{
+						Bundle: b,
+						Lines:  append([]int(nil), choice...),
+						Unit:   unit,
+						Saving: separate - unit,
+					})
+				}
+				return
+			}
+			for _, index := range options[s] {
+				choice[s] = index
+				walk(s + 1)
+			}
+		}
+		walk(0)
+	}
+	return candidates
+}
+
+// comboUnitsAvailable confere se o pedido tem unidades suficientes de cada item para o combo
+func comboUnitsAvailable(lines []pricedLine, choice []int) bool {
+	need := make(map[int]int)
+	for _, index := range choice {
+		if index >= 0 {
+			need[index]++
+		}
+	}
+	for index, quantity := range need {
+		if lines[index].Item.Quantity < quantity {
+			return false
+		}
+	}
+	return true
+}
+
+// takeComboUnits retira (sign = -1) ou devolve (sign = 1) as unidades usadas por um combo
+// Retorna false, sem alterar nada, quando não há unidades suficientes para retirar
+func takeComboUnits(remaining []int, choice []int, sign int) bool {
+	if sign < 0 {
+		need := make(map[int]int)
+		for _, index := range choice {
+			if index >= 0 {
+				need[index]++
+			}
+		}
+		for index, quantity := range need {
+			if remaining[index] < quantity {
+				return false
+			}
+		}
+	}
+	for _, index := range choice {
+		if index >= 0 {
+			remaining[index] += sign
+		}
+	}
+	return true
+}
+
+// comboStateKey identifica o estado da busca (unidades restantes e próximo candidato)
+func comboStateKey(remaining []int, start int) string {
+	var key strings.Builder
+	key.WriteString(strconv.Itoa(start))
+	for _, quantity := range remaining {
+		key.WriteByte(':')
+		key.WriteString(strconv.Itoa(quantity))
+	}
+	return key.String()
+}
+
+// buildComboLine monta o item do combo com os produtos escolhidos e a divisão do preço
+func buildComboLine(bundle comboBundle, candidate comboCandidate, lines []pricedLine) pricedLine {
+	line := pricedLine{
+		Item:        models.OrderItemRequest{ProductID: bundle.ProductID, Quantity: 1},
+		Index:       -1,
+		Station:     bundle.Station,
+		CategoryID:  bundle.CategoryID,
+		UnitPrice:   fromCents(candidate.Unit),
+		ComboSaving: candidate.Saving,
+	}
+	var parts []bundlePart
+	var prices []int64
+	for s, index := range candidate.Lines {
+		if index < 0 {
+			continue
+		}
+		slot := bundle.Slots[s]
+		source := lines[index]
+		component := models.BundleComponent{SlotID: slot.ID, SlotName: slot.Name, ProductID: source.Item.ProductID}
+		for _, option := range slot.Options {
+			if option.ProductID == source.Item.ProductID {
+				component.ProductName = option.ProductName
+				component.Upcharge = option.Upcharge
+			}
+		}
+		line.Item.Choices = append(line.Item.Choices, models.BundleChoice{SlotID: slot.ID, ProductID: source.Item.ProductID})
+		parts = append(parts, bundlePart{BundleComponent: component, Station: source.Station, CategoryID: source.CategoryID,
+			Notes: source.Item.Notes, Index: source.Index})
+		prices = append(prices, toCents(source.UnitPrice))
+	}
+	line.Components = splitBundlePrice(candidate.Unit, parts, prices)
+	return line
+}
@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"testing"
+
+	"backend-hamburgueria/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// classicCombo: R$ 35,00 com lanche (Classic R$ 28 ou Bacon +R$ 4), batata (R$ 12) e bebida (R$ 8)
+func classicCombo() comboBundle {
+	return comboBundle{ProductID: 20, Price: 3500, Station: "grill", CategoryID: 4, Slots: []models.BundleSlot{
+		{ID: 1, Name: "Lanche", IsRequired: true, Options: []models.BundleOption{
+			{ProductID: 2, ProductName: "Classic"}, {ProductID: 3, ProductName: "Bacon", Upcharge: 4},
+		}},
+		{ID: 2, Name: "Acompanhamento", IsRequired: true, Options: []models.BundleOption{{ProductID: 5, ProductName: "Batata"}}},
+		{ID: 3, Name: "Bebida", IsRequired: true, Options: []models.BundleOption{{ProductID: 7, ProductName: "Refrigerante"}}},
+	}}
+}
+
+// comboLine monta um item avulso já precificado
+func comboLine(productID, quantity int, unitPrice float64, station string) pricedLine {
+	return pricedLine{
+		Item:       models.OrderItemRequest{ProductID: productID, Quantity: quantity},
+		Station:    station,
+		UnitPrice:  unitPrice,
+		TotalPrice: unitPrice * float64(quantity),
+	}
+}
+
+// Teste do reagrupamento: 2 lanches, 2 batatas e 3 refrigerantes viram 2 combos + 1 refrigerante
+func TestDetectCombos(t *testing.T) {
+	lines := []pricedLine{
+		comboLine(2, 1, 28, "grill"),
+		comboLine(3, 1, 32, "grill"),
+		comboLine(5, 2, 12, "fryer"),
+		comboLine(7, 3, 8, "bar"),
+	}
+
+	regrouped, saving := detectCombos([]comboBundle{classicCombo()}, lines)
+	// Classic: 48 → 35 (R$ 13); Bacon: 52 → 39 (R$ 13)
+	assert.Equal(t, int64(2600), saving)
+	assert.Len(t, regrouped, 3)
+
+	assert.Equal(t, 7, regrouped[0].Item.ProductID)
+	assert.Equal(t, 1, regrouped[0].Item.Quantity)
+	assert.Equal(t, 8.0, regrouped[0].TotalPrice)
+
+	combo := regrouped[1]
+	assert.Equal(t, 20, combo.Item.ProductID)
+	assert.Equal(t, 35.0, combo.UnitPrice)
+	assert.Equal(t, int64(1300), combo.ComboSaving)
+	assert.Len(t, combo.Components, 3)
+	assert.Equal(t, "fryer", combo.Components[1].Station)
+
+	// A divisão do preço entre os produtos soma o valor do combo
+	var sum int64
+	for _, part := range regrouped[2].Components {
+		sum += toCents(part.UnitPrice)
+	}
+	assert.Equal(t, int64(3900), sum)
+	assert.Equal(t, 3, regrouped[2].Components[0].ProductID)
+}
+
+// Teste sem economia ou sem itens suficientes: o pedido fica como está
+func TestDetectCombosNoSaving(t *testing.T) {
+	lines := []pricedLine{comboLine(2, 1, 28, "grill"), comboLine(5, 1, 12, "fryer")}
+	regrouped, saving := detectCombos([]comboBundle{classicCombo()}, lines)
+	assert.Equal(t, int64(0), saving)
+	assert.Equal(t, lines, regrouped)
+
+	cheap := []pricedLine{comboLine(2, 1, 20, "grill"), comboLine(5, 1, 8, "fryer"), comboLine(7, 1, 5, "bar")}
+	_, saving = detectCombos([]comboBundle{classicCombo()}, cheap)
+	assert.Equal(t, int64(0), saving)
+}
+
+// Teste da etapa opcional: entra no combo só quando reduz o total
+func TestDetectCombosOptionalSlot(t *testing.T) {
+	bundle := classicCombo()
+	bundle.Slots = append(bundle.Slots, models.BundleSlot{ID: 4, Name: "Sobremesa", Options: []models.BundleOption{
+		{ProductID: 9, ProductName: "Brownie", Upcharge: 6},
+	}})
+	lines := []pricedLine{
+		comboLine(2, 1, 28, "grill"), comboLine(5, 1, 12, "fryer"), comboLine(7, 1, 8, "bar"), comboLine(9, 1, 10, "bar"),
+	}
+
+	regrouped, saving := detectCombos([]comboBundle{bundle}, lines)
+	// 58 avulso → 41 no combo com sobremesa
+	assert.Equal(t, int64(1700), saving)
+	assert.Len(t, regrouped, 1)
+	assert.Len(t, regrouped[0].Components, 4)
+}
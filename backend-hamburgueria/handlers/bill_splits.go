@@ -0,0 +1,729 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	// Modelos de dados da aplicação
+	"backend-hamburgueria/models"
+
+	// Framework web Gin
+	"github.com/gin-gonic/gin"
+	// Driver do PostgreSQL (arrays)
+	"github.com/lib/pq"
+)
+
+// ===== DIVISÃO DA CONTA =====
+
+// Modos de divisão
+const (
+	splitEqual  = "equal"  // Partes iguais entre N pessoas
+	splitItems  = "items"  // Cada parcela paga os itens que consumiu
+	splitCustom = "custom" // Valores informados pelo caixa
+)
+
+// Status da divisão
+const (
+	splitOpen      = "open"      // Aguardando pagamentos
+	splitPaid      = "paid"      // Todas as parcelas pagas
+	splitCancelled = "cancelled" // Substituída por outra divisão
+)
+
+// maxSplitShares limita o número de parcelas de uma divisão
+const maxSplitShares = 50
+
+// Erros do cálculo das parcelas
+var (
+	errSplitEmpty         = errors.New("Conta sem consumo para dividir")
+	errSplitUnknownItem   = errors.New("Item não pertence a esta conta")
+	errSplitUncoveredItem = errors.New("Todos os itens da conta precisam estar em alguma parcela")
+	errSplitCustomTotal   = errors.New("A soma das parcelas precisa ser igual ao valor da conta")
+)
+
+// Erros da divisão diante de mudanças na comanda
+var (
+	errSplitPaidShares = errors.New("Conta já tem parcelas pagas, a comanda não pode mudar")
+	errSplitOpen       = errors.New("Conta dividida com parcelas em aberto, receba as parcelas antes de fechar")
+	errSplitOutdated   = errors.New("A divisão paga não corresponde ao valor atual da comanda")
+)
+
+// billLine é um item da conta, em centavos
+type billLine struct {
+	ItemID int
+	Cents  int64
+}
+
+// billShareDraft é uma parcela calculada, antes de ser gravada
+type billShareDraft struct {
+	Label   string
+	Cents   int64 // Consumo da parcela
+	Service int64 // Taxa de serviço da parcela
+	ItemIDs []int
+}
+
+// CreateTableSessionSplit godoc
+// @Summary      Divide a conta da mesa
+// @Description  Divide a comanda em parcelas iguais (equal), por itens (items) ou por valores (custom), com taxa de serviço e centavos distribuídos
+// @Tags         Tables
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                            true  "ID da comanda"
+// @Param        body  body      models.CreateBillSplitRequest  true  "Modo e parcelas"
+// @Success      201   {object}  models.BillSplit
+// @Failure      400   {object}  models.ErrorResponse "Dados inválidos"
+// @Failure      404   {object}  models.ErrorResponse "Comanda não encontrada"
+// @Failure      409   {object}  models.ErrorResponse "Conta já tem parcelas pagas"
+// @Router       /api/table-sessions/{id}/split [post]
+func CreateTableSessionSplit(c *gin.Context, db DBInterface) {
+	createBillSplit(c, db, true)
+}
+
+// CreateOrderSplit godoc
+// @Summary      Divide a conta de um pedido
+// @Description  Divide um pedido fora de comanda em parcelas iguais (equal), por itens (items) ou por valores (custom)
+// @Tags         Orders
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                            true  "ID do pedido"
+// @Param        body  body      models.CreateBillSplitRequest  true  "Modo e parcelas"
+// @Success      201   {object}  models.BillSplit
+// @Failure      400   {object}  models.ErrorResponse "Dados inválidos"
+// @Failure      404   {object}  models.ErrorResponse "Pedido não encontrado"
+// @Failure      409   {object}  models.ErrorResponse "Conta já tem parcelas pagas"
+// @Router       /api/orders/{id}/split [post]
+func CreateOrderSplit(c *gin.Context, db DBInterface) {
+	createBillSplit(c, db, false)
+}
+
+// GetTableSessionSplit godoc
+// @Summary      Divisão atual da conta da mesa
+// @Description  Retorna a divisão em vigor da comanda, com o andamento dos pagamentos
+// @Tags         Tables
+// @Produce      json
+// @Param        id   path      int  true  "ID da comanda"
+// @Success      200  {object}  models.BillSplit
+// @Failure      404  {object}  models.ErrorResponse "Conta não dividida"
+// @Router       /api/table-sessions/{id}/split [get]
+func GetTableSessionSplit(c *gin.Context, db DBInterface) {
+	sessionID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID da comanda inválido"})
+		return
+	}
+
+	var splitID int
+	err = db.QueryRow(`
+		SELECT id FROM bill_splits WHERE table_session_id = $1 AND status <> $2 ORDER BY id DESC LIMIT 1
+	`, sessionID, splitCancelled).Scan(&splitID)
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Conta não dividida"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar divisão da conta"})
+		return
+	}
+
+	split, err := loadBillSplit(db, splitID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar divisão da conta"})
+		return
+	}
+	c.JSON(http.StatusOK, split)
+}
+
+// GetBillSplit godoc
+// @Summary      Detalhes da divisão da conta
+// @Tags         Tables
+// @Produce      json
+// @Param        id   path      int  true  "ID da divisão"
+// @Success      200  {object}  models.BillSplit
+// @Failure      404  {object}  models.ErrorResponse "Divisão não encontrada"
+// @Router       /api/bill-splits/{id} [get]
+func GetBillSplit(c *gin.Context, db DBInterface) {
+	splitID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID da divisão inválido"})
+		return
+	}
+
+	split, err := loadBillSplit(db, splitID)
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Divisão não encontrada"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar divisão da conta"})
+		return
+	}
+	c.JSON(http.StatusOK, split)
+}
+
+// PayBillShare godoc
+// @Summary      Registra o pagamento de uma parcela
+// @Description  Marca a parcela como paga; quando todas estão pagas a divisão fica como paid
+// @Tags         Tables
+// @Accept       json
+// @Produce      json
+// @Param        id        path      int                         true  "ID da divisão"
+// @Param        share_id  path      int                         true  "ID da parcela"
+// @Param        body      body      models.PayBillShareRequest  true  "Forma de pagamento"
+// @Success      200       {object}  models.BillSplit
+// @Failure      400       {object}  models.ErrorResponse "Forma de pagamento inválida"
+// @Failure      404       {object}  models.ErrorResponse "Parcela não encontrada"
+// @Failure      409       {object}  models.ErrorResponse "Parcela já paga ou divisão cancelada"
+// @Router       /api/bill-splits/{id}/shares/{share_id}/pay [post]
+func PayBillShare(c *gin.Context, db DBInterface) {
+	// ===== VALIDAR ENTRADA =====
+	splitID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID da divisão inválido"})
+		return
+	}
+	shareID, err := strconv.Atoi(c.Param("share_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID da parcela inválido"})
+		return
+	}
+	var req models.PayBillShareRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos"})
+		return
+	}
+	if !validPaymentMethod(req.PaymentMethod) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Forma de pagamento inválida (use cash, card ou pix)"})
+		return
+	}
+
+	// ===== INICIAR TRANSAÇÃO =====
+	tx, err := db.Begin()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao iniciar transação"})
+		return
+	}
+	defer tx.Rollback()
+
+	// Travar a divisão: pagamentos simultâneos de parcelas diferentes fecham a conta uma única vez
+	var splitStatus string
+	var paid bool
+	err = tx.QueryRow(`
+		SELECT b.status, s.paid_at IS NOT NULL
+		FROM bill_split_shares s
+		JOIN bill_splits b ON b.id = s.split_id
+		WHERE s.id = $1 AND s.split_id = $2
+		FOR UPDATE OF b, s
+	`, shareID, splitID).Scan(&splitStatus, &paid)
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Parcela não encontrada"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar parcela"})
+		return
+	}
+	if splitStatus == splitCancelled {
+		c.JSON(http.StatusConflict, gin.H{"error": "Divisão cancelada, consulte a divisão atual da conta"})
+		return
+	}
+	if paid {
+		c.JSON(http.StatusConflict, gin.H{"error": "Parcela já paga"})
+		return
+	}
+
+	// ===== REGISTRAR PAGAMENTO =====
+	if _, err := tx.Exec(`
+		UPDATE bill_split_shares SET payment_method = $1, paid_at = CURRENT_TIMESTAMP WHERE id = $2
+	`, req.PaymentMethod, shareID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao registrar pagamento"})
+		return
+	}
+	if _, err := tx.Exec(`
+		UPDATE bill_splits SET status = $1
+		WHERE id = $2 AND NOT EXISTS (SELECT 1 FROM bill_split_shares WHERE split_id = $2 AND paid_at IS NULL)
+	`, splitPaid, splitID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao registrar pagamento"})
+		return
+	}
+
+	split, err := loadBillSplit(tx, splitID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao registrar pagamento"})
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao registrar pagamento"})
+		return
+	}
+
+	c.JSON(http.StatusOK, split)
+}
+
+// createBillSplit divide a conta de uma comanda (forSession) ou de um pedido
+// Uma nova divisão substitui a anterior enquanto nenhuma parcela foi paga
+func createBillSplit(c *gin.Context, db DBInterface, forSession bool) {
+	// ===== VALIDAR ENTRADA =====
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+	var req models.CreateBillSplitRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos"})
+		return
+	}
+	if err := validateBillSplitRequest(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// ===== INICIAR TRANSAÇÃO =====
+	tx, err := db.Begin()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao iniciar transação"})
+		return
+	}
+	defer tx.Rollback()
+
+	// ===== TRAVAR A CONTA =====
+	// billColumn identifica a conta nas tabelas bill_splits e orders
+	billColumn := "table_session_id"
+	if forSession {
+		var status string
+		err = tx.QueryRow(`SELECT status FROM table_sessions WHERE id = $1 FOR UPDATE`, id).Scan(&status)
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Comanda não encontrada"})
+			return
+		}
+		if err == nil && status == tableSessionMerged {
+			c.JSON(http.StatusConflict, gin.H{"error": "Comanda juntada a outra mesa, divida a conta da mesa que a recebeu"})
+			return
+		}
+	} else {
+		billColumn = "id"
+		var sessionID *int
+		err = tx.QueryRow(`
+			SELECT table_session_id FROM orders WHERE id = $1 FOR UPDATE
+		`, id).Scan(&sessionID)
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Pedido não encontrado"})
+			return
+		}
+		if err == nil && sessionID != nil {
+			c.JSON(http.StatusConflict, gin.H{"error": "Pedido faz parte de uma comanda, divida a conta da mesa"})
+			return
+		}
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar conta"})
+		return
+	}
+
+	// ===== DIVISÃO ANTERIOR =====
+	splitColumn := "table_session_id"
+	if !forSession {
+		splitColumn = "order_id"
+	}
+	var paidShares int
+	err = tx.QueryRow(`
+		SELECT COUNT(s.id) FROM bill_splits b
+		JOIN bill_split_shares s ON s.split_id = b.id
+		WHERE b.`+splitColumn+` = $1 AND b.status <> $2 AND s.paid_at IS NOT NULL
+	`, id, splitCancelled).Scan(&paidShares)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar divisão anterior"})
+		return
+	}
+	if paidShares > 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": "Conta já tem parcelas pagas"})
+		return
+	}
+	if _, err := tx.Exec(`
+		UPDATE bill_splits SET status = $1 WHERE `+splitColumn+` = $2 AND status <> $1
+	`, splitCancelled, id); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao cancelar divisão anterior"})
+		return
+	}
+
+	// ===== ITENS E TOTAL DA CONTA =====
+	// A taxa de serviço já vem calculada em cada pedido; o restante é o valor a dividir
+	var billAmount, serviceAmount float64
+	if err := tx.QueryRow(`
+		SELECT COALESCE(SUM(total_amount - service_charge), 0), COALESCE(SUM(service_charge), 0)
+		FROM orders WHERE `+billColumn+` = $1
+	`, id).Scan(&billAmount, &serviceAmount); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao calcular conta"})
+		return
+	}
+	rows, err := tx.Query(`
+		SELECT oi.id, oi.total_price
+		FROM order_items oi
+		JOIN orders o ON o.id = oi.order_id
+		WHERE o.`+billColumn+` = $1
+		ORDER BY oi.id
+	`, id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar itens da conta"})
+		return
+	}
+	lines := []billLine{}
+	for rows.Next() {
+		var line billLine
+		var price float64
+		if err := rows.Scan(&line.ItemID, &price); err != nil {
+			rows.Close()
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao ler item da conta"})
+			return
+		}
+		line.Cents = toCents(price)
+		lines = append(lines, line)
+	}
+	rows.Close()
+
+	// ===== CALCULAR PARCELAS =====
+	// O cliente pode recusar a taxa de serviço no fechamento
+	serviceCents := toCents(serviceAmount)
+	if req.IncludeServiceCharge != nil && !*req.IncludeServiceCharge {
+		serviceCents = 0
+	}
+	shares, err := buildBillShares(req, lines, toCents(billAmount), serviceCents)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// ===== GRAVAR DIVISÃO =====
+	var subtotal, service int64
+	for _, share := range shares {
+		subtotal += share.Cents
+		service += share.Service
+	}
+	var sessionID, orderID *int
+	if forSession {
+		sessionID = &id
+	} else {
+		orderID = &id
+	}
+	var splitID int
+	if err := tx.QueryRow(`
+		INSERT INTO bill_splits (table_session_id, order_id, mode, subtotal_cents, service_charge_cents)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id
+	`, sessionID, orderID, req.Mode, subtotal, service).Scan(&splitID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao criar divisão da conta"})
+		return
+	}
+	for i, share := range shares {
+		if _, err := tx.Exec(`
+			INSERT INTO bill_split_shares (split_id, position, label, subtotal_cents, service_charge_cents, item_ids)
+			VALUES ($1, $2, $3, $4, $5, $6)
+		`, splitID, i+1, share.Label, share.Cents, share.Service, pq.Array(share.ItemIDs)); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao criar parcela"})
+			return
+		}
+	}
+
+	split, err := loadBillSplit(tx, splitID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao criar divisão da conta"})
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao criar divisão da conta"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, split)
+}
+
+// ===== FUNÇÕES AUXILIARES =====
+
+// validateBillSplitRequest confere o modo e as parcelas informadas
+func validateBillSplitRequest(req models.CreateBillSplitRequest) error {
+	switch req.Mode {
+	case splitEqual:
+		if req.People < 2 || req.People > maxSplitShares {
+			return errors.New("Número de pessoas inválido (2 a " + strconv.Itoa(maxSplitShares) + ")")
+		}
+		return nil
+	case splitItems, splitCustom:
+		if len(req.Shares) == 0 || len(req.Shares) > maxSplitShares {
+			return errors.New("Informe de 1 a " + strconv.Itoa(maxSplitShares) + " parcelas")
+		}
+	default:
+		return errors.New("Modo de divisão inválido (use equal, items ou custom)")
+	}
+
+	for _, share := range req.Shares {
+		if req.Mode == splitCustom && share.Amount <= 0 {
+			return errors.New("Valor da parcela inválido")
+		}
+		if req.Mode == splitItems {
+			if len(share.ItemIDs) == 0 {
+				return errors.New("Cada parcela precisa de ao menos um item")
+			}
+			for _, itemID := range share.ItemIDs {
+				if itemID <= 0 {
+					return errors.New("Item inválido na parcela")
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// buildBillShares calcula as parcelas em centavos
+// totalCents é o valor da conta sem a taxa de serviço; a diferença para a soma dos itens
+// (ex.: taxa de entrega, descontos) é dividida na proporção do consumo. A taxa de serviço
+// é repartida na mesma proporção das parcelas, de forma que a soma feche exatamente com o total.
+func buildBillShares(req models.CreateBillSplitRequest, lines []billLine, totalCents, serviceCents int64) ([]billShareDraft, error) {
+	var itemsCents int64
+	for _, line := range lines {
+		itemsCents += line.Cents
+	}
+	if totalCents <= 0 {
+		return nil, errSplitEmpty
+	}
+
+	var shares []billShareDraft
+	switch req.Mode {
+	case splitEqual:
+		shares = make([]billShareDraft, req.People)
+		for i, cents := range allocateCents(totalCents, make([]int64, req.People)) {
+			shares[i].Cents = cents
+		}
+
+	case splitItems:
+		shares = make([]billShareDraft, len(req.Shares))
+		known := make(map[int]bool, len(lines))
+		for _, line := range lines {
+			known[line.ItemID] = true
+		}
+		// sharers lista as parcelas de cada item (um item pode ser dividido entre várias)
+		sharers := make(map[int][]int)
+		for i, share := range req.Shares {
+			seen := make(map[int]bool)
+			for _, itemID := range share.ItemIDs {
+				if !known[itemID] {
+					return nil, errSplitUnknownItem
+				}
+				if seen[itemID] {
+					continue
+				}
+				seen[itemID] = true
+				sharers[itemID] = append(sharers[itemID], i)
+				shares[i].ItemIDs = append(shares[i].ItemIDs, itemID)
+			}
+		}
+		consumption := make([]int64, len(shares))
+		for _, line := range lines {
+			owners := sharers[line.ItemID]
+			if len(owners) == 0 {
+				return nil, errSplitUncoveredItem
+			}
+			for i, cents := range allocateCents(line.Cents, make([]int64, len(owners))) {
+				consumption[owners[i]] += cents
+			}
+		}
+		for i, extra := range allocateCents(totalCents-itemsCents, consumption) {
+			shares[i].Cents = consumption[i] + extra
+		}
+
+	case splitCustom:
+		shares = make([]billShareDraft, len(req.Shares))
+		var sum int64
+		for i, share := range req.Shares {
+			shares[i].Cents = toCents(share.Amount)
+			sum += shares[i].Cents
+		}
+		if sum != totalCents {
+			return nil, errSplitCustomTotal
+		}
+	}
+
+	weights := make([]int64, len(shares))
+	for i := range shares {
+		weights[i] = shares[i].Cents
+	}
+	for i, cents := range allocateCents(serviceCents, weights) {
+		shares[i].Service = cents
+	}
+	for i := range shares {
+		shares[i].Label = "Pessoa " + strconv.Itoa(i+1)
+		if i < len(req.Shares) && strings.TrimSpace(req.Shares[i].Label) != "" {
+			shares[i].Label = strings.TrimSpace(req.Shares[i].Label)
+		}
+	}
+	return shares, nil
+}
+
+// cancelSessionSplits cancela a divisão em vigor da comanda, que deixa de valer quando os pedidos mudam
+// Com parcelas já pagas a comanda não pode mudar: retorna errSplitPaidShares
+func cancelSessionSplits(q queryer, sessionID int) error {
+	// Travar as divisões: um pagamento simultâneo termina antes da conferência das parcelas
+	rows, err := q.Query(`
+		SELECT id FROM bill_splits WHERE table_session_id = $1 AND status <> $2 FOR UPDATE
+	`, sessionID, splitCancelled)
+	if err != nil {
+		return err
+	}
+	splitIDs, err := scanIDs(rows)
+	if err != nil || len(splitIDs) == 0 {
+		return err
+	}
+
+	var paidShares int
+	if err := q.QueryRow(`
+		SELECT COUNT(*) FROM bill_split_shares WHERE split_id = ANY($1) AND paid_at IS NOT NULL
+	`, pq.Array(splitIDs)).Scan(&paidShares); err != nil {
+		return err
+	}
+	if paidShares > 0 {
+		return errSplitPaidShares
+	}
+	_, err = q.Exec(`UPDATE bill_splits SET status = $1 WHERE id = ANY($2)`, splitCancelled, pq.Array(splitIDs))
+	return err
+}
+
+// checkSessionSplit confere a divisão da comanda antes do fechamento
+// Sem divisão não há o que conferir; com divisão, todas as parcelas precisam estar pagas
+// e os valores precisam bater com os pedidos atuais da comanda
+func checkSessionSplit(q queryer, sessionID int) error {
+	var status string
+	var subtotal, service int64
+	err := q.QueryRow(`
+		SELECT status, subtotal_cents, service_charge_cents FROM bill_splits
+		WHERE table_session_id = $1 AND status <> $2 ORDER BY id DESC LIMIT 1
+	`, sessionID, splitCancelled).Scan(&status, &subtotal, &service)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if status == splitOpen {
+		return errSplitOpen
+	}
+
+	var billAmount, serviceAmount float64
+	if err := q.QueryRow(`
+		SELECT COALESCE(SUM(total_amount - service_charge), 0), COALESCE(SUM(service_charge), 0)
+		FROM orders WHERE table_session_id = $1
+	`, sessionID).Scan(&billAmount, &serviceAmount); err != nil {
+		return err
+	}
+	if !splitMatchesBill(subtotal, service, toCents(billAmount), toCents(serviceAmount)) {
+		return errSplitOutdated
+	}
+	return nil
+}
+
+// splitMatchesBill indica se os valores da divisão batem com a conta (em centavos)
+// A taxa de serviço pode ter sido recusada pelo cliente na divisão
+func splitMatchesBill(splitSubtotal, splitService, billCents, serviceCents int64) bool {
+	return splitSubtotal == billCents && (splitService == serviceCents || splitService == 0)
+}
+
+// allocateCents reparte total na proporção dos pesos pelo método do maior resto
+// Os centavos que sobram do arredondamento vão para as maiores frações (empate: primeira parcela)
+// Pesos todos zerados dividem em partes iguais; um total negativo (desconto) é repartido do mesmo jeito.
+func allocateCents(total int64, weights []int64) []int64 {
+	result := make([]int64, len(weights))
+	if len(weights) == 0 {
+		return result
+	}
+	if total < 0 {
+		for i, cents := range allocateCents(-total, weights) {
+			result[i] = -cents
+		}
+		return result
+	}
+	var sum int64
+	for _, weight := range weights {
+		sum += weight
+	}
+	if sum <= 0 {
+		weights = make([]int64, len(weights))
+		for i := range weights {
+			weights[i] = 1
+		}
+		sum = int64(len(weights))
+	}
+
+	remainders := make([]int64, len(weights))
+	allocated := int64(0)
+	for i, weight := range weights {
+		result[i] = total * weight / sum
+		remainders[i] = total * weight % sum
+		allocated += result[i]
+	}
+	for left := total - allocated; left > 0; left-- {
+		best := 0
+		for i := range remainders {
+			if remainders[i] > remainders[best] {
+				best = i
+			}
+		}
+		result[best]++
+		remainders[best] = -1
+	}
+	return result
+}
+
+// validPaymentMethod indica se a forma de pagamento é aceita
+func validPaymentMethod(method string) bool {
+	return method == "cash" || method == "card" || method == "pix"
+}
+
+// loadBillSplit busca a divisão com suas parcelas
+func loadBillSplit(q queryer, splitID int) (models.BillSplit, error) {
+	var split models.BillSplit
+	var subtotal, service int64
+	err := q.QueryRow(`
+		SELECT id, table_session_id, order_id, mode, status, subtotal_cents, service_charge_cents, created_at
+		FROM bill_splits WHERE id = $1
+	`, splitID).Scan(&split.ID, &split.TableSessionID, &split.OrderID, &split.Mode, &split.Status,
+		&subtotal, &service, &split.CreatedAt)
+	if err != nil {
+		return split, err
+	}
+	split.Subtotal = fromCents(subtotal)
+	split.ServiceCharge = fromCents(service)
+	split.Total = fromCents(subtotal + service)
+
+	rows, err := q.Query(`
+		SELECT id, label, subtotal_cents, service_charge_cents, item_ids, COALESCE(payment_method, ''), paid_at
+		FROM bill_split_shares WHERE split_id = $1 ORDER BY position
+	`, splitID)
+	if err != nil {
+		return split, err
+	}
+	defer rows.Close()
+
+	var paid int64
+	split.Shares = []models.BillShare{}
+	for rows.Next() {
+		var share models.BillShare
+		var shareSubtotal, shareService int64
+		var itemIDs pq.Int64Array
+		if err := rows.Scan(&share.ID, &share.Label, &shareSubtotal, &shareService, &itemIDs,
+			&share.PaymentMethod, &share.PaidAt); err != nil {
+			return split, err
+		}
+		share.Subtotal = fromCents(shareSubtotal)
+		share.ServiceCharge = fromCents(shareService)
+		share.Amount = fromCents(shareSubtotal + shareService)
+		for _, itemID := range itemIDs {
+			share.ItemIDs = append(share.ItemIDs, int(itemID))
+		}
+		if share.PaidAt != nil {
+			paid += shareSubtotal + shareService
+		}
+		split.Shares = append(split.Shares, share)
+	}
+	split.PaidAmount = fromCents(paid)
+	return split, rows.Err()
+}
@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"backend-hamburgueria/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// Teste da repartição de centavos pelo maior resto
+func TestAllocateCents(t *testing.T) {
+	// R$ 100,00 entre 3 pessoas: o centavo que sobra fica com a primeira
+	assert.Equal(t, []int64{3334, 3333, 3333}, allocateCents(10000, make([]int64, 3)))
+	// Proporcional: 2/3 e 1/3
+	assert.Equal(t, []int64{667, 333}, allocateCents(1000, []int64{2, 1}))
+	assert.Equal(t, []int64{}, allocateCents(100, []int64{}))
+	// Desconto repartido com sinal negativo
+	assert.Equal(t, []int64{-51, -50}, allocateCents(-101, []int64{1, 1}))
+}
+
+// Teste da divisão em partes iguais com taxa de serviço
+func TestBuildBillSharesEqual(t *testing.T) {
+	lines := []billLine{{ItemID: 1, Cents: 5000}, {ItemID: 2, Cents: 5000}}
+	req := models.CreateBillSplitRequest{Mode: splitEqual, People: 3}
+
+	shares, err := buildBillShares(req, lines, 10000, 1000)
+	assert.NoError(t, err)
+	assert.Len(t, shares, 3)
+	assert.Equal(t, int64(3334), shares[0].Cents)
+	assert.Equal(t, int64(334), shares[0].Service)
+	assert.Equal(t, int64(333), shares[2].Service)
+	assert.Equal(t, "Pessoa 2", shares[1].Label)
+
+	var total int64
+	for _, share := range shares {
+		total += share.Cents + share.Service
+	}
+	assert.Equal(t, int64(11000), total)
+}
+
+// Teste da divisão por itens, com item compartilhado
+func TestBuildBillSharesItems(t *testing.T) {
+	lines := []billLine{{ItemID: 1, Cents: 3000}, {ItemID: 2, Cents: 1001}}
+	req := models.CreateBillSplitRequest{Mode: splitItems, Shares: []models.BillShareRequest{
+		{Label: "Ana", ItemIDs: []int{1, 2}},
+		{ItemIDs: []int{2}},
+	}}
+
+	shares, err := buildBillShares(req, lines, 4001, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3501), shares[0].Cents)
+	assert.Equal(t, int64(500), shares[1].Cents)
+	assert.Equal(t, "Ana", shares[0].Label)
+
+	// Item fora da conta e item sem dono
+	req.Shares[1].ItemIDs = []int{9}
+	_, err = buildBillShares(req, lines, 4001, 0)
+	assert.Equal(t, errSplitUnknownItem, err)
+
+	req.Shares = req.Shares[:1]
+	req.Shares[0].ItemIDs = []int{1}
+	_, err = buildBillShares(req, lines, 4001, 0)
+	assert.Equal(t, errSplitUncoveredItem, err)
+}
+
+// Teste da divisão por valores informados
+func TestBuildBillSharesCustom(t *testing.T) {
+	lines := []billLine{{ItemID: 1, Cents: 5000}}
+	req := models.CreateBillSplitRequest{Mode: splitCustom, Shares: []models.BillShareRequest{{Amount: 30}, {Amount: 20}}}
+
+	shares, err := buildBillShares(req, lines, 5000, 500)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(300), shares[0].Service)
+	assert.Equal(t, int64(200), shares[1].Service)
+
+	req.Shares[1].Amount = 10
+	_, err = buildBillShares(req, lines, 5000, 500)
+	assert.Equal(t, errSplitCustomTotal, err)
+}
+
+// Teste da validação do pedido de divisão
+func TestValidateBillSplitRequest(t *testing.T) {
+	assert.NoError(t, validateBillSplitRequest(models.CreateBillSplitRequest{Mode: splitEqual, People: 2}))
+	assert.Error(t, validateBillSplitRequest(models.CreateBillSplitRequest{Mode: splitEqual, People: 1}))
+	assert.Error(t, validateBillSplitRequest(models.CreateBillSplitRequest{Mode: "half"}))
+	assert.Error(t, validateBillSplitRequest(models.CreateBillSplitRequest{Mode: splitItems}))
+	assert.Error(t, validateBillSplitRequest(models.CreateBillSplitRequest{
+		Mode:   splitCustom,
+		Shares: []models.BillShareRequest{{Amount: 0}},
+	}))
+}
+
+// Teste para PayBillShare com forma de pagamento inválida e falha ao iniciar a transação
+func TestPayBillShare(t *testing.T) {
+	router, mockDB := setupTest()
+
+	router.POST("/bill-splits/:id/shares/:share_id/pay", func(c *gin.Context) {
+		PayBillShare(c, mockDB)
+	})
+
+	req, _ := http.NewRequest("POST", "/bill-splits/1/shares/2/pay", strings.NewReader(`{"payment_method": "cheque"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	mockDB.BeginFunc = func() (*sql.Tx, error) {
+		return nil, sql.ErrConnDone
+	}
+	req, _ = http.NewRequest("POST", "/bill-splits/1/shares/2/pay", strings.NewReader(`{"payment_method": "pix"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+// Teste da conferência da divisão paga com a conta no fechamento da comanda
+func TestSplitMatchesBill(t *testing.T) {
+	// Mesmos valores da conta, com ou sem a taxa de serviço recusada
+	assert.True(t, splitMatchesBill(10000, 1000, 10000, 1000))
+	assert.True(t, splitMatchesBill(10000, 0, 10000, 1000))
+
+	// Uma rodada nova depois da divisão muda o consumo e a taxa
+	assert.False(t, splitMatchesBill(10000, 1000, 12500, 1250))
+	// Taxa diferente da atual sem ter sido recusada
+	assert.False(t, splitMatchesBill(10000, 800, 10000, 1000))
+}
@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	// Configurações da aplicação (dia de operação)
+	"backend-hamburgueria/config"
+	// Modelos de dados da aplicação
+	"backend-hamburgueria/models"
+
+	// Framework web Gin
+	"github.com/gin-gonic/gin"
+)
+
+// ===== PAINEL DE PEDIDOS (TV DO BALCÃO) =====
+
+// boardRefreshInterval é o intervalo em que o stream reconsulta o painel mesmo sem eventos
+// Cobre a virada do dia de operação e alterações feitas por outras instâncias da API
+const boardRefreshInterval = 15 * time.Second
+
+// GetBoard godoc
+// @Summary      Painel de pedidos
+// @Description  Retorna apenas os números de senha "Preparando" e "Pronto" do dia de operação atual (sem nomes ou valores)
+// @Tags         Board
+// @Produce      json
+// @Success      200  {object}  models.OrderBoard
+// @Failure      500  {object}  models.ErrorResponse "Erro ao montar painel"
+// @Router       /api/board [get]
+func GetBoard(c *gin.Context, db DBInterface) {
+	board, err := loadBoard(db, time.Now())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao montar painel"})
+		return
+	}
+	c.JSON(http.StatusOK, board)
+}
+
+// StreamBoard godoc
+// @Summary      Stream do painel de pedidos
+// @Description  Stream SSE que envia o painel completo (evento "board") ao conectar e a cada mudança
+// @Tags         Board
+// @Produce      text/event-stream
+// @Success      200  {object}  models.OrderBoard
+// @Router       /api/board/stream [get]
+func StreamBoard(c *gin.Context, db DBInterface) {
+	// Assinar antes da primeira consulta para não perder mudanças no meio do caminho
+	events := orderEvents.Subscribe()
+	defer orderEvents.Unsubscribe(events)
+
+	ticker := time.NewTicker(boardRefreshInterval)
+	defer ticker.Stop()
+
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no") // Desliga o buffer do nginx
+
+	var lastSent []byte
+	first := true
+	c.Stream(func(w io.Writer) bool {
+		if !first {
+			select {
+			case <-c.Request.Context().Done():
+				return false
+			case <-events:
+			case <-ticker.C:
+			}
+		}
+		first = false
+
+		board, err := loadBoard(db, time.Now())
+		if err != nil {
+			c.SSEvent("error", gin.H{"error": "Erro ao montar painel"})
+			return true
+		}
+
+		// Só envia quando o painel mudou; caso contrário mantém a conexão viva
+		snapshot := board
+		snapshot.UpdatedAt = time.Time{}
+		payload, _ := json.Marshal(snapshot)
+		if bytes.Equal(payload, lastSent) {
+			_, err := io.WriteString(w, ": keepalive\n\n")
+			return err == nil
+		}
+		lastSent = payload
+		c.SSEvent("board", board)
+		return true
+	})
+}
+
+// ===== FUNÇÕES AUXILIARES =====
+
+// loadBoard monta o painel do dia de operação atual
+// Mostra a senha do dia de cada pedido (gravada na criação); pedidos entregues saem do painel
+func loadBoard(q queryer, now time.Time) (models.OrderBoard, error) {
+	dayStart := config.ServiceDayStart(now)
+	board := models.OrderBoard{
+		ServiceDay: dayStart.Format("2006-01-02"),
+		Preparing:  []models.BoardTicket{},
+		Ready:      []models.BoardTicket{},
+		UpdatedAt:  now,
+	}
+
+	rows, err := q.Query(`
+		SELECT ticket_number, status
+		FROM orders
+		WHERE store_id = $1 AND service_day = $2 AND status IN ('pending', 'preparing', 'ready')
+		ORDER BY ticket_number
+	`, config.StoreID(), board.ServiceDay)
+	if err != nil {
+		return board, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var ticket models.BoardTicket
+		if err := rows.Scan(&ticket.TicketNumber, &ticket.Status); err != nil {
+			return board, err
+		}
+		// Para o cliente, pendente e em preparo são a mesma coisa: "Preparando"
+		if ticket.Status == "ready" {
+			ticket.Label = "Pronto"
+			board.Ready = append(board.Ready, ticket)
+		} else {
+			ticket.Status = "preparing"
+			ticket.Label = "Preparando"
+			board.Preparing = append(board.Preparing, ticket)
+		}
+	}
+	return board, rows.Err()
+}
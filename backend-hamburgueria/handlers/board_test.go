@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// Teste para GetBoard
+func TestGetBoard(t *testing.T) {
+	router, mockDB := setupTest()
+
+	router.GET("/board", func(c *gin.Context) {
+		GetBoard(c, mockDB)
+	})
+
+	// Mock da resposta do banco - retornar erro para simular falha
+	mockDB.QueryFunc = func(query string, args ...interface{}) (*sql.Rows, error) {
+		return nil, sql.ErrConnDone
+	}
+
+	req, _ := http.NewRequest("GET", "/board", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+// Teste do broker de eventos: todos os assinantes recebem e o cancelamento fecha o canal
+func TestEventBroker(t *testing.T) {
+	broker := newEventBroker()
+	first := broker.Subscribe()
+	second := broker.Subscribe()
+
+	broker.Publish(orderEvent{Type: eventOrderStatus, OrderID: 7, Status: "ready"})
+	assert.Equal(t, 7, (<-first).OrderID)
+	assert.Equal(t, "ready", (<-second).Status)
+
+	broker.Unsubscribe(first)
+	_, open := <-first
+	assert.False(t, open)
+
+	// Assinante lento não trava a publicação
+	for i := 0; i < 100; i++ {
+		broker.Publish(orderEvent{Type: eventOrderCreated, OrderID: i})
+	}
+	assert.Len(t, second, cap(second))
+}
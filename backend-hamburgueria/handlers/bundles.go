@@ -0,0 +1,437 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	// Modelos de dados da aplicação
+	"backend-hamburgueria/models"
+
+	// Framework web Gin
+	"github.com/gin-gonic/gin"
+	// Driver do PostgreSQL (arrays)
+	"github.com/lib/pq"
+)
+
+// ===== COMBOS =====
+
+// Erros das escolhas de um combo no pedido
+var (
+	errNotBundle               = errors.New("Produto não é um combo")
+	errBundleChoice            = errors.New("Escolha inválida para o combo")
+	errBundleIncomplete        = errors.New("Escolha todas as etapas do combo")
+	errBundleOptionUnavailable = errors.New("Opção do combo indisponível")
+	errBundleOptionVariant     = errors.New("Opção do combo tem variações e não pode ser vendida no combo")
+)
+
+// bundleProduct são os dados de um produto aceito numa etapa, usados no preço e na cozinha
+type bundleProduct struct {
+	Name       string
+	Price      float64
+	Station    string
+	CategoryID int
+	Available  bool
+	// Produto com variações cadastradas (não aceito nas etapas: o combo não escolhe variação)
+	HasVariants bool
+}
+
+// bundlePart é um produto do combo pedido, com a estação da cozinha
+type bundlePart struct {
+	models.BundleComponent
+	Station    string
+	CategoryID int      // Categoria do produto (horários de venda)
+	Notes      string   // Observação do item original (combos montados automaticamente)
+	Allergens  []string // Alérgenos do produto escolhido (alerta de alergia na cozinha)
+	Index      int      // Posição do item avulso de origem no pedido enviado (combos automáticos)
+}
+
+// GetBundle godoc
+// @Summary      Etapas de um combo
+// @Description  Retorna o produto do combo com as etapas e os produtos aceitos em cada uma (com acréscimo)
+// @Tags         Products
+// @Produce      json
+// @Param        id   path      int  true  "ID do produto"
+// @Success      200  {object}  models.Bundle
+// @Failure      404  {object}  models.ErrorResponse "Combo não encontrado"
+// @Router       /api/products/{id}/bundle [get]
+func GetBundle(c *gin.Context, db DBInterface) {
+	productID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do produto inválido"})
+		return
+	}
+	respondBundle(c, db, productID)
+}
+
+// SetBundle godoc
+// @Summary      Define as etapas de um combo
+// @Description  Substitui as etapas do produto e o marca como combo; uma lista vazia volta a ser produto simples
+// @Tags         Products
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                      true  "ID do produto"
+// @Param        body  body      models.SetBundleRequest  true  "Etapas do combo"
+// @Success      200   {object}  models.Bundle
+// @Failure      400   {object}  models.ErrorResponse "Dados inválidos"
+// @Failure      404   {object}  models.ErrorResponse "Produto não encontrado"
+// @Router       /api/products/{id}/bundle [put]
+func SetBundle(c *gin.Context, db DBInterface) {
+	// ===== VALIDAR DADOS DE ENTRADA =====
+	productID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do produto inválido"})
+		return
+	}
+	var req models.SetBundleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos"})
+		return
+	}
+	if err := validateBundleRequest(productID, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// ===== INICIAR TRANSAÇÃO =====
+	tx, err := db.Begin()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao iniciar transação"})
+		return
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(`UPDATE products SET is_bundle = $2 WHERE id = $1`, productID, len(req.Slots) > 0)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao salvar combo"})
+		return
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Produto não encontrado"})
+		return
+	}
+
+	// Produtos das etapas: precisam existir e não podem ser combos, o lanche personalizado
+	// nem produtos com variações (o combo não tem escolha de variação)
+	var optionIDs []int
+	for _, slot := range req.Slots {
+		for _, option := range slot.Options {
+			optionIDs = append(optionIDs, option.ProductID)
+		}
+	}
+	if len(optionIDs) > 0 {
+		var valid int
+		err := tx.QueryRow(`
+			SELECT COUNT(DISTINCT id) FROM products p
+			WHERE id = ANY($1) AND NOT is_bundle AND id <> $2
+				AND NOT EXISTS (SELECT 1 FROM product_variants v WHERE v.product_id = p.id)
+		`, pq.Array(optionIDs), customBurgerProductID).Scan(&valid)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao salvar combo"})
+			return
+		}
+		if valid != countDistinct(optionIDs) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "As etapas só aceitam produtos simples cadastrados, sem variações"})
+			return
+		}
+	}
+
+	// ===== SUBSTITUIR ETAPAS =====
+	if _, err := tx.Exec(`DELETE FROM bundle_slots WHERE bundle_id = $1`, productID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao salvar combo"})
+		return
+	}
+	for i, slot := range req.Slots {
+		var slotID int
+		err := tx.QueryRow(`
+			INSERT INTO bundle_slots (bundle_id, name, position, is_required) VALUES ($1, $2, $3, $4) RETURNING id
+		`, productID, slot.Name, i+1, *slot.IsRequired).Scan(&slotID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao salvar combo"})
+			return
+		}
+		for _, option := range slot.Options {
+			if _, err := tx.Exec(`
+				INSERT INTO bundle_slot_options (slot_id, product_id, upcharge, is_default) VALUES ($1, $2, $3, $4)
+			`, slotID, option.ProductID, option.Upcharge, option.IsDefault); err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao salvar combo"})
+				return
+			}
+		}
+	}
+
+	// ===== COMMIT DA TRANSAÇÃO =====
+	if err := tx.Commit(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao salvar combo"})
+		return
+	}
+	respondBundle(c, db, productID)
+}
+
+// ===== FUNÇÕES AUXILIARES =====
+
+// validateBundleRequest confere nomes, opções repetidas, acréscimos e escolhas padrão das etapas
+func validateBundleRequest(productID int, req *models.SetBundleRequest) error {
+	if productID == customBurgerProductID {
+		return errors.New("O lanche personalizado não pode ser um combo")
+	}
+	for i := range req.Slots {
+		slot := &req.Slots[i]
+		slot.Name = strings.TrimSpace(slot.Name)
+		if slot.Name == "" {
+			return errors.New("Nome da etapa é obrigatório")
+		}
+		if len(slot.Options) == 0 {
+			return errors.New("Cada etapa precisa de pelo menos um produto")
+		}
+		if slot.IsRequired == nil {
+			required := true
+			slot.IsRequired = &required
+		}
+		seen := make(map[int]bool)
+		defaults := 0
+		for _, option := range slot.Options {
+			if option.ProductID <= 0 || option.ProductID == productID || seen[option.ProductID] {
+				return errors.New("Produtos da etapa inválidos ou repetidos")
+			}
+			if option.Upcharge < 0 {
+				return errors.New("Acréscimo não pode ser negativo")
+			}
+			seen[option.ProductID] = true
+			if option.IsDefault {
+				defaults++
+			}
+		}
+		if defaults > 1 {
+			return errors.New("Cada etapa aceita no máximo uma escolha padrão")
+		}
+	}
+	return nil
+}
+
+// countDistinct conta os valores diferentes da lista
+func countDistinct(values []int) int {
+	seen := make(map[int]bool)
+	for _, value := range values {
+		seen[value] = true
+	}
+	return len(seen)
+}
+
+// respondBundle responde com o combo e suas etapas
+func respondBundle(c *gin.Context, db DBInterface, productID int) {
+	var bundle models.Bundle
+	product := &bundle.Product
+	err := db.QueryRow(`
+		SELECT id, name, description, price, category_id, image_url, is_available, station, prep_minutes, is_bundle, created_at
+		FROM products WHERE id = $1
+	`, productID).Scan(&product.ID, &product.Name, &product.Description, &product.Price, &product.CategoryID, &product.ImageURL,
+		&product.IsAvailable, &product.Station, &product.PrepMinutes, &product.IsBundle, &product.CreatedAt)
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Produto não encontrado"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar combo"})
+		return
+	}
+
+	bundle.Slots, _, err = loadBundleSlots(db, productID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar etapas do combo"})
+		return
+	}
+	c.JSON(http.StatusOK, bundle)
+}
+
+// loadBundleSlots busca as etapas do combo e os dados dos produtos aceitos
+func loadBundleSlots(q queryer, bundleID int) ([]models.BundleSlot, map[int]bundleProduct, error) {
+	rows, err := q.Query(`
+		SELECT s.id, s.name, s.position, s.is_required,
+			o.product_id, p.name, o.upcharge, o.is_default, p.price, p.station, COALESCE(p.category_id, 0), p.is_available,
+			EXISTS (SELECT 1 FROM product_variants v WHERE v.product_id = p.id)
+		FROM bundle_slots s
+		JOIN bundle_slot_options o ON o.slot_id = s.id
+		JOIN products p ON p.id = o.product_id
+		WHERE s.bundle_id = $1
+		ORDER BY s.position, o.upcharge, p.name
+	`, bundleID)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	slots := []models.BundleSlot{}
+	products := make(map[int]bundleProduct)
+	for rows.Next() {
+		var slot models.BundleSlot
+		var option models.BundleOption
+		var product bundleProduct
+		if err := rows.Scan(&slot.ID, &slot.Name, &slot.Position, &slot.IsRequired,
+			&option.ProductID, &option.ProductName, &option.Upcharge, &option.IsDefault,
+			&product.Price, &product.Station, &product.CategoryID, &product.Available, &product.HasVariants); err != nil {
+			return nil, nil, err
+		}
+		product.Name = option.ProductName
+		products[option.ProductID] = product
+		if len(slots) == 0 || slots[len(slots)-1].ID != slot.ID {
+			slots = append(slots, slot)
+		}
+		last := &slots[len(slots)-1]
+		last.Options = append(last.Options, option)
+	}
+	return slots, products, rows.Err()
+}
+
+// resolveBundleChoices aplica as escolhas do cliente às etapas do combo
+// Etapas sem escolha usam a opção padrão; etapas opcionais sem padrão ficam de fora
+func resolveBundleChoices(slots []models.BundleSlot, choices []models.BundleChoice) ([]models.BundleComponent, error) {
+	chosen := make(map[int]int)
+	for _, choice := range choices {
+		if _, repeated := chosen[choice.SlotID]; repeated {
+			return nil, errBundleChoice
+		}
+		chosen[choice.SlotID] = choice.ProductID
+	}
+
+	var components []models.BundleComponent
+	for _, slot := range slots {
+		productID, ok := chosen[slot.ID]
+		delete(chosen, slot.ID)
+		var selected *models.BundleOption
+		for i := range slot.Options {
+			option := &slot.Options[i]
+			if (ok && option.ProductID == productID) || (!ok && option.IsDefault) {
+				selected = option
+				break
+			}
+		}
+		if selected == nil {
+			if ok {
+				return nil, errBundleChoice
+			}
+			if slot.IsRequired {
+				return nil, errBundleIncomplete
+			}
+			continue
+		}
+		components = append(components, models.BundleComponent{
+			SlotID:      slot.ID,
+			SlotName:    slot.Name,
+			ProductID:   selected.ProductID,
+			ProductName: selected.ProductName,
+			Upcharge:    selected.Upcharge,
+		})
+	}
+	// Escolha para uma etapa que não é deste combo
+	if len(chosen) > 0 {
+		return nil, errBundleChoice
+	}
+	if len(components) == 0 {
+		return nil, errBundleIncomplete
+	}
+	return components, nil
+}
+
+// priceBundle calcula o preço unitário do combo (preço base + acréscimos) e reparte o valor
+// entre os produtos escolhidos, proporcional ao preço de cada um, para os itens gravados somarem o combo
+func priceBundle(q queryer, line *pricedLine, basePrice float64) error {
+	slots, products, err := loadBundleSlots(q, line.Item.ProductID)
+	if err != nil {
+		return err
+	}
+	if len(slots) == 0 {
+		return errNotBundle
+	}
+	components, err := resolveBundleChoices(slots, line.Item.Choices)
+	if err != nil {
+		return err
+	}
+
+	unit, parts, prices, err := bundleParts(components, products, toCents(basePrice))
+	if err != nil {
+		return err
+	}
+	line.Components = splitBundlePrice(unit, parts, prices)
+	line.UnitPrice = fromCents(unit)
+	return nil
+}
+
+// bundleParts confere os produtos escolhidos e soma os acréscimos ao preço base (em centavos)
+// Retorna o preço unitário do combo, os produtos e o preço avulso de cada um
+func bundleParts(components []models.BundleComponent, products map[int]bundleProduct, unit int64) (int64, []bundlePart, []int64, error) {
+	parts := make([]bundlePart, len(components))
+	prices := make([]int64, len(components))
+	for i, component := range components {
+		product := products[component.ProductID]
+		if !product.Available {
+			return 0, nil, nil, errBundleOptionUnavailable
+		}
+		// Etapas cadastradas antes das variações do produto: o combo não sabe qual variação vender
+		if product.HasVariants {
+			return 0, nil, nil, errBundleOptionVariant
+		}
+		unit += toCents(component.Upcharge)
+		parts[i] = bundlePart{BundleComponent: component, Station: product.Station, CategoryID: product.CategoryID}
+		prices[i] = toCents(product.Price)
+	}
+	return unit, parts, prices, nil
+}
+
+// splitBundlePrice reparte o preço unitário do combo entre os produtos, proporcional ao
+// preço avulso de cada um mais o acréscimo, para os itens gravados somarem o valor do combo
+func splitBundlePrice(unit int64, parts []bundlePart, prices []int64) []bundlePart {
+	weights := make([]int64, len(parts))
+	for i, part := range parts {
+		weights[i] = prices[i] + toCents(part.Upcharge)
+	}
+	for i, share := range allocateCents(unit, weights) {
+		parts[i].UnitPrice = fromCents(share)
+	}
+	return parts
+}
+
+// insertOrderLine grava um item do pedido e retorna o ID da linha gravada
+// Combos viram um item por produto escolhido (com a parte do preço do combo), ligados pelo
+// bundle_seq, para a cozinha preparar cada produto na sua estação; o ID retornado é o do primeiro
+func insertOrderLine(tx *sql.Tx, orderID, seq int, line pricedLine) (int, error) {
+	item := line.Item
+	var itemID int
+	if len(line.Components) == 0 {
+		variantID, variantName, variantSKU := variantSnapshot(line)
+		err := tx.QueryRow(`
+			INSERT INTO order_items (order_id, product_id, ingredients, quantity, unit_price, total_price, notes,
+				variant_id, variant_name, variant_sku, allergies, allergen_conflicts)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+			RETURNING id
+		`, orderID, item.ProductID, item.Ingredients, item.Quantity, line.UnitPrice, line.TotalPrice, item.Notes,
+			variantID, variantName, variantSKU, pq.Array(line.Allergies), pq.Array(line.AllergenConflicts)).Scan(&itemID)
+		return itemID, err
+	}
+
+	for i, part := range line.Components {
+		notes := item.Notes
+		if part.Notes != "" {
+			notes = part.Notes
+		}
+		// Cada produto do combo tem o alerta pelos próprios alérgenos
+		conflicts := intersectTags(part.Allergens, line.Allergies)
+		var id int
+		err := tx.QueryRow(`
+			INSERT INTO order_items (order_id, product_id, ingredients, quantity, unit_price, total_price, notes,
+				bundle_id, bundle_seq, bundle_slot, allergies, allergen_conflicts)
+			VALUES ($1, $2, '', $3, $4, $5, $6, $7, $8, $9, $10, $11)
+			RETURNING id
+		`, orderID, part.ProductID, item.Quantity, part.UnitPrice, fromCents(toCents(part.UnitPrice)*int64(item.Quantity)),
+			notes, item.ProductID, seq, part.SlotName, pq.Array(line.Allergies), pq.Array(conflicts)).Scan(&id)
+		if err != nil {
+			return 0, err
+		}
+		if i == 0 {
+			itemID = id
+		}
+	}
+	return itemID, nil
+}
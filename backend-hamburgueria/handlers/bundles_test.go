@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"backend-hamburgueria/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// comboSlots: lanche (obrigatório), acompanhamento (padrão batata) e sobremesa opcional
+func comboSlots() []models.BundleSlot {
+	return []models.BundleSlot{
+		{ID: 1, Name: "Lanche", IsRequired: true, Options: []models.BundleOption{
+			{ProductID: 2, ProductName: "Classic"}, {ProductID: 3, ProductName: "Bacon", Upcharge: 4},
+		}},
+		{ID: 2, Name: "Acompanhamento", IsRequired: true, Options: []models.BundleOption{
+			{ProductID: 5, ProductName: "Batata", IsDefault: true}, {ProductID: 6, ProductName: "Onion rings", Upcharge: 3},
+		}},
+		{ID: 3, Name: "Sobremesa", Options: []models.BundleOption{{ProductID: 9, ProductName: "Brownie", Upcharge: 6}}},
+	}
+}
+
+// Teste das escolhas do combo: padrão, opcional e escolhas inválidas
+func TestResolveBundleChoices(t *testing.T) {
+	components, err := resolveBundleChoices(comboSlots(), []models.BundleChoice{{SlotID: 1, ProductID: 3}})
+	assert.NoError(t, err)
+	assert.Len(t, components, 2)
+	assert.Equal(t, 3, components[0].ProductID)
+	assert.Equal(t, 4.0, components[0].Upcharge)
+	assert.Equal(t, 5, components[1].ProductID)
+	assert.Equal(t, "Acompanhamento", components[1].SlotName)
+
+	// Lanche sem escolha nem padrão
+	_, err = resolveBundleChoices(comboSlots(), nil)
+	assert.Equal(t, errBundleIncomplete, err)
+	// Produto fora da etapa, etapa repetida e etapa de outro combo
+	_, err = resolveBundleChoices(comboSlots(), []models.BundleChoice{{SlotID: 1, ProductID: 5}})
+	assert.Equal(t, errBundleChoice, err)
+	_, err = resolveBundleChoices(comboSlots(), []models.BundleChoice{{SlotID: 1, ProductID: 2}, {SlotID: 1, ProductID: 3}})
+	assert.Equal(t, errBundleChoice, err)
+	_, err = resolveBundleChoices(comboSlots(), []models.BundleChoice{{SlotID: 1, ProductID: 2}, {SlotID: 8, ProductID: 2}})
+	assert.Equal(t, errBundleChoice, err)
+}
+
+// Teste dos produtos escolhidos no combo: acréscimos, indisponíveis e produtos com variações
+func TestBundleParts(t *testing.T) {
+	components, err := resolveBundleChoices(comboSlots(), []models.BundleChoice{{SlotID: 1, ProductID: 3}})
+	assert.NoError(t, err)
+	products := map[int]bundleProduct{
+		3: {Name: "Bacon", Price: 28, Station: "grill", Available: true},
+		5: {Name: "Batata", Price: 12, Station: "fryer", Available: true},
+	}
+
+	unit, parts, prices, err := bundleParts(components, products, 3500)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3900), unit)
+	assert.Equal(t, "fryer", parts[1].Station)
+	assert.Equal(t, []int64{2800, 1200}, prices)
+
+	// Batata passou a ter variações (P/M/G) depois do combo cadastrado: o combo não escolhe a variação
+	products[5] = bundleProduct{Name: "Batata", Price: 12, Station: "fryer", Available: true, HasVariants: true}
+	_, _, _, err = bundleParts(components, products, 3500)
+	assert.Equal(t, errBundleOptionVariant, err)
+
+	products[5] = bundleProduct{Name: "Batata", Price: 12, Station: "fryer"}
+	_, _, _, err = bundleParts(components, products, 3500)
+	assert.Equal(t, errBundleOptionUnavailable, err)
+}
+
+// Teste da validação do cadastro de combos
+func TestValidateBundleRequest(t *testing.T) {
+	req := models.SetBundleRequest{Slots: []models.BundleSlotRequest{
+		{Name: " Bebida ", Options: []models.BundleOption{{ProductID: 7}, {ProductID: 8, Upcharge: 2, IsDefault: true}}},
+	}}
+	assert.NoError(t, validateBundleRequest(10, &req))
+	assert.Equal(t, "Bebida", req.Slots[0].Name)
+	assert.True(t, *req.Slots[0].IsRequired)
+
+	invalid := []models.SetBundleRequest{
+		{Slots: []models.BundleSlotRequest{{Name: "", Options: []models.BundleOption{{ProductID: 7}}}}},
+		{Slots: []models.BundleSlotRequest{{Name: "Bebida"}}},
+		{Slots: []models.BundleSlotRequest{{Name: "Bebida", Options: []models.BundleOption{{ProductID: 7}, {ProductID: 7}}}}},
+		{Slots: []models.BundleSlotRequest{{Name: "Bebida", Options: []models.BundleOption{{ProductID: 10}}}}},
+		{Slots: []models.BundleSlotRequest{{Name: "Bebida", Options: []models.BundleOption{{ProductID: 7, Upcharge: -1}}}}},
+		{Slots: []models.BundleSlotRequest{{Name: "Bebida", Options: []models.BundleOption{
+			{ProductID: 7, IsDefault: true}, {ProductID: 8, IsDefault: true},
+		}}}},
+	}
+	for _, req := range invalid {
+		assert.Error(t, validateBundleRequest(10, &req))
+	}
+	assert.Error(t, validateBundleRequest(customBurgerProductID, &models.SetBundleRequest{}))
+}
+
+// Teste para SetBundle com dados inválidos (recusado antes do banco)
+func TestSetBundleInvalid(t *testing.T) {
+	router, mockDB := setupTest()
+
+	router.PUT("/products/:id/bundle", func(c *gin.Context) {
+		SetBundle(c, mockDB)
+	})
+
+	body := `{"slots": [{"name": "Bebida", "options": []}]}`
+	req, _ := http.NewRequest("PUT", "/products/10/bundle", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
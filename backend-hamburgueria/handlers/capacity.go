@@ -0,0 +1,246 @@
+package handlers
+
+import (
+	"math"
+	"net/http"
+	"sort"
+	"time"
+
+	// Configurações da aplicação (modelo de capacidade)
+	"backend-hamburgueria/config"
+	// Modelos de dados da aplicação
+	"backend-hamburgueria/models"
+
+	// Framework web Gin
+	"github.com/gin-gonic/gin"
+)
+
+// ===== CAPACIDADE DA COZINHA =====
+
+// defaultStation é a estação usada quando o produto não define uma
+const defaultStation = "grill"
+
+// kitchenCapacityLockKey identifica o advisory lock que serializa as verificações de capacidade
+// Sem ele, dois pedidos simultâneos poderiam ver a mesma fila e ambos passarem do limite
+const kitchenCapacityLockKey = 27001
+
+// kitchenLoad é a fotografia da fila da cozinha usada no cálculo de capacidade
+type kitchenLoad struct {
+	StationItems map[string]int // Itens em aberto por estação
+	QueueEntries []time.Time    // Entrada na fila dos pedidos da janela atual (inclui agendados)
+}
+
+// kitchenEstimate é o resultado do cálculo de capacidade para um pedido novo
+type kitchenEstimate struct {
+	Delay         time.Duration            // Atraso extra causado pela fila (0 = há capacidade)
+	StationDelays map[string]time.Duration // Atraso por estação
+	WindowDelay   time.Duration            // Atraso causado pelo limite de pedidos por janela
+	RecentOrders  int                      // Pedidos que entraram na janela atual
+}
+
+// GetKitchenWait godoc
+// @Summary      Espera estimada da cozinha
+// @Description  Retorna a espera estimada para um pedido novo e a ocupação das estações (público, para a tela do cardápio)
+// @Tags         Kitchen
+// @Produce      json
+// @Success      200  {object}  models.KitchenWaitResponse
+// @Failure      500  {object}  models.ErrorResponse "Erro ao calcular espera"
+// @Router       /api/kitchen/wait [get]
+func GetKitchenWait(c *gin.Context, db DBInterface) {
+	capacity := config.LoadKitchenCapacity()
+	now := time.Now()
+
+	load, err := loadKitchenLoad(db, capacity, now)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao calcular espera"})
+		return
+	}
+
+	// Um item novo em cada estação limitada: a espera é a da estação mais ocupada
+	probe := make(map[string]int)
+	for station := range capacity.StationLimits {
+		probe[station] = 1
+	}
+	estimate := estimateKitchenDelay(load, probe, capacity, now)
+
+	// ===== MONTAR RESPOSTA =====
+	stations := []models.StationLoad{}
+	for station, limit := range capacity.StationLimits {
+		stations = append(stations, models.StationLoad{
+			Station:   station,
+			OpenItems: load.StationItems[station],
+			Capacity:  limit,
+		})
+	}
+	sort.Slice(stations, func(i, j int) bool { return stations[i].Station < stations[j].Station })
+
+	c.JSON(http.StatusOK, models.KitchenWaitResponse{
+		AcceptingOrders:      estimate.Delay == 0 || capacity.OverloadMode == config.OverloadSchedule,
+		OverloadMode:         capacity.OverloadMode,
+		EstimatedWaitMinutes: ceilMinutes(capacity.AvgPrepTime + estimate.Delay),
+		QueueDelayMinutes:    ceilMinutes(estimate.Delay),
+		RecentOrders:         estimate.RecentOrders,
+		MaxOrdersPerWindow:   capacity.MaxOrdersPerWindow,
+		WindowMinutes:        int(capacity.Window / time.Minute),
+		Stations:             stations,
+	})
+}
+
+// ReleaseScheduledOrders libera para a fila da cozinha os pedidos agendados cujo horário chegou
+// Chamado periodicamente pelo main; retorna quantos pedidos foram liberados
+func ReleaseScheduledOrders(db DBInterface) (int64, error) {
+	// A liberação e o registro no histórico acontecem no mesmo comando
+	result, err := db.Exec(`
+		WITH released AS (
+			UPDATE orders SET status = 'pending', updated_at = CURRENT_TIMESTAMP
+			WHERE status = 'scheduled' AND release_at <= CURRENT_TIMESTAMP
+			RETURNING id
+		)
+		INSERT INTO order_history (order_id, event, status)
+		SELECT id, $1, 'pending' FROM released
+	`, orderEventStatus)
+	if err != nil {
+		return 0, err
+	}
+	released, err := result.RowsAffected()
+	if err == nil && released > 0 {
+		orderEvents.Publish(orderEvent{Type: eventOrdersRelease, Status: "pending"})
+	}
+	return released, err
+}
+
+// ===== FUNÇÕES AUXILIARES =====
+
+// loadKitchenLoad busca a ocupação atual das estações e as entradas recentes na fila
+func loadKitchenLoad(q queryer, capacity config.KitchenCapacity, now time.Time) (kitchenLoad, error) {
+	load := kitchenLoad{StationItems: make(map[string]int)}
+
+	// Itens ainda não finalizados por estação (agendados por falta de capacidade também ocupam a cozinha;
+	// os agendados pelo cliente para mais tarde só contam depois de liberados)
+	rows, err := q.Query(`
+		SELECT COALESCE(p.station, $1), SUM(oi.quantity)
+		FROM order_items oi
+		JOIN orders o ON o.id = oi.order_id
+		LEFT JOIN products p ON p.id = oi.product_id
+		WHERE (o.status IN ('pending', 'preparing') OR (o.status = 'scheduled' AND o.scheduled_for IS NULL))
+			AND oi.prepared_at IS NULL
+		GROUP BY 1
+	`, defaultStation)
+	if err != nil {
+		return load, err
+	}
+	for rows.Next() {
+		var station string
+		var items int
+		if err := rows.Scan(&station, &items); err != nil {
+			rows.Close()
+			return load, err
+		}
+		load.StationItems[station] = items
+	}
+	rows.Close()
+
+	if capacity.MaxOrdersPerWindow <= 0 {
+		return load, nil
+	}
+
+	// Entradas na fila a partir do início da janela atual (inclusive as agendadas para depois)
+	rows, err = q.Query(`
+		SELECT COALESCE(release_at, created_at)::timestamptz
+		FROM orders
+		WHERE COALESCE(release_at, created_at) > $1
+		ORDER BY 1
+	`, now.Add(-capacity.Window))
+	if err != nil {
+		return load, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var entered time.Time
+		if err := rows.Scan(&entered); err != nil {
+			return load, err
+		}
+		load.QueueEntries = append(load.QueueEntries, entered)
+	}
+	return load, nil
+}
+
+// stationRounds retorna quantas rodadas a estação precisa para produzir os itens
+func stationRounds(items, limit int) int {
+	return (items + limit - 1) / limit
+}
+
+// estimateKitchenDelay calcula o atraso de um pedido novo com os itens informados por estação
+//
+// Estações: a cozinha produz até "limite" itens por rodada (AvgPrepTime). O pedido espera
+// apenas as rodadas que a fila em aberto acrescenta; um pedido grande com a estação vazia
+// não é atrasado pelo próprio tamanho.
+// Janela: no máximo MaxOrdersPerWindow pedidos podem entrar na fila a cada Window.
+func estimateKitchenDelay(load kitchenLoad, newItems map[string]int, capacity config.KitchenCapacity, now time.Time) kitchenEstimate {
+	estimate := kitchenEstimate{StationDelays: make(map[string]time.Duration)}
+
+	for station, items := range newItems {
+		limit := capacity.StationLimits[station]
+		if limit <= 0 || items <= 0 {
+			continue
+		}
+		total := load.StationItems[station] + items
+		extra := stationRounds(total, limit) - stationRounds(items, limit)
+		if extra > 0 {
+			delay := time.Duration(extra) * capacity.AvgPrepTime
+			estimate.StationDelays[station] = delay
+			if delay > estimate.Delay {
+				estimate.Delay = delay
+			}
+		}
+	}
+
+	if capacity.MaxOrdersPerWindow > 0 {
+		estimate.RecentOrders = countEntries(load.QueueEntries, now.Add(-capacity.Window), now)
+		slot := nextWindowSlot(load.QueueEntries, now, capacity.Window, capacity.MaxOrdersPerWindow)
+		estimate.WindowDelay = slot.Sub(now)
+		if estimate.WindowDelay > estimate.Delay {
+			estimate.Delay = estimate.WindowDelay
+		}
+	}
+
+	return estimate
+}
+
+// nextWindowSlot encontra o primeiro instante (a partir de now) em que cabe mais um pedido na janela
+// Os candidatos são "agora" e os momentos em que cada entrada sai da janela
+func nextWindowSlot(entries []time.Time, now time.Time, window time.Duration, max int) time.Time {
+	candidates := []time.Time{now}
+	for _, entered := range entries {
+		if leaves := entered.Add(window); leaves.After(now) {
+			candidates = append(candidates, leaves)
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].Before(candidates[j]) })
+
+	for _, t := range candidates {
+		if countEntries(entries, t.Add(-window), t) < max {
+			return t
+		}
+	}
+	return candidates[len(candidates)-1]
+}
+
+// countEntries conta as entradas no intervalo (from, to]
+func countEntries(entries []time.Time, from, to time.Time) int {
+	count := 0
+	for _, entered := range entries {
+		if entered.After(from) && !entered.After(to) {
+			count++
+		}
+	}
+	return count
+}
+
+// ceilMinutes arredonda uma duração para cima em minutos inteiros
+func ceilMinutes(d time.Duration) int {
+	if d <= 0 {
+		return 0
+	}
+	return int(math.Ceil(d.Minutes()))
+}
@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"backend-hamburgueria/config"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// Teste para GetKitchenWait
+func TestGetKitchenWait(t *testing.T) {
+	router, mockDB := setupTest()
+
+	router.GET("/kitchen/wait", func(c *gin.Context) {
+		GetKitchenWait(c, mockDB)
+	})
+
+	// Mock da resposta do banco - retornar erro para simular falha
+	mockDB.QueryFunc = func(query string, args ...interface{}) (*sql.Rows, error) {
+		return nil, sql.ErrConnDone
+	}
+
+	req, _ := http.NewRequest("GET", "/kitchen/wait", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+// Teste do limite por estação: itens além da capacidade esperam rodadas extras
+func TestEstimateKitchenDelayStations(t *testing.T) {
+	capacity := config.KitchenCapacity{
+		StationLimits: map[string]int{"grill": 10, "bar": 20},
+		AvgPrepTime:   10 * time.Minute,
+	}
+	load := kitchenLoad{StationItems: map[string]int{"grill": 18, "bar": 3}}
+	now := time.Now()
+
+	// 18 + 2 = 20 itens cabem em duas rodadas: espera uma rodada
+	estimate := estimateKitchenDelay(load, map[string]int{"grill": 2, "bar": 1}, capacity, now)
+	assert.Equal(t, 10*time.Minute, estimate.Delay)
+	assert.Equal(t, 10*time.Minute, estimate.StationDelays["grill"])
+	assert.NotContains(t, estimate.StationDelays, "bar")
+
+	// Só bebidas: a chapa cheia não atrasa o pedido
+	estimate = estimateKitchenDelay(load, map[string]int{"bar": 2}, capacity, now)
+	assert.Equal(t, time.Duration(0), estimate.Delay)
+}
+
+// Teste de pedido maior que a estação com a cozinha vazia: não há fila para esperar
+func TestEstimateKitchenDelayLargeOrderEmptyKitchen(t *testing.T) {
+	capacity := config.KitchenCapacity{
+		StationLimits: map[string]int{"grill": 10},
+		AvgPrepTime:   10 * time.Minute,
+		OverloadMode:  config.OverloadReject,
+	}
+	now := time.Now()
+
+	// 25 itens com a chapa vazia: o pedido entra sem espera
+	estimate := estimateKitchenDelay(kitchenLoad{}, map[string]int{"grill": 25}, capacity, now)
+	assert.Equal(t, time.Duration(0), estimate.Delay)
+	assert.NotContains(t, estimate.StationDelays, "grill")
+
+	// Com 5 itens na fila: 30 itens precisam de 3 rodadas contra 3 sozinhos, sem espera extra
+	estimate = estimateKitchenDelay(kitchenLoad{StationItems: map[string]int{"grill": 5}}, map[string]int{"grill": 25}, capacity, now)
+	assert.Equal(t, time.Duration(0), estimate.Delay)
+
+	// Com 6 itens na fila: 31 itens precisam de 4 rodadas, uma a mais que o pedido sozinho
+	estimate = estimateKitchenDelay(kitchenLoad{StationItems: map[string]int{"grill": 6}}, map[string]int{"grill": 25}, capacity, now)
+	assert.Equal(t, 10*time.Minute, estimate.Delay)
+}
+
+// Teste do limite de pedidos por janela
+func TestEstimateKitchenDelayWindow(t *testing.T) {
+	now := time.Date(2026, 10, 19, 20, 0, 0, 0, time.UTC)
+	capacity := config.KitchenCapacity{
+		MaxOrdersPerWindow: 3,
+		Window:             10 * time.Minute,
+		AvgPrepTime:        10 * time.Minute,
+	}
+	load := kitchenLoad{QueueEntries: []time.Time{
+		now.Add(-8 * time.Minute),
+		now.Add(-5 * time.Minute),
+		now.Add(-1 * time.Minute),
+	}}
+
+	// A janela está cheia: o próximo pedido cabe quando o mais antigo sair (em 2 minutos)
+	estimate := estimateKitchenDelay(load, nil, capacity, now)
+	assert.Equal(t, 3, estimate.RecentOrders)
+	assert.Equal(t, 2*time.Minute, estimate.Delay)
+
+	// Com um pedido a menos na janela há vaga imediata
+	load.QueueEntries = load.QueueEntries[1:]
+	estimate = estimateKitchenDelay(load, nil, capacity, now)
+	assert.Equal(t, time.Duration(0), estimate.Delay)
+}
@@ -0,0 +1,663 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	// Configurações da aplicação (validade do carrinho)
+	"backend-hamburgueria/config"
+	// Modelos de dados da aplicação
+	"backend-hamburgueria/models"
+
+	// Framework web Gin
+	"github.com/gin-gonic/gin"
+	// Driver do PostgreSQL (arrays)
+	"github.com/lib/pq"
+)
+
+// ===== CARRINHOS =====
+
+// Status do carrinho
+const (
+	cartOpen       = "open"        // Em montagem
+	cartCheckedOut = "checked_out" // Fechado: virou pedido
+)
+
+// maxCartItems limita o número de itens (linhas) de um carrinho
+const maxCartItems = 50
+
+// Erros do carrinho
+var (
+	errCartNotFound   = errors.New("Carrinho não encontrado")
+	errCartCheckedOut = errors.New("Carrinho já foi fechado")
+	errCartExpired    = errors.New("Carrinho expirado")
+	errCartFull       = errors.New("Carrinho cheio")
+	errCartEmpty      = errors.New("Carrinho vazio")
+)
+
+// CreateCart godoc
+// @Summary      Cria um carrinho
+// @Description  Cria um carrinho no servidor, compartilhado pelo totem e pelo app; os dados do pedido são opcionais
+// @Tags         Carts
+// @Accept       json
+// @Produce      json
+// @Param        body  body      models.CartDetails  false  "Dados do pedido"
+// @Success      201   {object}  models.Cart
+// @Router       /api/carts [post]
+func CreateCart(c *gin.Context, db DBInterface) {
+	var details models.CartDetails
+	if c.Request.Body != nil && c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&details); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos"})
+			return
+		}
+	}
+	details.CouponCode = ""
+	raw, err := json.Marshal(details)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos"})
+		return
+	}
+
+	cartID, err := newCartID()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao criar carrinho"})
+		return
+	}
+
+	// Limpeza: carrinhos abandonados há mais de um dia
+	if _, err := db.Exec(`
+		DELETE FROM carts WHERE status = $1 AND expires_at < CURRENT_TIMESTAMP - INTERVAL '1 day'
+	`, cartOpen); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao criar carrinho"})
+		return
+	}
+
+	if _, err := db.Exec(`
+		INSERT INTO carts (id, store_id, details, expires_at)
+		VALUES ($1, $2, $3, CURRENT_TIMESTAMP + $4 * INTERVAL '1 minute')
+	`, cartID, config.StoreID(), raw, config.CartTTL().Minutes()); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao criar carrinho"})
+		return
+	}
+
+	respondCart(c, db, cartID, http.StatusCreated)
+}
+
+// GetCart godoc
+// @Summary      Detalhes do carrinho
+// @Tags         Carts
+// @Produce      json
+// @Param        id   path      string  true  "ID do carrinho"
+// @Success      200  {object}  models.Cart
+// @Failure      404  {object}  models.ErrorResponse "Carrinho não encontrado"
+// @Router       /api/carts/{id} [get]
+func GetCart(c *gin.Context, db DBInterface) {
+	respondCart(c, db, c.Param("id"), http.StatusOK)
+}
+
+// UpdateCart godoc
+// @Summary      Atualiza os dados do pedido no carrinho
+// @Description  Substitui tipo do pedido, cliente, mesa, entrega, observações e gorjeta (o cupom tem rota própria)
+// @Tags         Carts
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string              true  "ID do carrinho"
+// @Param        body  body      models.CartDetails  true  "Dados do pedido"
+// @Success      200   {object}  models.Cart
+// @Failure      404   {object}  models.ErrorResponse "Carrinho não encontrado"
+// @Failure      410   {object}  models.ErrorResponse "Carrinho expirado"
+// @Router       /api/carts/{id} [put]
+func UpdateCart(c *gin.Context, db DBInterface) {
+	cartID := c.Param("id")
+	var details models.CartDetails
+	if err := c.ShouldBindJSON(&details); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos"})
+		return
+	}
+
+	current, err := loadCart(db, cartID)
+	if err != nil {
+		respondCartError(c, err)
+		return
+	}
+	if err := touchCart(db, cartID); err != nil {
+		respondCartError(c, err)
+		return
+	}
+
+	// O cupom continua o mesmo: é aplicado e removido pela rota do cupom
+	details.CouponCode = current.CouponCode
+	if err := saveCartDetails(db, cartID, details); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao atualizar carrinho"})
+		return
+	}
+
+	respondCart(c, db, cartID, http.StatusOK)
+}
+
+// AddCartItem godoc
+// @Summary      Adiciona um item ao carrinho
+// @Description  Valida o produto e as escolhas (ingredientes do lanche personalizado, etapas do combo) com o cálculo de preço do pedido; allergies são as alergias de quem vai consumir o item
+// @Tags         Carts
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string                   true  "ID do carrinho"
+// @Param        body  body      models.OrderItemRequest  true  "Item"
+// @Success      201   {object}  models.Cart
+// @Failure      400   {object}  models.ErrorResponse "Item inválido"
+// @Failure      404   {object}  models.ErrorResponse "Carrinho não encontrado"
+// @Failure      410   {object}  models.ErrorResponse "Carrinho expirado"
+// @Router       /api/carts/{id}/items [post]
+func AddCartItem(c *gin.Context, db DBInterface) {
+	cartID := c.Param("id")
+	var item models.OrderItemRequest
+	if err := c.ShouldBindJSON(&item); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos"})
+		return
+	}
+	if item.Quantity <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": errInvalidQuantity.Error()})
+		return
+	}
+	allergies, err := normalizeAllergens(item.Allergies)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	item.Allergies = allergies
+
+	if err := touchCart(db, cartID); err != nil {
+		respondCartError(c, err)
+		return
+	}
+	var count int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM cart_items WHERE cart_id = $1`, cartID).Scan(&count); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar itens do carrinho"})
+		return
+	}
+	if count >= maxCartItems {
+		respondCartError(c, errCartFull)
+		return
+	}
+	if _, err := priceLine(db, item); err != nil {
+		respondPricingError(c, err)
+		return
+	}
+
+	if _, err := db.Exec(`
+		INSERT INTO cart_items (cart_id, product_id, ingredients, quantity, notes, choices, variant_id, allergies)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`, cartID, item.ProductID, item.Ingredients, item.Quantity, item.Notes, choicesJSON(item.Choices), item.VariantID,
+		pq.Array(item.Allergies)); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao adicionar item ao carrinho"})
+		return
+	}
+
+	respondCart(c, db, cartID, http.StatusCreated)
+}
+
+// UpdateCartItem godoc
+// @Summary      Altera um item do carrinho
+// @Description  Altera quantidade, ingredientes, escolhas do combo, alergias e observações; o produto do item não muda
+// @Tags         Carts
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string                   true  "ID do carrinho"
+// @Param        item_id  path      int                      true  "ID do item"
+// @Param        body     body      models.OrderItemRequest  true  "Item"
+// @Success      200      {object}  models.Cart
+// @Failure      400      {object}  models.ErrorResponse "Item inválido"
+// @Failure      404      {object}  models.ErrorResponse "Item não encontrado"
+// @Router       /api/carts/{id}/items/{item_id} [put]
+func UpdateCartItem(c *gin.Context, db DBInterface) {
+	cartID := c.Param("id")
+	itemID, err := strconv.Atoi(c.Param("item_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do item inválido"})
+		return
+	}
+	var item models.OrderItemRequest
+	if err := c.ShouldBindJSON(&item); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos"})
+		return
+	}
+	if item.Quantity <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": errInvalidQuantity.Error()})
+		return
+	}
+	if item.Allergies, err = normalizeAllergens(item.Allergies); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := touchCart(db, cartID); err != nil {
+		respondCartError(c, err)
+		return
+	}
+	err = db.QueryRow(`SELECT product_id FROM cart_items WHERE id = $1 AND cart_id = $2`, itemID, cartID).Scan(&item.ProductID)
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Item não encontrado no carrinho"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar item do carrinho"})
+		return
+	}
+	if _, err := priceLine(db, item); err != nil {
+		respondPricingError(c, err)
+		return
+	}
+
+	if _, err := db.Exec(`
+		UPDATE cart_items SET ingredients = $1, quantity = $2, notes = $3, choices = $4, variant_id = $5, allergies = $6
+		WHERE id = $7 AND cart_id = $8
+	`, item.Ingredients, item.Quantity, item.Notes, choicesJSON(item.Choices), item.VariantID, pq.Array(item.Allergies),
+		itemID, cartID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao alterar item do carrinho"})
+		return
+	}
+
+	respondCart(c, db, cartID, http.StatusOK)
+}
+
+// RemoveCartItem godoc
+// @Summary      Remove um item do carrinho
+// @Tags         Carts
+// @Produce      json
+// @Param        id       path      string  true  "ID do carrinho"
+// @Param        item_id  path      int     true  "ID do item"
+// @Success      200      {object}  models.Cart
+// @Failure      404      {object}  models.ErrorResponse "Item não encontrado"
+// @Router       /api/carts/{id}/items/{item_id} [delete]
+func RemoveCartItem(c *gin.Context, db DBInterface) {
+	cartID := c.Param("id")
+	itemID, err := strconv.Atoi(c.Param("item_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do item inválido"})
+		return
+	}
+
+	if err := touchCart(db, cartID); err != nil {
+		respondCartError(c, err)
+		return
+	}
+	result, err := db.Exec(`DELETE FROM cart_items WHERE id = $1 AND cart_id = $2`, itemID, cartID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao remover item do carrinho"})
+		return
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Item não encontrado no carrinho"})
+		return
+	}
+
+	respondCart(c, db, cartID, http.StatusOK)
+}
+
+// ApplyCartCoupon godoc
+// @Summary      Aplica um cupom ao carrinho
+// @Description  Confere o cupom com o cálculo de preço do pedido antes de guardá-lo
+// @Tags         Carts
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string                     true  "ID do carrinho"
+// @Param        body  body      models.ApplyCouponRequest  true  "Código do cupom"
+// @Success      200   {object}  models.Cart
+// @Failure      400   {object}  models.ErrorResponse "Cupom inválido"
+// @Router       /api/carts/{id}/coupon [post]
+func ApplyCartCoupon(c *gin.Context, db DBInterface) {
+	cartID := c.Param("id")
+	var req models.ApplyCouponRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos"})
+		return
+	}
+	code := strings.ToUpper(strings.TrimSpace(req.Code))
+	if code == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Informe o código do cupom"})
+		return
+	}
+
+	cart, err := loadCart(db, cartID)
+	if err != nil {
+		respondCartError(c, err)
+		return
+	}
+	if err := touchCart(db, cartID); err != nil {
+		respondCartError(c, err)
+		return
+	}
+
+	// Cotar o carrinho com o cupom: só erros do próprio cupom impedem aplicá-lo
+	// (o carrinho ainda pode estar incompleto, ex.: sem nome para retirada)
+	cart.CouponCode = code
+	orderReq := cartOrderRequest(cart)
+	if err := prepareOrderRequest(&orderReq); err == nil {
+		if _, err := priceOrder(db, &orderReq); isCouponError(err) {
+			respondPricingError(c, err)
+			return
+		}
+	}
+
+	if err := saveCartDetails(db, cartID, cart.CartDetails); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao aplicar cupom"})
+		return
+	}
+	respondCart(c, db, cartID, http.StatusOK)
+}
+
+// RemoveCartCoupon godoc
+// @Summary      Remove o cupom do carrinho
+// @Tags         Carts
+// @Produce      json
+// @Param        id   path      string  true  "ID do carrinho"
+// @Success      200  {object}  models.Cart
+// @Router       /api/carts/{id}/coupon [delete]
+func RemoveCartCoupon(c *gin.Context, db DBInterface) {
+	cartID := c.Param("id")
+	cart, err := loadCart(db, cartID)
+	if err != nil {
+		respondCartError(c, err)
+		return
+	}
+	if err := touchCart(db, cartID); err != nil {
+		respondCartError(c, err)
+		return
+	}
+
+	cart.CouponCode = ""
+	if err := saveCartDetails(db, cartID, cart.CartDetails); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao remover cupom"})
+		return
+	}
+	respondCart(c, db, cartID, http.StatusOK)
+}
+
+// QuoteCart godoc
+// @Summary      Cota o carrinho
+// @Description  Preços, descontos, taxas e total do carrinho com o mesmo cálculo e as mesmas verificações do pedido
+// @Tags         Carts
+// @Produce      json
+// @Param        id   path      string  true  "ID do carrinho"
+// @Success      200  {object}  models.OrderQuote
+// @Failure      400  {object}  models.ErrorResponse "Carrinho incompleto ou inválido"
+// @Failure      404  {object}  models.ErrorResponse "Carrinho não encontrado"
+// @Failure      409  {object}  models.ErrorResponse "Carrinho já foi fechado, horário agendado esgotado ou alergia não confirmada"
+// @Failure      410  {object}  models.ErrorResponse "Carrinho expirado"
+// @Failure      503  {object}  models.ErrorResponse "Loja fechada ou pedidos pausados"
+// @Router       /api/carts/{id}/quote [get]
+func QuoteCart(c *gin.Context, db DBInterface) {
+	cartID := c.Param("id")
+
+	// Carrinho fechado ou expirado não vira pedido: a cotação recusa como o checkout
+	if err := checkCartOpen(db, cartID); err != nil {
+		respondCartError(c, err)
+		return
+	}
+	cart, err := loadCart(db, cartID)
+	if err != nil {
+		respondCartError(c, err)
+		return
+	}
+
+	req := cartOrderRequest(cart)
+	if err := prepareOrderRequest(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	quote, ok := quoteOrderRequest(c, db, &req)
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, quote)
+}
+
+// CheckoutCart godoc
+// @Summary      Fecha o carrinho
+// @Description  Cria o pedido com os itens e dados do carrinho, com as mesmas regras de CreateOrder; o carrinho fica como checked_out
+// @Tags         Carts
+// @Produce      json
+// @Param        id   path      string  true  "ID do carrinho"
+// @Success      201  {object}  map[string]interface{} "Mesma resposta de POST /api/orders, com cart_id"
+// @Failure      400  {object}  models.ErrorResponse "Carrinho vazio ou incompleto"
+// @Failure      404  {object}  models.ErrorResponse "Carrinho não encontrado"
+// @Failure      409  {object}  models.ErrorResponse "Carrinho já foi fechado"
+// @Failure      410  {object}  models.ErrorResponse "Carrinho expirado"
+// @Router       /api/carts/{id}/checkout [post]
+func CheckoutCart(c *gin.Context, db DBInterface) {
+	cartID := c.Param("id")
+
+	// ===== INICIAR TRANSAÇÃO =====
+	tx, err := db.Begin()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao iniciar transação"})
+		return
+	}
+	defer tx.Rollback()
+
+	// Travar o carrinho: dois cliques em "finalizar" geram um único pedido
+	if _, err := tx.Exec(`SELECT 1 FROM carts WHERE id = $1 FOR UPDATE`, cartID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar carrinho"})
+		return
+	}
+	if err := checkCartOpen(tx, cartID); err != nil {
+		respondCartError(c, err)
+		return
+	}
+	cart, err := loadCart(tx, cartID)
+	if err != nil {
+		respondCartError(c, err)
+		return
+	}
+	if len(cart.Items) == 0 {
+		respondCartError(c, errCartEmpty)
+		return
+	}
+
+	// ===== CRIAR PEDIDO =====
+	req := cartOrderRequest(cart)
+	if err := prepareOrderRequest(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	placed, ok := placeOrder(c, tx, &req)
+	if !ok {
+		return
+	}
+	if _, err := tx.Exec(`
+		UPDATE carts SET status = $1, order_id = $2, updated_at = CURRENT_TIMESTAMP WHERE id = $3
+	`, cartCheckedOut, placed.ID, cartID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao fechar carrinho"})
+		return
+	}
+
+	// ===== COMMIT DA TRANSAÇÃO =====
+	if err := tx.Commit(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao finalizar pedido"})
+		return
+	}
+	orderEvents.Publish(orderEvent{Type: eventOrderCreated, OrderID: placed.ID, Status: placed.Status, AllergyAlert: placed.AllergyAlert})
+
+	response := placed.response()
+	response["cart_id"] = cartID
+	c.JSON(http.StatusCreated, response)
+}
+
+// ===== FUNÇÕES AUXILIARES =====
+
+// newCartID gera o identificador aleatório do carrinho
+// Não é sequencial: quem não tem o ID não consegue ler nem alterar o carrinho
+func newCartID() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// loadCart busca o carrinho com seus itens
+func loadCart(q queryer, cartID string) (models.Cart, error) {
+	var cart models.Cart
+	var raw []byte
+	err := q.QueryRow(`
+		SELECT id, details, status, order_id, expires_at, created_at, updated_at FROM carts WHERE id = $1
+	`, cartID).Scan(&cart.ID, &raw, &cart.Status, &cart.OrderID, &cart.ExpiresAt, &cart.CreatedAt, &cart.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return cart, errCartNotFound
+	}
+	if err != nil {
+		return cart, err
+	}
+	if err := json.Unmarshal(raw, &cart.CartDetails); err != nil {
+		return cart, err
+	}
+
+	rows, err := q.Query(`
+		SELECT id, product_id, ingredients, quantity, notes, choices, variant_id, allergies FROM cart_items WHERE cart_id = $1 ORDER BY id
+	`, cartID)
+	if err != nil {
+		return cart, err
+	}
+	defer rows.Close()
+
+	cart.Items = []models.CartItem{}
+	for rows.Next() {
+		var item models.CartItem
+		var choices []byte
+		var variantID sql.NullInt64
+		if err := rows.Scan(&item.ID, &item.ProductID, &item.Ingredients, &item.Quantity, &item.Notes, &choices, &variantID,
+			pq.Array(&item.Allergies)); err != nil {
+			return cart, err
+		}
+		if variantID.Valid {
+			id := int(variantID.Int64)
+			item.VariantID = &id
+		}
+		if err := json.Unmarshal(choices, &item.Choices); err != nil {
+			return cart, err
+		}
+		cart.Items = append(cart.Items, item)
+	}
+	return cart, rows.Err()
+}
+
+// checkCartOpen confere se o carrinho existe, está aberto e não expirou
+func checkCartOpen(q queryer, cartID string) error {
+	var status string
+	var expired bool
+	err := q.QueryRow(`
+		SELECT status, expires_at <= CURRENT_TIMESTAMP FROM carts WHERE id = $1
+	`, cartID).Scan(&status, &expired)
+	switch {
+	case err == sql.ErrNoRows:
+		return errCartNotFound
+	case err != nil:
+		return err
+	case status != cartOpen:
+		return errCartCheckedOut
+	case expired:
+		return errCartExpired
+	}
+	return nil
+}
+
+// touchCart confere se o carrinho pode ser alterado e renova a validade
+func touchCart(q queryer, cartID string) error {
+	if err := checkCartOpen(q, cartID); err != nil {
+		return err
+	}
+	_, err := q.Exec(`
+		UPDATE carts SET expires_at = CURRENT_TIMESTAMP + $1 * INTERVAL '1 minute', updated_at = CURRENT_TIMESTAMP
+		WHERE id = $2
+	`, config.CartTTL().Minutes(), cartID)
+	return err
+}
+
+// saveCartDetails grava os dados do pedido no carrinho
+func saveCartDetails(q queryer, cartID string, details models.CartDetails) error {
+	raw, err := json.Marshal(details)
+	if err != nil {
+		return err
+	}
+	_, err = q.Exec(`UPDATE carts SET details = $1, updated_at = CURRENT_TIMESTAMP WHERE id = $2`, raw, cartID)
+	return err
+}
+
+// cartOrderRequest monta o pedido a partir do carrinho
+func cartOrderRequest(cart models.Cart) models.CreateOrderRequest {
+	details := cart.CartDetails
+	req := models.CreateOrderRequest{
+		OrderType:             details.OrderType,
+		CustomerName:          details.CustomerName,
+		TableNumber:           details.TableNumber,
+		TableToken:            details.TableToken,
+		PickupName:            details.PickupName,
+		CustomerPhone:         details.CustomerPhone,
+		DeliveryAddress:       details.DeliveryAddress,
+		DeliveryAddressID:     details.DeliveryAddressID,
+		DeliveryLatitude:      details.DeliveryLatitude,
+		DeliveryLongitude:     details.DeliveryLongitude,
+		Notes:                 details.Notes,
+		IncludeServiceCharge:  details.IncludeServiceCharge,
+		Tip:                   details.Tip,
+		CouponCode:            details.CouponCode,
+		ScheduledFor:          details.ScheduledFor,
+		Allergies:             details.Allergies,
+		AllowAllergyConflicts: details.AllowAllergyConflicts,
+		Items:                 []models.OrderItemRequest{},
+	}
+	for _, item := range cart.Items {
+		req.Items = append(req.Items, models.OrderItemRequest{
+			ProductID:   item.ProductID,
+			Ingredients: item.Ingredients,
+			Quantity:    item.Quantity,
+			Notes:       item.Notes,
+			Choices:     item.Choices,
+			VariantID:   item.VariantID,
+			Allergies:   item.Allergies,
+		})
+	}
+	return req
+}
+
+// choicesJSON serializa as escolhas do combo para a coluna JSONB do item do carrinho
+func choicesJSON(choices []models.BundleChoice) string {
+	if len(choices) == 0 {
+		return "[]"
+	}
+	raw, _ := json.Marshal(choices)
+	return string(raw)
+}
+
+// respondCart responde com o carrinho atualizado
+func respondCart(c *gin.Context, db DBInterface, cartID string, status int) {
+	cart, err := loadCart(db, cartID)
+	if err != nil {
+		respondCartError(c, err)
+		return
+	}
+	c.JSON(status, cart)
+}
+
+// respondCartError converte um erro do carrinho na resposta HTTP
+func respondCartError(c *gin.Context, err error) {
+	switch err {
+	case errCartNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errCartCheckedOut:
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errCartExpired:
+		c.JSON(http.StatusGone, gin.H{"error": err.Error()})
+	case errCartFull, errCartEmpty:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao acessar carrinho"})
+	}
+}
@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"backend-hamburgueria/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// Teste do identificador aleatório do carrinho
+func TestNewCartID(t *testing.T) {
+	first, err := newCartID()
+	assert.NoError(t, err)
+	assert.Len(t, first, 32)
+
+	second, _ := newCartID()
+	assert.NotEqual(t, first, second)
+}
+
+// Teste da montagem do pedido a partir do carrinho
+func TestCartOrderRequest(t *testing.T) {
+	cart := models.Cart{
+		CartDetails: models.CartDetails{OrderType: orderTypeTakeaway, CustomerName: "Ana", Tip: 2, CouponCode: "BURGER5"},
+		Items:       []models.CartItem{{ID: 9, ProductID: 3, Quantity: 2, Notes: "sem cebola"}},
+	}
+
+	req := cartOrderRequest(cart)
+	assert.Equal(t, orderTypeTakeaway, req.OrderType)
+	assert.Equal(t, "BURGER5", req.CouponCode)
+	assert.Equal(t, 2.0, req.Tip)
+	assert.Equal(t, []models.OrderItemRequest{{ProductID: 3, Quantity: 2, Notes: "sem cebola"}}, req.Items)
+}
+
+// Teste da conversão dos erros do carrinho em respostas HTTP
+func TestRespondCartError(t *testing.T) {
+	cases := map[error]int{
+		errCartNotFound:   http.StatusNotFound,
+		errCartCheckedOut: http.StatusConflict,
+		errCartExpired:    http.StatusGone,
+		errCartEmpty:      http.StatusBadRequest,
+		sql.ErrConnDone:   http.StatusInternalServerError,
+	}
+	for err, code := range cases {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		respondCartError(c, err)
+		assert.Equal(t, code, w.Code, err.Error())
+	}
+}
+
+// Teste para AddCartItem com quantidade inválida
+func TestAddCartItemInvalidQuantity(t *testing.T) {
+	router, mockDB := setupTest()
+
+	router.POST("/carts/:id/items", func(c *gin.Context) {
+		AddCartItem(c, mockDB)
+	})
+
+	req, _ := http.NewRequest("POST", "/carts/abc/items", strings.NewReader(`{"product_id": 2, "quantity": 0}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+// Teste para AddCartItem com alergia desconhecida (recusada antes do banco)
+func TestAddCartItemInvalidAllergy(t *testing.T) {
+	router, mockDB := setupTest()
+
+	router.POST("/carts/:id/items", func(c *gin.Context) {
+		AddCartItem(c, mockDB)
+	})
+
+	body := `{"product_id": 2, "quantity": 1, "allergies": ["pimenta"]}`
+	req, _ := http.NewRequest("POST", "/carts/abc/items", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+// Teste do fechamento de carrinho com alergia declarada só no item: o pedido é recusado com 409
+// até a confirmação, como na criação direta
+func TestCheckoutCartItemAllergyConflict(t *testing.T) {
+	cart := models.Cart{
+		CartDetails: models.CartDetails{OrderType: orderTypeTakeaway, CustomerName: "Ana"},
+		// Produto 5 (receita com pão e cheddar): glúten e lactose
+		Items: []models.CartItem{{ID: 9, ProductID: 5, Quantity: 1, Allergies: []string{"lactose"}}},
+	}
+
+	req := cartOrderRequest(cart)
+	assert.Equal(t, []string{"lactose"}, req.Items[0].Allergies)
+	assert.NoError(t, normalizeOrderAllergies(&req))
+
+	lines := []pricedLine{{Item: req.Items[0]}}
+	applyAllergies(lines, sampleDietary(), req.Allergies)
+	conflicts := orderPricing{Lines: lines}.allergyConflicts()
+	assert.Len(t, conflicts, 1)
+	assert.False(t, req.AllowAllergyConflicts)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	respondAllergyConflict(c, conflicts)
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), `"allergens":["lactose"]`)
+}
+
+// Teste para CheckoutCart com falha ao iniciar a transação
+func TestCheckoutCart(t *testing.T) {
+	router, mockDB := setupTest()
+
+	router.POST("/carts/:id/checkout", func(c *gin.Context) {
+		CheckoutCart(c, mockDB)
+	})
+
+	mockDB.BeginFunc = func() (*sql.Tx, error) {
+		return nil, sql.ErrConnDone
+	}
+
+	req, _ := http.NewRequest("POST", "/carts/abc/checkout", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
@@ -0,0 +1,435 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	// Configurações da aplicação (fuso horário da loja)
+	"backend-hamburgueria/config"
+	// Modelos de dados da aplicação
+	"backend-hamburgueria/models"
+
+	// Framework web Gin
+	"github.com/gin-gonic/gin"
+	// Driver do PostgreSQL (arrays)
+	"github.com/lib/pq"
+)
+
+// ===== CUPONS DE DESCONTO =====
+
+// Tipos de cupom
+const (
+	couponPercentage = "percentage" // Percentual sobre os itens participantes
+	couponFixed      = "fixed"      // Valor fixo, limitado aos itens participantes
+)
+
+// Erros do cupom informado no pedido
+var (
+	errCouponNotFound      = errors.New("Cupom inválido")
+	errCouponExpired       = errors.New("Cupom fora do período de validade")
+	errCouponWrongDay      = errors.New("Cupom não vale neste dia da semana")
+	errCouponNotEligible   = errors.New("Cupom não vale para os itens do pedido")
+	errCouponExhausted     = errors.New("Cupom esgotado")
+	errCouponCustomerLimit = errors.New("Limite de uso do cupom atingido para este cliente")
+	errCouponPhoneRequired = errors.New("Informe o telefone para usar este cupom")
+)
+
+// couponMinOrderError indica um pedido abaixo do mínimo exigido pelo cupom
+type couponMinOrderError struct {
+	MinOrder float64
+}
+
+func (e *couponMinOrderError) Error() string {
+	return "Pedido abaixo do valor mínimo para usar este cupom"
+}
+
+// appliedCoupon é o cupom aceito no cálculo de preço, com o desconto em centavos
+type appliedCoupon struct {
+	Coupon   models.Coupon
+	Discount int64
+}
+
+// couponColumns são as colunas lidas por scanCoupon
+const couponColumns = `id, code, description, kind, value, min_order, max_uses, max_uses_per_customer,
+	starts_at, ends_at, weekdays, product_ids, category_ids, is_active, uses_count, created_at`
+
+// GetCoupons godoc
+// @Summary      Lista os cupons
+// @Description  Retorna todos os cupons cadastrados com a quantidade de usos
+// @Tags         Coupons
+// @Produce      json
+// @Success      200  {array}   models.Coupon
+// @Failure      500  {object}  models.ErrorResponse "Erro ao buscar cupons"
+// @Router       /api/coupons [get]
+func GetCoupons(c *gin.Context, db DBInterface) {
+	rows, err := db.Query("SELECT " + couponColumns + " FROM coupons ORDER BY created_at DESC, id DESC")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar cupons"})
+		return
+	}
+	defer rows.Close()
+
+	coupons := []models.Coupon{}
+	for rows.Next() {
+		coupon, err := scanCoupon(rows)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao ler cupom"})
+			return
+		}
+		coupons = append(coupons, coupon)
+	}
+	if err := rows.Err(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar cupons"})
+		return
+	}
+	c.JSON(http.StatusOK, coupons)
+}
+
+// CreateCoupon godoc
+// @Summary      Cria um cupom
+// @Description  Cadastra um cupom percentual ou de valor fixo, com pedido mínimo, limites de uso, validade e itens participantes
+// @Tags         Coupons
+// @Accept       json
+// @Produce      json
+// @Param        body  body      models.CouponRequest  true  "Dados do cupom"
+// @Success      201   {object}  models.Coupon
+// @Failure      400   {object}  models.ErrorResponse "Dados inválidos"
+// @Failure      409   {object}  models.ErrorResponse "Código já cadastrado"
+// @Router       /api/coupons [post]
+func CreateCoupon(c *gin.Context, db DBInterface) {
+	// ===== VALIDAR DADOS DE ENTRADA =====
+	var req models.CouponRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos"})
+		return
+	}
+	if err := validateCouponRequest(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// ===== INSERIR CUPOM =====
+	row := db.QueryRow(`
+		INSERT INTO coupons (code, description, kind, value, min_order, max_uses, max_uses_per_customer,
+			starts_at, ends_at, weekdays, product_ids, category_ids, is_active)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		RETURNING `+couponColumns,
+		req.Code, req.Description, req.Kind, req.Value, req.MinOrder, req.MaxUses, req.MaxUsesPerCustomer,
+		req.StartsAt, req.EndsAt, pq.Array(req.Weekdays), pq.Array(req.ProductIDs), pq.Array(req.CategoryIDs), *req.IsActive)
+	coupon, err := scanCoupon(row)
+	if isUniqueViolation(err) {
+		c.JSON(http.StatusConflict, gin.H{"error": "Já existe um cupom com este código"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao criar cupom"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, coupon)
+}
+
+// UpdateCoupon godoc
+// @Summary      Altera um cupom
+// @Description  Substitui os dados do cupom; os usos já registrados são mantidos
+// @Tags         Coupons
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                   true  "ID do cupom"
+// @Param        body  body      models.CouponRequest  true  "Dados do cupom"
+// @Success      200   {object}  models.Coupon
+// @Failure      400   {object}  models.ErrorResponse "Dados inválidos"
+// @Failure      404   {object}  models.ErrorResponse "Cupom não encontrado"
+// @Failure      409   {object}  models.ErrorResponse "Código já cadastrado"
+// @Router       /api/coupons/{id} [put]
+func UpdateCoupon(c *gin.Context, db DBInterface) {
+	// ===== VALIDAR DADOS DE ENTRADA =====
+	couponID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+	var req models.CouponRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos"})
+		return
+	}
+	if err := validateCouponRequest(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// ===== ATUALIZAR CUPOM =====
+	row := db.QueryRow(`
+		UPDATE coupons SET code = $2, description = $3, kind = $4, value = $5, min_order = $6, max_uses = $7,
+			max_uses_per_customer = $8, starts_at = $9, ends_at = $10, weekdays = $11, product_ids = $12,
+			category_ids = $13, is_active = $14
+		WHERE id = $1
+		RETURNING `+couponColumns,
+		couponID, req.Code, req.Description, req.Kind, req.Value, req.MinOrder, req.MaxUses, req.MaxUsesPerCustomer,
+		req.StartsAt, req.EndsAt, pq.Array(req.Weekdays), pq.Array(req.ProductIDs), pq.Array(req.CategoryIDs), *req.IsActive)
+	coupon, err := scanCoupon(row)
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Cupom não encontrado"})
+		return
+	}
+	if isUniqueViolation(err) {
+		c.JSON(http.StatusConflict, gin.H{"error": "Já existe um cupom com este código"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao atualizar cupom"})
+		return
+	}
+
+	c.JSON(http.StatusOK, coupon)
+}
+
+// ===== FUNÇÕES AUXILIARES =====
+
+// validateCouponRequest normaliza o código e confere valores, limites e validade
+func validateCouponRequest(req *models.CouponRequest) error {
+	req.Code = strings.ToUpper(strings.TrimSpace(req.Code))
+	req.Description = strings.TrimSpace(req.Description)
+	if req.Code == "" || strings.ContainsAny(req.Code, " \t") {
+		return errors.New("Código do cupom inválido")
+	}
+	switch req.Kind {
+	case couponPercentage:
+		if req.Value <= 0 || req.Value > 100 {
+			return errors.New("Percentual do cupom deve estar entre 0 e 100")
+		}
+	case couponFixed:
+		if req.Value <= 0 {
+			return errors.New("Valor do cupom deve ser maior que zero")
+		}
+	default:
+		return errors.New("Tipo de cupom inválido (use percentage ou fixed)")
+	}
+	if req.MinOrder < 0 {
+		return errors.New("Pedido mínimo não pode ser negativo")
+	}
+	if (req.MaxUses != nil && *req.MaxUses <= 0) || (req.MaxUsesPerCustomer != nil && *req.MaxUsesPerCustomer <= 0) {
+		return errors.New("Limites de uso devem ser maiores que zero")
+	}
+	if req.StartsAt != nil && req.EndsAt != nil && !req.EndsAt.After(*req.StartsAt) {
+		return errors.New("Fim da validade deve ser depois do início")
+	}
+	for _, day := range req.Weekdays {
+		if day < 0 || day > 6 {
+			return errors.New("Dias da semana vão de 0 (domingo) a 6 (sábado)")
+		}
+	}
+	if req.Weekdays == nil {
+		req.Weekdays = []int{}
+	}
+	if req.ProductIDs == nil {
+		req.ProductIDs = []int{}
+	}
+	if req.CategoryIDs == nil {
+		req.CategoryIDs = []int{}
+	}
+	if req.IsActive == nil {
+		active := true
+		req.IsActive = &active
+	}
+	return nil
+}
+
+// scanCoupon lê um cupom nas colunas de couponColumns
+func scanCoupon(row rowScanner) (models.Coupon, error) {
+	var coupon models.Coupon
+	var maxUses, maxPerCustomer sql.NullInt64
+	var weekdays, productIDs, categoryIDs pq.Int64Array
+	err := row.Scan(&coupon.ID, &coupon.Code, &coupon.Description, &coupon.Kind, &coupon.Value, &coupon.MinOrder,
+		&maxUses, &maxPerCustomer, &coupon.StartsAt, &coupon.EndsAt, &weekdays, &productIDs, &categoryIDs,
+		&coupon.IsActive, &coupon.UsesCount, &coupon.CreatedAt)
+	if err != nil {
+		return coupon, err
+	}
+	if maxUses.Valid {
+		value := int(maxUses.Int64)
+		coupon.MaxUses = &value
+	}
+	if maxPerCustomer.Valid {
+		value := int(maxPerCustomer.Int64)
+		coupon.MaxUsesPerCustomer = &value
+	}
+	coupon.Weekdays = intsFromArray(weekdays)
+	coupon.ProductIDs = intsFromArray(productIDs)
+	coupon.CategoryIDs = intsFromArray(categoryIDs)
+	return coupon, nil
+}
+
+// intsFromArray converte um array do PostgreSQL em []int (nunca nil)
+func intsFromArray(values pq.Int64Array) []int {
+	ints := make([]int, 0, len(values))
+	for _, value := range values {
+		ints = append(ints, int(value))
+	}
+	return ints
+}
+
+// applyCoupon busca o cupom pelo código e calcula o desconto sobre os itens do pedido
+// Os limites de uso são conferidos aqui para a cotação já recusar o cupom; a garantia
+// contra usos simultâneos fica em redeemCoupon, na transação do pedido
+func applyCoupon(q queryer, req *models.CreateOrderRequest, lines []pricedLine, subtotal int64) (appliedCoupon, error) {
+	var applied appliedCoupon
+	coupon, err := scanCoupon(q.QueryRow("SELECT "+couponColumns+" FROM coupons WHERE code = $1 AND is_active",
+		strings.ToUpper(strings.TrimSpace(req.CouponCode))))
+	if err == sql.ErrNoRows {
+		return applied, errCouponNotFound
+	}
+	if err != nil {
+		return applied, err
+	}
+
+	discount, err := couponDiscount(coupon, lines, subtotal, time.Now())
+	if err != nil {
+		return applied, err
+	}
+	if coupon.MaxUses != nil && coupon.UsesCount >= *coupon.MaxUses {
+		return applied, errCouponExhausted
+	}
+	if coupon.MaxUsesPerCustomer != nil {
+		if req.CustomerPhone == "" {
+			return applied, errCouponPhoneRequired
+		}
+		used, err := countCustomerRedemptions(q, coupon.ID, req.CustomerPhone)
+		if err != nil {
+			return applied, err
+		}
+		if used >= *coupon.MaxUsesPerCustomer {
+			return applied, errCouponCustomerLimit
+		}
+	}
+	return appliedCoupon{Coupon: coupon, Discount: discount}, nil
+}
+
+// couponDiscount confere validade, dia da semana e pedido mínimo e calcula o desconto em centavos
+// O desconto incide só nos itens participantes (já com as promoções); o pedido mínimo vale para o subtotal inteiro
+func couponDiscount(coupon models.Coupon, lines []pricedLine, subtotal int64, now time.Time) (int64, error) {
+	if (coupon.StartsAt != nil && now.Before(*coupon.StartsAt)) || (coupon.EndsAt != nil && !now.Before(*coupon.EndsAt)) {
+		return 0, errCouponExpired
+	}
+	if len(coupon.Weekdays) > 0 && !containsInt(coupon.Weekdays, int(now.In(config.StoreLocation()).Weekday())) {
+		return 0, errCouponWrongDay
+	}
+	if subtotal < toCents(coupon.MinOrder) {
+		return 0, &couponMinOrderError{MinOrder: coupon.MinOrder}
+	}
+
+	var eligible int64
+	for _, line := range lines {
+		if couponAppliesTo(coupon, line) {
+			eligible += toCents(line.TotalPrice) - line.Discount
+		}
+	}
+	if eligible == 0 {
+		return 0, errCouponNotEligible
+	}
+
+	if coupon.Kind == couponPercentage {
+		return int64(math.Round(float64(eligible) * coupon.Value / 100)), nil
+	}
+	if discount := toCents(coupon.Value); discount < eligible {
+		return discount, nil
+	}
+	return eligible, nil
+}
+
+// couponAppliesTo indica se o item participa do cupom (pelo produto ou pela categoria)
+// Sem produtos nem categorias definidos, todos os itens participam
+func couponAppliesTo(coupon models.Coupon, line pricedLine) bool {
+	if len(coupon.ProductIDs) == 0 && len(coupon.CategoryIDs) == 0 {
+		return true
+	}
+	return containsInt(coupon.ProductIDs, line.Item.ProductID) || containsInt(coupon.CategoryIDs, line.CategoryID)
+}
+
+// containsInt indica se o valor está na lista
+func containsInt(values []int, value int) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// countCustomerRedemptions conta os usos do cupom por um cliente (telefone)
+func countCustomerRedemptions(q queryer, couponID int, phone string) (int, error) {
+	var used int
+	err := q.QueryRow(`
+		SELECT COUNT(*) FROM coupon_redemptions WHERE coupon_id = $1 AND customer_phone = $2
+	`, couponID, phone).Scan(&used)
+	return used, err
+}
+
+// redeemCoupon registra o uso do cupom na transação do pedido
+// O UPDATE condicional trava a linha do cupom até o fim da transação: checkouts simultâneos
+// com o mesmo código passam aqui um de cada vez, e o último uso disponível só é concedido uma vez
+func redeemCoupon(tx *sql.Tx, applied *appliedCoupon, orderID int, phone string) error {
+	coupon := applied.Coupon
+	var id int
+	err := tx.QueryRow(`
+		UPDATE coupons SET uses_count = uses_count + 1
+		WHERE id = $1 AND is_active AND (max_uses IS NULL OR uses_count < max_uses)
+		RETURNING id
+	`, coupon.ID).Scan(&id)
+	if err == sql.ErrNoRows {
+		return errCouponExhausted
+	}
+	if err != nil {
+		return err
+	}
+
+	// Com a linha travada, a contagem por cliente já enxerga os usos concorrentes confirmados
+	if coupon.MaxUsesPerCustomer != nil {
+		used, err := countCustomerRedemptions(tx, coupon.ID, phone)
+		if err != nil {
+			return err
+		}
+		if used >= *coupon.MaxUsesPerCustomer {
+			return errCouponCustomerLimit
+		}
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO coupon_redemptions (coupon_id, order_id, customer_phone, discount_amount)
+		VALUES ($1, $2, NULLIF($3, ''), $4)
+	`, coupon.ID, orderID, phone, fromCents(applied.Discount))
+	return err
+}
+
+// isCouponError indica se o erro de preço vem do cupom informado
+func isCouponError(err error) bool {
+	var minOrder *couponMinOrderError
+	switch {
+	case errors.As(err, &minOrder):
+		return true
+	case err == errCouponNotFound || err == errCouponExpired || err == errCouponWrongDay || err == errCouponNotEligible ||
+		err == errCouponExhausted || err == errCouponCustomerLimit || err == errCouponPhoneRequired:
+		return true
+	}
+	return false
+}
+
+// respondCouponError converte um erro do cupom na resposta HTTP
+// Limites de uso atingidos respondem 409; os demais erros do cupom, 400
+func respondCouponError(c *gin.Context, err error) {
+	var minOrder *couponMinOrderError
+	switch {
+	case errors.As(err, &minOrder):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "min_order": minOrder.MinOrder})
+	case err == errCouponExhausted || err == errCouponCustomerLimit:
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+}
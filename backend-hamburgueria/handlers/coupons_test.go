@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"backend-hamburgueria/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// couponLines monta itens já precificados para os testes de desconto
+func couponLines() []pricedLine {
+	return []pricedLine{
+		{Item: models.OrderItemRequest{ProductID: 2, Quantity: 2}, CategoryID: 1, TotalPrice: 50},
+		{Item: models.OrderItemRequest{ProductID: 7, Quantity: 1}, CategoryID: 3, TotalPrice: 8},
+	}
+}
+
+// Teste do desconto percentual e fixo, sobre todos os itens ou só os participantes
+func TestCouponDiscount(t *testing.T) {
+	now := time.Now()
+
+	discount, err := couponDiscount(models.Coupon{Kind: couponPercentage, Value: 10}, couponLines(), 5800, now)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(580), discount)
+
+	// Só a categoria 3 participa: 10% de R$ 8,00
+	discount, err = couponDiscount(models.Coupon{Kind: couponPercentage, Value: 10, CategoryIDs: []int{3}}, couponLines(), 5800, now)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(80), discount)
+
+	// Valor fixo limitado aos itens participantes
+	discount, err = couponDiscount(models.Coupon{Kind: couponFixed, Value: 15, ProductIDs: []int{7}}, couponLines(), 5800, now)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(800), discount)
+
+	_, err = couponDiscount(models.Coupon{Kind: couponFixed, Value: 5, ProductIDs: []int{99}}, couponLines(), 5800, now)
+	assert.Equal(t, errCouponNotEligible, err)
+}
+
+// Teste da validade, dos dias da semana e do pedido mínimo do cupom
+func TestCouponDiscountRestrictions(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+
+	_, err := couponDiscount(models.Coupon{Kind: couponFixed, Value: 5, StartsAt: &future}, couponLines(), 5800, now)
+	assert.Equal(t, errCouponExpired, err)
+	_, err = couponDiscount(models.Coupon{Kind: couponFixed, Value: 5, EndsAt: &past}, couponLines(), 5800, now)
+	assert.Equal(t, errCouponExpired, err)
+
+	// Loja em UTC: domingo (0) não vale numa segunda-feira
+	t.Setenv("STORE_TIMEZONE", "UTC")
+	monday := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	_, err = couponDiscount(models.Coupon{Kind: couponFixed, Value: 5, Weekdays: []int{0}}, couponLines(), 5800, monday)
+	assert.Equal(t, errCouponWrongDay, err)
+	_, err = couponDiscount(models.Coupon{Kind: couponFixed, Value: 5, Weekdays: []int{1}}, couponLines(), 5800, monday)
+	assert.NoError(t, err)
+
+	_, err = couponDiscount(models.Coupon{Kind: couponFixed, Value: 5, MinOrder: 60}, couponLines(), 5800, now)
+	var minOrder *couponMinOrderError
+	assert.ErrorAs(t, err, &minOrder)
+	assert.Equal(t, 60.0, minOrder.MinOrder)
+}
+
+// Teste da validação do cadastro de cupons
+func TestValidateCouponRequest(t *testing.T) {
+	req := models.CouponRequest{Code: " burger5 ", Kind: couponFixed, Value: 5}
+	assert.NoError(t, validateCouponRequest(&req))
+	assert.Equal(t, "BURGER5", req.Code)
+	assert.True(t, *req.IsActive)
+	assert.Equal(t, []int{}, req.Weekdays)
+
+	zero := 0
+	start := time.Now()
+	end := start.Add(-time.Hour)
+	invalid := []models.CouponRequest{
+		{Code: "", Kind: couponFixed, Value: 5},
+		{Code: "A B", Kind: couponFixed, Value: 5},
+		{Code: "X", Kind: "free", Value: 5},
+		{Code: "X", Kind: couponPercentage, Value: 150},
+		{Code: "X", Kind: couponFixed, Value: 5, MaxUses: &zero},
+		{Code: "X", Kind: couponFixed, Value: 5, StartsAt: &start, EndsAt: &end},
+		{Code: "X", Kind: couponFixed, Value: 5, Weekdays: []int{7}},
+	}
+	for _, req := range invalid {
+		assert.Error(t, validateCouponRequest(&req))
+	}
+}
+
+// Teste para CreateCoupon com dados inválidos (recusado antes do banco)
+func TestCreateCouponInvalid(t *testing.T) {
+	router, mockDB := setupTest()
+
+	router.POST("/coupons", func(c *gin.Context) {
+		CreateCoupon(c, mockDB)
+	})
+
+	body := `{"code": "BURGER5", "kind": "percentage", "value": 0}`
+	req, _ := http.NewRequest("POST", "/coupons", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+// Teste da conversão dos erros do cupom em respostas HTTP
+func TestRespondCouponError(t *testing.T) {
+	cases := map[error]int{
+		errCouponNotFound:                  http.StatusBadRequest,
+		errCouponWrongDay:                  http.StatusBadRequest,
+		&couponMinOrderError{MinOrder: 40}: http.StatusBadRequest,
+		errCouponExhausted:                 http.StatusConflict,
+		errCouponCustomerLimit:             http.StatusConflict,
+	}
+	for err, code := range cases {
+		assert.True(t, isCouponError(err), err.Error())
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		respondCouponError(c, err)
+		assert.Equal(t, code, w.Code, err.Error())
+	}
+	assert.False(t, isCouponError(errProductNotFound))
+}
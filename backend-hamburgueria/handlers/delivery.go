@@ -0,0 +1,432 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	// Configurações da aplicação (localização da loja)
+	"backend-hamburgueria/config"
+	// Modelos de dados da aplicação
+	"backend-hamburgueria/models"
+
+	// Framework web Gin
+	"github.com/gin-gonic/gin"
+)
+
+// ===== ENTREGA: ENDEREÇOS, ZONAS E TAXAS =====
+
+// Tipos de zona de entrega
+const (
+	deliveryZoneRadius  = "radius"  // Raio a partir da loja
+	deliveryZonePolygon = "polygon" // Área desenhada no mapa
+)
+
+// Erros da resolução de entrega, convertidos em respostas 400/404 pelos handlers
+var (
+	errAddressNotFound        = errors.New("Endereço de entrega não encontrado")
+	errAddressWithoutLocation = errors.New("Informe as coordenadas do endereço de entrega")
+	errOutsideDeliveryArea    = errors.New("Endereço fora da área de entrega")
+)
+
+// orderDelivery reúne o que foi resolvido para um pedido de entrega
+type orderDelivery struct {
+	AddressID *int            // Endereço salvo usado (nil = endereço avulso)
+	Point     models.GeoPoint // Coordenadas do destino
+	Quote     models.DeliveryQuote
+}
+
+// GetDeliveryZones godoc
+// @Summary      Lista as zonas de entrega
+// @Description  Retorna as zonas de entrega com taxa e pedido mínimo, na ordem de prioridade
+// @Tags         Delivery
+// @Produce      json
+// @Success      200  {array}   models.DeliveryZone
+// @Failure      500  {object}  models.ErrorResponse "Erro ao buscar zonas de entrega"
+// @Router       /api/delivery/zones [get]
+func GetDeliveryZones(c *gin.Context, db DBInterface) {
+	zones, err := loadDeliveryZones(db, false)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar zonas de entrega"})
+		return
+	}
+	c.JSON(http.StatusOK, zones)
+}
+
+// CreateDeliveryZone godoc
+// @Summary      Cria uma zona de entrega
+// @Description  Cadastra uma zona por raio (km a partir da loja) ou por polígono, com taxa e pedido mínimo
+// @Tags         Delivery
+// @Accept       json
+// @Produce      json
+// @Param        body  body      models.DeliveryZone  true  "Zona de entrega"
+// @Success      201   {object}  models.DeliveryZone
+// @Failure      400   {object}  models.ErrorResponse "Dados inválidos"
+// @Failure      500   {object}  models.ErrorResponse "Erro ao criar zona de entrega"
+// @Router       /api/delivery/zones [post]
+func CreateDeliveryZone(c *gin.Context, db DBInterface) {
+	// ===== VALIDAR DADOS DE ENTRADA =====
+	var zone models.DeliveryZone
+	if err := c.ShouldBindJSON(&zone); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos"})
+		return
+	}
+	if err := validateDeliveryZone(&zone); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// ===== INSERIR ZONA =====
+	var polygon interface{}
+	if zone.Kind == deliveryZonePolygon {
+		raw, _ := json.Marshal(zone.Polygon)
+		polygon = string(raw)
+	}
+	err := db.QueryRow(`
+		INSERT INTO delivery_zones (name, kind, radius_km, polygon, delivery_fee, min_order, sort_order, is_active)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, TRUE)
+		RETURNING id, created_at
+	`, zone.Name, zone.Kind, zone.RadiusKm, polygon, zone.DeliveryFee, zone.MinOrder, zone.SortOrder).Scan(&zone.ID, &zone.CreatedAt)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao criar zona de entrega"})
+		return
+	}
+	zone.IsActive = true
+
+	c.JSON(http.StatusCreated, zone)
+}
+
+// QuoteDelivery godoc
+// @Summary      Calcula a taxa de entrega
+// @Description  Informa a zona, a taxa e o pedido mínimo para um endereço salvo (address_id) ou coordenadas (latitude/longitude)
+// @Tags         Delivery
+// @Produce      json
+// @Param        address_id  query     int     false  "ID do endereço salvo"
+// @Param        latitude    query     number  false  "Latitude do destino"
+// @Param        longitude   query     number  false  "Longitude do destino"
+// @Success      200  {object}  models.DeliveryQuote
+// @Failure      400  {object}  models.ErrorResponse "Endereço fora da área de entrega"
+// @Failure      404  {object}  models.ErrorResponse "Endereço de entrega não encontrado"
+// @Router       /api/delivery/quote [get]
+func QuoteDelivery(c *gin.Context, db DBInterface) {
+	// ===== VALIDAR DESTINO =====
+	var point models.GeoPoint
+	if rawID := c.Query("address_id"); rawID != "" {
+		addressID, err := strconv.Atoi(rawID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "ID do endereço inválido"})
+			return
+		}
+		address, err := loadCustomerAddress(db, addressID)
+		if err != nil {
+			respondDeliveryError(c, err)
+			return
+		}
+		point = models.GeoPoint{Latitude: address.Latitude, Longitude: address.Longitude}
+	} else {
+		latitude, errLat := strconv.ParseFloat(c.Query("latitude"), 64)
+		longitude, errLng := strconv.ParseFloat(c.Query("longitude"), 64)
+		point = models.GeoPoint{Latitude: latitude, Longitude: longitude}
+		if errLat != nil || errLng != nil || !validGeoPoint(point) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": errAddressWithoutLocation.Error()})
+			return
+		}
+	}
+
+	// ===== LOCALIZAR ZONA =====
+	quote, err := quoteDeliveryPoint(db, point)
+	if err != nil {
+		respondDeliveryError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, quote)
+}
+
+// CreateCustomerAddress godoc
+// @Summary      Salva um endereço de entrega
+// @Description  Cadastra um endereço do cliente (identificado pelo telefone) com coordenadas
+// @Tags         Delivery
+// @Accept       json
+// @Produce      json
+// @Param        body  body      models.CustomerAddress  true  "Endereço"
+// @Success      201   {object}  models.CustomerAddress
+// @Failure      400   {object}  models.ErrorResponse "Dados inválidos"
+// @Failure      500   {object}  models.ErrorResponse "Erro ao salvar endereço"
+// @Router       /api/addresses [post]
+func CreateCustomerAddress(c *gin.Context, db DBInterface) {
+	// ===== VALIDAR DADOS DE ENTRADA =====
+	var address models.CustomerAddress
+	if err := c.ShouldBindJSON(&address); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos"})
+		return
+	}
+	address.CustomerPhone = normalizePhone(address.CustomerPhone)
+	address.Street = strings.TrimSpace(address.Street)
+	if len(address.CustomerPhone) < 10 || len(address.CustomerPhone) > 13 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Telefone inválido, informe DDD e número"})
+		return
+	}
+	if address.Street == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Rua é obrigatória"})
+		return
+	}
+	point := models.GeoPoint{Latitude: address.Latitude, Longitude: address.Longitude}
+	if (address.Latitude == 0 && address.Longitude == 0) || !validGeoPoint(point) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": errAddressWithoutLocation.Error()})
+		return
+	}
+
+	// ===== INSERIR ENDEREÇO =====
+	err := db.QueryRow(`
+		INSERT INTO customer_addresses (customer_phone, label, street, number, complement, neighborhood, city, reference, latitude, longitude)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id, created_at
+	`, address.CustomerPhone, address.Label, address.Street, address.Number, address.Complement,
+		address.Neighborhood, address.City, address.Reference, address.Latitude, address.Longitude).Scan(&address.ID, &address.CreatedAt)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao salvar endereço"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, address)
+}
+
+// GetCustomerAddresses godoc
+// @Summary      Lista os endereços do cliente
+// @Description  Retorna os endereços salvos para o telefone informado
+// @Tags         Delivery
+// @Produce      json
+// @Param        phone  query     string  true  "Telefone do cliente"
+// @Success      200    {array}   models.CustomerAddress
+// @Failure      400    {object}  models.ErrorResponse "Telefone inválido"
+// @Failure      500    {object}  models.ErrorResponse "Erro ao buscar endereços"
+// @Router       /api/addresses [get]
+func GetCustomerAddresses(c *gin.Context, db DBInterface) {
+	phone := normalizePhone(c.Query("phone"))
+	if len(phone) < 10 || len(phone) > 13 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Telefone inválido, informe DDD e número"})
+		return
+	}
+
+	rows, err := db.Query(`SELECT `+addressColumns+` FROM customer_addresses WHERE customer_phone = $1 ORDER BY created_at DESC`, phone)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar endereços"})
+		return
+	}
+	defer rows.Close()
+
+	addresses := []models.CustomerAddress{}
+	for rows.Next() {
+		var address models.CustomerAddress
+		if err := scanAddress(rows, &address); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao ler endereço"})
+			return
+		}
+		addresses = append(addresses, address)
+	}
+
+	c.JSON(http.StatusOK, addresses)
+}
+
+// ===== FUNÇÕES AUXILIARES =====
+
+// addressColumns lista as colunas de customer_addresses na ordem lida por scanAddress
+const addressColumns = `id, customer_phone, label, street, number, complement, neighborhood, city, reference, latitude, longitude, created_at`
+
+// scanAddress lê uma linha de customer_addresses selecionada com addressColumns
+func scanAddress(row rowScanner, address *models.CustomerAddress) error {
+	return row.Scan(&address.ID, &address.CustomerPhone, &address.Label, &address.Street, &address.Number, &address.Complement,
+		&address.Neighborhood, &address.City, &address.Reference, &address.Latitude, &address.Longitude, &address.CreatedAt)
+}
+
+// loadCustomerAddress busca um endereço salvo pelo ID
+func loadCustomerAddress(q queryer, addressID int) (models.CustomerAddress, error) {
+	var address models.CustomerAddress
+	err := scanAddress(q.QueryRow(`SELECT `+addressColumns+` FROM customer_addresses WHERE id = $1`, addressID), &address)
+	if err == sql.ErrNoRows {
+		return address, errAddressNotFound
+	}
+	return address, err
+}
+
+// formatAddress monta o endereço em uma linha para a comanda e o entregador
+func formatAddress(address models.CustomerAddress) string {
+	line := address.Street
+	if address.Number != "" {
+		line += ", " + address.Number
+	}
+	for _, part := range []string{address.Complement, address.Neighborhood, address.City} {
+		if part != "" {
+			line += " - " + part
+		}
+	}
+	if address.Reference != "" {
+		line += " (" + address.Reference + ")"
+	}
+	return line
+}
+
+// loadDeliveryZones busca as zonas de entrega na ordem de prioridade
+func loadDeliveryZones(q queryer, activeOnly bool) ([]models.DeliveryZone, error) {
+	rows, err := q.Query(`
+		SELECT id, name, kind, COALESCE(radius_km, 0), COALESCE(polygon::text, ''), delivery_fee, min_order, sort_order, is_active, created_at
+		FROM delivery_zones
+		WHERE is_active OR NOT $1
+		ORDER BY sort_order, delivery_fee, id
+	`, activeOnly)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	zones := []models.DeliveryZone{}
+	for rows.Next() {
+		var zone models.DeliveryZone
+		var polygon string
+		if err := rows.Scan(&zone.ID, &zone.Name, &zone.Kind, &zone.RadiusKm, &polygon, &zone.DeliveryFee,
+			&zone.MinOrder, &zone.SortOrder, &zone.IsActive, &zone.CreatedAt); err != nil {
+			return nil, err
+		}
+		if polygon != "" {
+			if err := json.Unmarshal([]byte(polygon), &zone.Polygon); err != nil {
+				return nil, err
+			}
+		}
+		zones = append(zones, zone)
+	}
+	return zones, rows.Err()
+}
+
+// validateDeliveryZone confere os campos da zona conforme o tipo
+func validateDeliveryZone(zone *models.DeliveryZone) error {
+	zone.Name = strings.TrimSpace(zone.Name)
+	if zone.Name == "" {
+		return errors.New("Nome da zona é obrigatório")
+	}
+	if zone.DeliveryFee < 0 || zone.MinOrder < 0 {
+		return errors.New("Taxa de entrega e pedido mínimo não podem ser negativos")
+	}
+	switch zone.Kind {
+	case deliveryZoneRadius:
+		if zone.RadiusKm <= 0 {
+			return errors.New("Raio da zona deve ser maior que zero")
+		}
+		zone.Polygon = nil
+	case deliveryZonePolygon:
+		if len(zone.Polygon) < 3 {
+			return errors.New("O polígono da zona precisa de pelo menos 3 pontos")
+		}
+		for _, point := range zone.Polygon {
+			if !validGeoPoint(point) {
+				return errors.New("Coordenadas do polígono inválidas")
+			}
+		}
+		zone.RadiusKm = 0
+	default:
+		return errors.New("Tipo de zona inválido (use radius ou polygon)")
+	}
+	return nil
+}
+
+// matchDeliveryZone escolhe a primeira zona (em ordem de prioridade) que atende o ponto
+// Zonas por raio são ignoradas quando a loja não tem coordenadas configuradas
+func matchDeliveryZone(zones []models.DeliveryZone, point models.GeoPoint, store *models.GeoPoint) (models.DeliveryZone, bool) {
+	for _, zone := range zones {
+		switch zone.Kind {
+		case deliveryZoneRadius:
+			if store != nil && haversineKm(*store, point) <= zone.RadiusKm {
+				return zone, true
+			}
+		case deliveryZonePolygon:
+			if pointInPolygon(point, zone.Polygon) {
+				return zone, true
+			}
+		}
+	}
+	return models.DeliveryZone{}, false
+}
+
+// quoteDeliveryPoint calcula zona, taxa e pedido mínimo para o ponto
+func quoteDeliveryPoint(q queryer, point models.GeoPoint) (models.DeliveryQuote, error) {
+	zones, err := loadDeliveryZones(q, true)
+	if err != nil {
+		return models.DeliveryQuote{}, err
+	}
+
+	var store *models.GeoPoint
+	if latitude, longitude, ok := config.StoreCoordinates(); ok {
+		store = &models.GeoPoint{Latitude: latitude, Longitude: longitude}
+	}
+	zone, ok := matchDeliveryZone(zones, point, store)
+	if !ok {
+		return models.DeliveryQuote{}, errOutsideDeliveryArea
+	}
+
+	quote := models.DeliveryQuote{
+		ZoneID:      zone.ID,
+		ZoneName:    zone.Name,
+		DeliveryFee: zone.DeliveryFee,
+		MinOrder:    zone.MinOrder,
+	}
+	if store != nil {
+		quote.DistanceKm = math.Round(haversineKm(*store, point)*10) / 10
+	}
+	return quote, nil
+}
+
+// resolveOrderDelivery resolve o destino e a taxa de um pedido de entrega
+// Com endereço salvo, completa o texto do endereço e o telefone a partir do cadastro
+func resolveOrderDelivery(q queryer, req *models.CreateOrderRequest) (orderDelivery, error) {
+	var delivery orderDelivery
+
+	if req.DeliveryAddressID > 0 {
+		address, err := loadCustomerAddress(q, req.DeliveryAddressID)
+		if err != nil {
+			return delivery, err
+		}
+		// Um endereço de outro cliente é tratado como inexistente
+		if req.CustomerPhone != "" && req.CustomerPhone != address.CustomerPhone {
+			return delivery, errAddressNotFound
+		}
+		req.CustomerPhone = address.CustomerPhone
+		req.DeliveryAddress = formatAddress(address)
+		delivery.AddressID = &address.ID
+		delivery.Point = models.GeoPoint{Latitude: address.Latitude, Longitude: address.Longitude}
+	} else {
+		if req.DeliveryLatitude == nil || req.DeliveryLongitude == nil {
+			return delivery, errAddressWithoutLocation
+		}
+		delivery.Point = models.GeoPoint{Latitude: *req.DeliveryLatitude, Longitude: *req.DeliveryLongitude}
+		if !validGeoPoint(delivery.Point) {
+			return delivery, errAddressWithoutLocation
+		}
+	}
+
+	quote, err := quoteDeliveryPoint(q, delivery.Point)
+	delivery.Quote = quote
+	return delivery, err
+}
+
+// respondDeliveryError converte os erros de entrega na resposta HTTP adequada
+func respondDeliveryError(c *gin.Context, err error) {
+	switch err {
+	case errAddressNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errAddressWithoutLocation, errOutsideDeliveryArea:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao calcular entrega"})
+	}
+}
+
+// deliveryCoordinate grava a coordenada só em pedidos de entrega (NULL nos demais)
+func deliveryCoordinate(orderType string, value float64) *float64 {
+	if orderType != orderTypeDelivery {
+		return nil
+	}
+	return &value
+}
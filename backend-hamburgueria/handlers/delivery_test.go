@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"backend-hamburgueria/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// Teste da distância entre dois pontos (Praça da Sé → MASP, ~2,6 km)
+func TestHaversineKm(t *testing.T) {
+	se := models.GeoPoint{Latitude: -23.5503, Longitude: -46.6339}
+	masp := models.GeoPoint{Latitude: -23.5614, Longitude: -46.6559}
+	assert.InDelta(t, 2.58, haversineKm(se, masp), 0.1)
+	assert.Equal(t, 0.0, haversineKm(se, se))
+}
+
+// Teste do ponto dentro/fora de um polígono
+func TestPointInPolygon(t *testing.T) {
+	square := []models.GeoPoint{
+		{Latitude: 0, Longitude: 0},
+		{Latitude: 0, Longitude: 1},
+		{Latitude: 1, Longitude: 1},
+		{Latitude: 1, Longitude: 0},
+	}
+	assert.True(t, pointInPolygon(models.GeoPoint{Latitude: 0.5, Longitude: 0.5}, square))
+	assert.False(t, pointInPolygon(models.GeoPoint{Latitude: 1.5, Longitude: 0.5}, square))
+	assert.False(t, pointInPolygon(models.GeoPoint{Latitude: 0.5, Longitude: -0.1}, square))
+}
+
+// Teste da escolha da zona: a primeira na ordem de prioridade que contém o ponto
+func TestMatchDeliveryZone(t *testing.T) {
+	store := &models.GeoPoint{Latitude: 0, Longitude: 0}
+	zones := []models.DeliveryZone{
+		{ID: 1, Kind: deliveryZoneRadius, RadiusKm: 3},
+		{ID: 2, Kind: deliveryZonePolygon, Polygon: []models.GeoPoint{
+			{Latitude: 0, Longitude: 0}, {Latitude: 0, Longitude: 0.1}, {Latitude: 0.1, Longitude: 0.1}, {Latitude: 0.1, Longitude: 0},
+		}},
+	}
+
+	// ~1,1 km da loja: zona de raio
+	zone, ok := matchDeliveryZone(zones, models.GeoPoint{Latitude: 0.01, Longitude: 0}, store)
+	assert.True(t, ok)
+	assert.Equal(t, 1, zone.ID)
+
+	// ~7 km, mas dentro do polígono
+	zone, ok = matchDeliveryZone(zones, models.GeoPoint{Latitude: 0.05, Longitude: 0.05}, store)
+	assert.True(t, ok)
+	assert.Equal(t, 2, zone.ID)
+
+	// Sem coordenadas da loja, zonas de raio não atendem
+	_, ok = matchDeliveryZone(zones[:1], models.GeoPoint{Latitude: 0.01, Longitude: 0}, nil)
+	assert.False(t, ok)
+
+	// Fora de tudo
+	_, ok = matchDeliveryZone(zones, models.GeoPoint{Latitude: 1, Longitude: 1}, store)
+	assert.False(t, ok)
+}
+
+// Teste da validação de zonas
+func TestValidateDeliveryZone(t *testing.T) {
+	assert.NoError(t, validateDeliveryZone(&models.DeliveryZone{Name: "Centro", Kind: deliveryZoneRadius, RadiusKm: 2}))
+	assert.Error(t, validateDeliveryZone(&models.DeliveryZone{Name: "Centro", Kind: deliveryZoneRadius}))
+	assert.Error(t, validateDeliveryZone(&models.DeliveryZone{Name: "Centro", Kind: deliveryZonePolygon, Polygon: []models.GeoPoint{{}, {}}}))
+	assert.Error(t, validateDeliveryZone(&models.DeliveryZone{Name: "Centro", Kind: "circle", RadiusKm: 2}))
+	assert.Error(t, validateDeliveryZone(&models.DeliveryZone{Name: "Centro", Kind: deliveryZoneRadius, RadiusKm: 2, DeliveryFee: -1}))
+}
+
+// Teste para QuoteDelivery sem coordenadas e com falha ao buscar zonas
+func TestQuoteDelivery(t *testing.T) {
+	router, mockDB := setupTest()
+
+	router.GET("/delivery/quote", func(c *gin.Context) {
+		QuoteDelivery(c, mockDB)
+	})
+
+	req, _ := http.NewRequest("GET", "/delivery/quote", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	mockDB.QueryFunc = func(query string, args ...interface{}) (*sql.Rows, error) {
+		return nil, sql.ErrConnDone
+	}
+	req, _ = http.NewRequest("GET", "/delivery/quote?latitude=-23.55&longitude=-46.63", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+// Teste para CreateCustomerAddress sem coordenadas
+func TestCreateCustomerAddressWithoutLocation(t *testing.T) {
+	router, mockDB := setupTest()
+
+	router.POST("/addresses", func(c *gin.Context) {
+		CreateCustomerAddress(c, mockDB)
+	})
+
+	body := `{"customer_phone": "11987654321", "street": "Rua A", "number": "10"}`
+	req, _ := http.NewRequest("POST", "/addresses", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,768 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	// Configurações da aplicação (localização da loja e velocidade média)
+	"backend-hamburgueria/config"
+	// Modelos de dados da aplicação
+	"backend-hamburgueria/models"
+
+	// Framework web Gin
+	"github.com/gin-gonic/gin"
+)
+
+// ===== ENTREGADORES E DESPACHO =====
+
+// Status do entregador
+const (
+	driverOffline    = "offline"     // Fora de serviço
+	driverAvailable  = "available"   // Aguardando pedidos
+	driverOnDelivery = "on_delivery" // Com pedidos em rota
+)
+
+// Andamento da entrega de um pedido
+const (
+	deliveryAssigned  = "assigned"  // Entregador atribuído, aguardando retirada
+	deliveryPickedUp  = "picked_up" // Saiu da loja
+	deliveryDelivered = "delivered" // Entregue ao cliente
+)
+
+// roadFactor converte a distância em linha reta na distância aproximada pelas ruas
+const roadFactor = 1.3
+
+// errOrderNotDelivery indica que o pedido consultado não é de entrega
+var errOrderNotDelivery = errors.New("Pedido não é de entrega")
+
+// driverCandidate é um entregador disponível considerado no despacho automático
+type driverCandidate struct {
+	ID       int
+	Name     string
+	Position models.GeoPoint
+}
+
+// GetDrivers godoc
+// @Summary      Lista os entregadores
+// @Description  Retorna os entregadores ativos com status e última posição (filtro opcional ?status=)
+// @Tags         Drivers
+// @Produce      json
+// @Param        status  query     string  false  "offline, available ou on_delivery"
+// @Success      200     {array}   models.Driver
+// @Failure      500     {object}  models.ErrorResponse "Erro ao buscar entregadores"
+// @Router       /api/drivers [get]
+func GetDrivers(c *gin.Context, db DBInterface) {
+	status := c.Query("status")
+
+	rows, err := db.Query(`
+		SELECT `+driverColumns+`
+		FROM drivers
+		WHERE is_active AND ($1 = '' OR status = $1)
+		ORDER BY name
+	`, status)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar entregadores"})
+		return
+	}
+	defer rows.Close()
+
+	drivers := []models.Driver{}
+	for rows.Next() {
+		var driver models.Driver
+		if err := scanDriver(rows, &driver); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao ler entregador"})
+			return
+		}
+		drivers = append(drivers, driver)
+	}
+
+	c.JSON(http.StatusOK, drivers)
+}
+
+// CreateDriver godoc
+// @Summary      Cadastra um entregador
+// @Description  Cadastra um entregador (começa offline até ficar disponível)
+// @Tags         Drivers
+// @Accept       json
+// @Produce      json
+// @Param        body  body      models.Driver  true  "Entregador"
+// @Success      201   {object}  models.Driver
+// @Failure      400   {object}  models.ErrorResponse "Dados inválidos"
+// @Failure      500   {object}  models.ErrorResponse "Erro ao cadastrar entregador"
+// @Router       /api/drivers [post]
+func CreateDriver(c *gin.Context, db DBInterface) {
+	// ===== VALIDAR DADOS DE ENTRADA =====
+	var driver models.Driver
+	if err := c.ShouldBindJSON(&driver); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos"})
+		return
+	}
+	driver.Name = strings.TrimSpace(driver.Name)
+	driver.Phone = normalizePhone(driver.Phone)
+	if driver.Name == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Nome do entregador é obrigatório"})
+		return
+	}
+	if len(driver.Phone) < 10 || len(driver.Phone) > 13 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Telefone inválido, informe DDD e número"})
+		return
+	}
+
+	// ===== INSERIR ENTREGADOR =====
+	err := scanDriver(db.QueryRow(`
+		INSERT INTO drivers (name, phone, vehicle, status)
+		VALUES ($1, $2, $3, $4)
+		RETURNING `+driverColumns, driver.Name, driver.Phone, driver.Vehicle, driverOffline), &driver)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao cadastrar entregador"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, driver)
+}
+
+// UpdateDriverStatus godoc
+// @Summary      Altera a disponibilidade do entregador
+// @Description  Coloca o entregador como disponível ou offline (não vale durante uma entrega)
+// @Tags         Drivers
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                          true  "ID do entregador"
+// @Param        body  body      models.DriverStatusRequest  true  "Novo status"
+// @Success      200   {object}  models.StatusResponse
+// @Failure      400   {object}  models.ErrorResponse "Status inválido"
+// @Failure      404   {object}  models.ErrorResponse "Entregador não encontrado"
+// @Failure      409   {object}  models.ErrorResponse "Entregador em entrega"
+// @Router       /api/drivers/{id}/status [put]
+func UpdateDriverStatus(c *gin.Context, db DBInterface) {
+	// ===== VALIDAR ENTRADA =====
+	driverID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do entregador inválido"})
+		return
+	}
+	var req models.DriverStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos"})
+		return
+	}
+	if req.Status != driverAvailable && req.Status != driverOffline {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Status inválido (use available ou offline)"})
+		return
+	}
+
+	// ===== ATUALIZAR STATUS =====
+	// Quem está em rota só volta a ficar disponível ao entregar o último pedido
+	var current string
+	err = db.QueryRow(`
+		WITH target AS (SELECT id, status FROM drivers WHERE id = $2 AND is_active),
+		updated AS (
+			UPDATE drivers SET status = $1
+			WHERE id = $2 AND is_active AND status <> $3
+			RETURNING id
+		)
+		SELECT status FROM target
+	`, req.Status, driverID, driverOnDelivery).Scan(&current)
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Entregador não encontrado"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao atualizar entregador"})
+		return
+	}
+	if current == driverOnDelivery {
+		c.JSON(http.StatusConflict, gin.H{"error": "Entregador em entrega, finalize os pedidos antes"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Status do entregador atualizado"})
+}
+
+// UpdateDriverPosition godoc
+// @Summary      Envia a posição do entregador
+// @Description  Atualiza a posição do entregador e notifica o rastreamento dos pedidos em rota
+// @Tags         Drivers
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                            true  "ID do entregador"
+// @Param        body  body      models.DriverPositionRequest  true  "Posição atual"
+// @Success      200   {object}  models.StatusResponse
+// @Failure      400   {object}  models.ErrorResponse "Coordenadas inválidas"
+// @Failure      404   {object}  models.ErrorResponse "Entregador não encontrado"
+// @Router       /api/drivers/{id}/position [post]
+func UpdateDriverPosition(c *gin.Context, db DBInterface) {
+	// ===== VALIDAR ENTRADA =====
+	driverID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do entregador inválido"})
+		return
+	}
+	var req models.DriverPositionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos"})
+		return
+	}
+	point := models.GeoPoint{Latitude: req.Latitude, Longitude: req.Longitude}
+	if (req.Latitude == 0 && req.Longitude == 0) || !validGeoPoint(point) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Coordenadas inválidas"})
+		return
+	}
+
+	// ===== GRAVAR POSIÇÃO =====
+	err = db.QueryRow(`
+		UPDATE drivers SET latitude = $1, longitude = $2, last_seen_at = CURRENT_TIMESTAMP
+		WHERE id = $3 AND is_active
+		RETURNING id
+	`, req.Latitude, req.Longitude, driverID).Scan(&driverID)
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Entregador não encontrado"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao atualizar posição"})
+		return
+	}
+	// Trilha de posições (histórico do trajeto)
+	if _, err := db.Exec(`
+		INSERT INTO driver_positions (driver_id, latitude, longitude) VALUES ($1, $2, $3)
+	`, driverID, req.Latitude, req.Longitude); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao atualizar posição"})
+		return
+	}
+
+	// ===== NOTIFICAR RASTREAMENTO =====
+	orderIDs, err := activeDriverOrders(db, driverID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar pedidos do entregador"})
+		return
+	}
+	for _, orderID := range orderIDs {
+		orderEvents.Publish(orderEvent{Type: eventDriverMoved, OrderID: orderID})
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Posição atualizada", "active_orders": orderIDs})
+}
+
+// DispatchOrder godoc
+// @Summary      Atribui um pedido de entrega a um entregador
+// @Description  Atribui um pedido pronto ao entregador informado ou, sem driver_id, ao disponível mais próximo da loja
+// @Tags         Drivers
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                           true   "ID do pedido"
+// @Param        body  body      models.DispatchOrderRequest  false  "Entregador (opcional)"
+// @Success      200   {object}  models.Driver
+// @Failure      400   {object}  models.ErrorResponse "Pedido não é de entrega"
+// @Failure      404   {object}  models.ErrorResponse "Pedido não encontrado"
+// @Failure      409   {object}  models.ErrorResponse "Nenhum entregador disponível"
+// @Router       /api/orders/{id}/dispatch [post]
+func DispatchOrder(c *gin.Context, db DBInterface) {
+	// ===== VALIDAR ENTRADA =====
+	orderID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do pedido inválido"})
+		return
+	}
+	// Corpo opcional: sem ele o despacho é automático
+	var req models.DispatchOrderRequest
+	if c.Request.Body != nil && c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil && err != io.EOF {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos"})
+			return
+		}
+	}
+	if req.DriverID < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do entregador inválido"})
+		return
+	}
+
+	// ===== INICIAR TRANSAÇÃO =====
+	tx, err := db.Begin()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao iniciar transação"})
+		return
+	}
+	defer tx.Rollback()
+
+	// ===== CONFERIR PEDIDO =====
+	var orderType, status, deliveryStatus string
+	var destLat, destLng *float64
+	err = tx.QueryRow(`
+		SELECT order_type, status, COALESCE(delivery_status, ''), delivery_latitude, delivery_longitude
+		FROM orders WHERE id = $1
+		FOR UPDATE
+	`, orderID).Scan(&orderType, &status, &deliveryStatus, &destLat, &destLng)
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Pedido não encontrado"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar pedido"})
+		return
+	}
+	if orderType != orderTypeDelivery {
+		c.JSON(http.StatusBadRequest, gin.H{"error": errOrderNotDelivery.Error()})
+		return
+	}
+	if deliveryStatus != "" {
+		c.JSON(http.StatusConflict, gin.H{"error": "Pedido já atribuído a um entregador"})
+		return
+	}
+	if status != "ready" {
+		c.JSON(http.StatusConflict, gin.H{"error": "Pedido ainda não está pronto para entrega"})
+		return
+	}
+
+	// ===== ESCOLHER ENTREGADOR =====
+	var driver models.Driver
+	if req.DriverID > 0 {
+		// Escolha manual: o entregador pode levar mais de um pedido na mesma rota
+		err = scanDriver(tx.QueryRow(`SELECT `+driverColumns+` FROM drivers WHERE id = $1 AND is_active FOR UPDATE`, req.DriverID), &driver)
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Entregador não encontrado"})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar entregador"})
+			return
+		}
+		if driver.Status == driverOffline {
+			c.JSON(http.StatusConflict, gin.H{"error": "Entregador está offline"})
+			return
+		}
+	} else {
+		// Automático: o disponível mais próximo da loja (ou do destino, sem coordenadas da loja)
+		candidates, err := loadAvailableDrivers(tx)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar entregadores"})
+			return
+		}
+		var origin *models.GeoPoint
+		if latitude, longitude, ok := config.StoreCoordinates(); ok {
+			origin = &models.GeoPoint{Latitude: latitude, Longitude: longitude}
+		} else if destLat != nil && destLng != nil {
+			origin = &models.GeoPoint{Latitude: *destLat, Longitude: *destLng}
+		}
+		nearest, ok := selectNearestDriver(candidates, origin)
+		if !ok {
+			c.JSON(http.StatusConflict, gin.H{"error": "Nenhum entregador disponível"})
+			return
+		}
+		err = scanDriver(tx.QueryRow(`SELECT `+driverColumns+` FROM drivers WHERE id = $1`, nearest.ID), &driver)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar entregador"})
+			return
+		}
+	}
+
+	// ===== ATRIBUIR =====
+	if _, err := tx.Exec(`
+		UPDATE orders SET driver_id = $1, delivery_status = $2, assigned_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $3
+	`, driver.ID, deliveryAssigned, orderID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao atribuir entregador"})
+		return
+	}
+	if _, err := tx.Exec(`UPDATE drivers SET status = $1 WHERE id = $2`, driverOnDelivery, driver.ID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao atribuir entregador"})
+		return
+	}
+	driver.Status = driverOnDelivery
+	if err := recordOrderEvent(tx, orderID, orderEventDispatch, status, "entregador #"+strconv.Itoa(driver.ID)+" "+driver.Name); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao registrar histórico do pedido"})
+		return
+	}
+
+	// ===== COMMIT DA TRANSAÇÃO =====
+	if err := tx.Commit(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao atribuir entregador"})
+		return
+	}
+	orderEvents.Publish(orderEvent{Type: eventOrderDispatch, OrderID: orderID, Status: deliveryAssigned})
+
+	c.JSON(http.StatusOK, driver)
+}
+
+// UpdateDeliveryStatus godoc
+// @Summary      Atualiza o andamento da entrega
+// @Description  Marca o pedido como retirado pelo entregador (picked_up) ou entregue (delivered)
+// @Tags         Drivers
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                            true  "ID do pedido"
+// @Param        body  body      models.DeliveryStatusRequest  true  "Andamento da entrega"
+// @Success      200   {object}  models.StatusResponse
+// @Failure      400   {object}  models.ErrorResponse "Status inválido"
+// @Failure      404   {object}  models.ErrorResponse "Pedido não encontrado"
+// @Failure      409   {object}  models.ErrorResponse "Transição inválida"
+// @Router       /api/orders/{id}/delivery-status [put]
+func UpdateDeliveryStatus(c *gin.Context, db DBInterface) {
+	// ===== VALIDAR ENTRADA =====
+	orderID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do pedido inválido"})
+		return
+	}
+	var req models.DeliveryStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos"})
+		return
+	}
+	if req.Status != deliveryPickedUp && req.Status != deliveryDelivered {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Status inválido (use picked_up ou delivered)"})
+		return
+	}
+
+	// ===== INICIAR TRANSAÇÃO =====
+	tx, err := db.Begin()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao iniciar transação"})
+		return
+	}
+	defer tx.Rollback()
+
+	var deliveryStatus, status string
+	var driverID *int
+	err = tx.QueryRow(`
+		SELECT COALESCE(delivery_status, ''), status, driver_id FROM orders WHERE id = $1 FOR UPDATE
+	`, orderID).Scan(&deliveryStatus, &status, &driverID)
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Pedido não encontrado"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar pedido"})
+		return
+	}
+	if !validDeliveryTransition(deliveryStatus, req.Status) {
+		c.JSON(http.StatusConflict, gin.H{"error": "Transição de entrega inválida: " + deliveryStatusLabel(deliveryStatus) + " → " + req.Status})
+		return
+	}
+
+	// ===== ATUALIZAR ENTREGA =====
+	if req.Status == deliveryPickedUp {
+		_, err = tx.Exec(`
+			UPDATE orders SET delivery_status = $1, picked_up_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+			WHERE id = $2
+		`, req.Status, orderID)
+	} else {
+		status = "delivered"
+		_, err = tx.Exec(`
+			UPDATE orders SET delivery_status = $1, status = $2, delivered_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+			WHERE id = $3
+		`, req.Status, status, orderID)
+		if err == nil && driverID != nil {
+			// Último pedido da rota entregue: o entregador volta a ficar disponível
+			_, err = tx.Exec(`
+				UPDATE drivers SET status = $1
+				WHERE id = $2 AND status = $3
+				AND NOT EXISTS (
+					SELECT 1 FROM orders WHERE driver_id = $2 AND delivery_status IN ($4, $5)
+				)
+			`, driverAvailable, *driverID, driverOnDelivery, deliveryAssigned, deliveryPickedUp)
+		}
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao atualizar entrega"})
+		return
+	}
+	if err := recordOrderEvent(tx, orderID, orderEventDelivery, status, req.Status); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao registrar histórico do pedido"})
+		return
+	}
+
+	// ===== COMMIT DA TRANSAÇÃO =====
+	if err := tx.Commit(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao atualizar entrega"})
+		return
+	}
+	orderEvents.Publish(orderEvent{Type: eventOrderDispatch, OrderID: orderID, Status: req.Status})
+	if req.Status == deliveryDelivered {
+		orderEvents.Publish(orderEvent{Type: eventOrderStatus, OrderID: orderID, Status: status})
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Entrega atualizada", "delivery_status": req.Status})
+}
+
+// GetOrderTracking godoc
+// @Summary      Rastreamento da entrega
+// @Description  Retorna o entregador, a distância restante e a previsão de chegada do pedido
+// @Tags         Drivers
+// @Produce      json
+// @Param        id   path      int  true  "ID do pedido"
+// @Success      200  {object}  models.OrderTracking
+// @Failure      400  {object}  models.ErrorResponse "Pedido não é de entrega"
+// @Failure      404  {object}  models.ErrorResponse "Pedido não encontrado"
+// @Router       /api/orders/{id}/tracking [get]
+func GetOrderTracking(c *gin.Context, db DBInterface) {
+	orderID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do pedido inválido"})
+		return
+	}
+
+	tracking, err := loadOrderTracking(db, orderID, time.Now())
+	if err != nil {
+		respondTrackingError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, tracking)
+}
+
+// StreamOrderTracking godoc
+// @Summary      Stream do rastreamento da entrega
+// @Description  Stream SSE que envia o rastreamento (evento "tracking") ao conectar e a cada mudança; encerra após a entrega
+// @Tags         Drivers
+// @Produce      text/event-stream
+// @Param        id   path      int  true  "ID do pedido"
+// @Success      200  {object}  models.OrderTracking
+// @Router       /api/orders/{id}/tracking/stream [get]
+func StreamOrderTracking(c *gin.Context, db DBInterface) {
+	orderID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do pedido inválido"})
+		return
+	}
+
+	// Assinar antes da primeira consulta para não perder mudanças no meio do caminho
+	events := orderEvents.Subscribe()
+	defer orderEvents.Unsubscribe(events)
+
+	// Primeira consulta fora do stream: pedido inexistente responde 404 normal
+	tracking, err := loadOrderTracking(db, orderID, time.Now())
+	if err != nil {
+		respondTrackingError(c, err)
+		return
+	}
+
+	ticker := time.NewTicker(boardRefreshInterval)
+	defer ticker.Stop()
+
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no") // Desliga o buffer do nginx
+
+	first := true
+	c.Stream(func(w io.Writer) bool {
+		if !first {
+			select {
+			case <-c.Request.Context().Done():
+				return false
+			case event := <-events:
+				if event.OrderID != orderID {
+					return true
+				}
+			case <-ticker.C:
+			}
+			tracking, err = loadOrderTracking(db, orderID, time.Now())
+			if err != nil {
+				c.SSEvent("error", gin.H{"error": "Erro ao calcular rastreamento"})
+				return true
+			}
+		}
+		first = false
+
+		c.SSEvent("tracking", tracking)
+		// Entregue: último evento, encerra o stream
+		return tracking.DeliveryStatus != deliveryDelivered
+	})
+}
+
+// ===== FUNÇÕES AUXILIARES =====
+
+// driverColumns lista as colunas de drivers na ordem lida por scanDriver
+const driverColumns = `id, name, phone, vehicle, status, latitude, longitude, last_seen_at::timestamptz, is_active, created_at`
+
+// scanDriver lê uma linha de drivers selecionada com driverColumns
+func scanDriver(row rowScanner, driver *models.Driver) error {
+	return row.Scan(&driver.ID, &driver.Name, &driver.Phone, &driver.Vehicle, &driver.Status,
+		&driver.Latitude, &driver.Longitude, &driver.LastSeenAt, &driver.IsActive, &driver.CreatedAt)
+}
+
+// loadAvailableDrivers busca os entregadores disponíveis com posição conhecida
+// Trava as linhas para que dois despachos simultâneos não escolham o mesmo entregador
+func loadAvailableDrivers(q queryer) ([]driverCandidate, error) {
+	rows, err := q.Query(`
+		SELECT id, name, latitude, longitude
+		FROM drivers
+		WHERE is_active AND status = $1 AND latitude IS NOT NULL AND longitude IS NOT NULL
+		FOR UPDATE SKIP LOCKED
+	`, driverAvailable)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var candidates []driverCandidate
+	for rows.Next() {
+		var candidate driverCandidate
+		if err := rows.Scan(&candidate.ID, &candidate.Name, &candidate.Position.Latitude, &candidate.Position.Longitude); err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, candidate)
+	}
+	return candidates, rows.Err()
+}
+
+// selectNearestDriver escolhe o candidato mais próximo da origem
+// Sem origem conhecida, fica com o primeiro disponível
+func selectNearestDriver(candidates []driverCandidate, origin *models.GeoPoint) (driverCandidate, bool) {
+	if len(candidates) == 0 {
+		return driverCandidate{}, false
+	}
+	if origin == nil {
+		return candidates[0], true
+	}
+	best := candidates[0]
+	bestDistance := haversineKm(*origin, best.Position)
+	for _, candidate := range candidates[1:] {
+		if distance := haversineKm(*origin, candidate.Position); distance < bestDistance {
+			best, bestDistance = candidate, distance
+		}
+	}
+	return best, true
+}
+
+// activeDriverOrders lista os pedidos em rota do entregador
+func activeDriverOrders(q queryer, driverID int) ([]int, error) {
+	rows, err := q.Query(`
+		SELECT id FROM orders WHERE driver_id = $1 AND delivery_status IN ($2, $3) ORDER BY id
+	`, driverID, deliveryAssigned, deliveryPickedUp)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	orderIDs := []int{}
+	for rows.Next() {
+		var orderID int
+		if err := rows.Scan(&orderID); err != nil {
+			return nil, err
+		}
+		orderIDs = append(orderIDs, orderID)
+	}
+	return orderIDs, rows.Err()
+}
+
+// validDeliveryTransition confere o andamento da entrega: assigned → picked_up → delivered
+// (entregar direto de assigned é permitido quando o entregador esquece de marcar a retirada)
+func validDeliveryTransition(current, next string) bool {
+	switch next {
+	case deliveryPickedUp:
+		return current == deliveryAssigned
+	case deliveryDelivered:
+		return current == deliveryAssigned || current == deliveryPickedUp
+	}
+	return false
+}
+
+// deliveryStatusLabel descreve o andamento atual nas mensagens de erro
+func deliveryStatusLabel(status string) string {
+	if status == "" {
+		return "sem entregador"
+	}
+	return status
+}
+
+// loadOrderTracking monta o rastreamento do pedido com distância e previsão de chegada
+func loadOrderTracking(q queryer, orderID int, now time.Time) (models.OrderTracking, error) {
+	tracking := models.OrderTracking{OrderID: orderID, UpdatedAt: now}
+
+	var orderType string
+	var destLat, destLng *float64
+	var driverID *int
+	var driverName, vehicle *string
+	var driverLat, driverLng *float64
+	var lastSeen *time.Time
+	err := q.QueryRow(`
+		SELECT o.order_type, o.status, COALESCE(o.delivery_status, ''), o.delivery_latitude, o.delivery_longitude,
+		       d.id, d.name, d.vehicle, d.latitude, d.longitude, d.last_seen_at::timestamptz
+		FROM orders o
+		LEFT JOIN drivers d ON d.id = o.driver_id
+		WHERE o.id = $1
+	`, orderID).Scan(&orderType, &tracking.Status, &tracking.DeliveryStatus, &destLat, &destLng,
+		&driverID, &driverName, &vehicle, &driverLat, &driverLng, &lastSeen)
+	if err != nil {
+		return tracking, err
+	}
+	if orderType != orderTypeDelivery {
+		return tracking, errOrderNotDelivery
+	}
+
+	if destLat != nil && destLng != nil {
+		tracking.Destination = &models.GeoPoint{Latitude: *destLat, Longitude: *destLng}
+	}
+	if driverID != nil {
+		tracking.Driver = &models.TrackedDriver{
+			ID:         *driverID,
+			Name:       *driverName,
+			Vehicle:    *vehicle,
+			Latitude:   driverLat,
+			Longitude:  driverLng,
+			LastSeenAt: lastSeen,
+		}
+	}
+
+	var store *models.GeoPoint
+	if latitude, longitude, ok := config.StoreCoordinates(); ok {
+		store = &models.GeoPoint{Latitude: latitude, Longitude: longitude}
+	}
+	estimateTrackingArrival(&tracking, store, config.DriverAverageSpeedKmh(), now)
+	return tracking, nil
+}
+
+// estimateTrackingArrival calcula a distância restante e a previsão de chegada
+// Antes da retirada o trajeto passa pela loja; depois vai direto ao destino.
+// Sem posição do entregador, usa a distância da loja até o destino.
+func estimateTrackingArrival(tracking *models.OrderTracking, store *models.GeoPoint, speedKmh float64, now time.Time) {
+	tracking.DistanceKm = 0
+	tracking.EtaMinutes = 0
+	tracking.EstimatedArrivalAt = nil
+	if tracking.DeliveryStatus == deliveryDelivered || tracking.Destination == nil {
+		return
+	}
+
+	var driver *models.GeoPoint
+	if tracking.Driver != nil && tracking.Driver.Latitude != nil && tracking.Driver.Longitude != nil {
+		driver = &models.GeoPoint{Latitude: *tracking.Driver.Latitude, Longitude: *tracking.Driver.Longitude}
+	}
+
+	var distance float64
+	switch {
+	case driver != nil && tracking.DeliveryStatus == deliveryPickedUp:
+		distance = haversineKm(*driver, *tracking.Destination)
+	case driver != nil && store != nil:
+		distance = haversineKm(*driver, *store) + haversineKm(*store, *tracking.Destination)
+	case driver != nil:
+		distance = haversineKm(*driver, *tracking.Destination)
+	case store != nil:
+		distance = haversineKm(*store, *tracking.Destination)
+	default:
+		return
+	}
+
+	distance *= roadFactor
+	tracking.DistanceKm = math.Round(distance*10) / 10
+	tracking.EtaMinutes = int(math.Ceil(distance / speedKmh * 60))
+	arrival := now.Add(time.Duration(tracking.EtaMinutes) * time.Minute)
+	tracking.EstimatedArrivalAt = &arrival
+}
+
+// respondTrackingError converte os erros do rastreamento na resposta HTTP adequada
+func respondTrackingError(c *gin.Context, err error) {
+	switch err {
+	case sql.ErrNoRows:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Pedido não encontrado"})
+	case errOrderNotDelivery:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao calcular rastreamento"})
+	}
+}
@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"backend-hamburgueria/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// Teste do despacho automático: o entregador mais próximo da origem
+func TestSelectNearestDriver(t *testing.T) {
+	origin := &models.GeoPoint{Latitude: 0, Longitude: 0}
+	candidates := []driverCandidate{
+		{ID: 1, Position: models.GeoPoint{Latitude: 0.05, Longitude: 0}},
+		{ID: 2, Position: models.GeoPoint{Latitude: 0.01, Longitude: 0.01}},
+		{ID: 3, Position: models.GeoPoint{Latitude: -0.03, Longitude: 0}},
+	}
+
+	driver, ok := selectNearestDriver(candidates, origin)
+	assert.True(t, ok)
+	assert.Equal(t, 2, driver.ID)
+
+	// Sem origem conhecida, o primeiro disponível
+	driver, ok = selectNearestDriver(candidates, nil)
+	assert.True(t, ok)
+	assert.Equal(t, 1, driver.ID)
+
+	_, ok = selectNearestDriver(nil, origin)
+	assert.False(t, ok)
+}
+
+// Teste das transições de entrega
+func TestValidDeliveryTransition(t *testing.T) {
+	assert.True(t, validDeliveryTransition(deliveryAssigned, deliveryPickedUp))
+	assert.True(t, validDeliveryTransition(deliveryPickedUp, deliveryDelivered))
+	assert.True(t, validDeliveryTransition(deliveryAssigned, deliveryDelivered))
+	assert.False(t, validDeliveryTransition("", deliveryPickedUp))
+	assert.False(t, validDeliveryTransition(deliveryDelivered, deliveryPickedUp))
+}
+
+// Teste da previsão de chegada: antes da retirada o trajeto passa pela loja
+func TestEstimateTrackingArrival(t *testing.T) {
+	now := time.Date(2026, 10, 19, 20, 0, 0, 0, time.UTC)
+	store := &models.GeoPoint{Latitude: 0, Longitude: 0}
+	driverLat, driverLng := 0.0, 0.0
+	tracking := models.OrderTracking{
+		DeliveryStatus: deliveryAssigned,
+		Destination:    &models.GeoPoint{Latitude: 0.0449, Longitude: 0}, // ~5 km da loja
+		Driver:         &models.TrackedDriver{Latitude: &driverLat, Longitude: &driverLng},
+	}
+
+	// Entregador na loja: 5 km * 1,3 = 6,5 km a 26 km/h = 15 min
+	estimateTrackingArrival(&tracking, store, 26, now)
+	assert.InDelta(t, 6.5, tracking.DistanceKm, 0.1)
+	assert.Equal(t, 15, tracking.EtaMinutes)
+	assert.Equal(t, now.Add(15*time.Minute), *tracking.EstimatedArrivalAt)
+
+	// Em rota, na metade do caminho
+	driverLat = 0.02245
+	tracking.DeliveryStatus = deliveryPickedUp
+	estimateTrackingArrival(&tracking, store, 26, now)
+	assert.Equal(t, 8, tracking.EtaMinutes)
+
+	// Entregue: nada a estimar
+	tracking.DeliveryStatus = deliveryDelivered
+	estimateTrackingArrival(&tracking, store, 26, now)
+	assert.Equal(t, 0, tracking.EtaMinutes)
+	assert.Nil(t, tracking.EstimatedArrivalAt)
+}
+
+// Teste para DispatchOrder com falha ao iniciar a transação
+func TestDispatchOrder(t *testing.T) {
+	router, mockDB := setupTest()
+
+	router.POST("/orders/:id/dispatch", func(c *gin.Context) {
+		DispatchOrder(c, mockDB)
+	})
+
+	mockDB.BeginFunc = func() (*sql.Tx, error) {
+		return nil, sql.ErrConnDone
+	}
+
+	// Sem corpo: despacho automático
+	req, _ := http.NewRequest("POST", "/orders/1/dispatch", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+
+	req, _ = http.NewRequest("POST", "/orders/abc/dispatch", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+// Teste para UpdateDeliveryStatus e UpdateDriverPosition com dados inválidos
+func TestDeliveryInvalidRequests(t *testing.T) {
+	router, mockDB := setupTest()
+
+	router.PUT("/orders/:id/delivery-status", func(c *gin.Context) {
+		UpdateDeliveryStatus(c, mockDB)
+	})
+	router.POST("/drivers/:id/position", func(c *gin.Context) {
+		UpdateDriverPosition(c, mockDB)
+	})
+
+	req, _ := http.NewRequest("PUT", "/orders/1/delivery-status", strings.NewReader(`{"status": "lost"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	req, _ = http.NewRequest("POST", "/drivers/1/position", strings.NewReader(`{"latitude": 120, "longitude": 10}`))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
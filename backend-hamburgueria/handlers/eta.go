@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"time"
+
+	// Configurações da aplicação (modelo de capacidade)
+	"backend-hamburgueria/config"
+
+	// Driver PostgreSQL - usado para passar arrays como parâmetro (ANY)
+	"github.com/lib/pq"
+)
+
+// ===== PREVISÃO DE PREPARO (ETA) =====
+
+// defaultPrepTime é usado quando o produto não tem tempo de preparo conhecido
+const defaultPrepTime = 8 * time.Minute
+
+// prepTimePriorWeight é o "peso" do tempo cadastrado frente às medições reais
+// Com 5 medições, o tempo aprendido e o cadastrado valem o mesmo; depois o aprendido domina
+const prepTimePriorWeight = 5
+
+// prepItem é o mínimo necessário de um item para estimar o preparo
+type prepItem struct {
+	ProductID int
+	Quantity  int
+}
+
+// loadPrepTimes retorna o tempo de preparo esperado de cada produto
+// Combina o tempo cadastrado (products.prep_minutes) com a média real dos últimos 30 dias,
+// medida em cada item: do início do preparo do pedido ("preparing" no histórico) até o item
+// ser finalizado na cozinha (order_items.prepared_at)
+func loadPrepTimes(q queryer, productIDs []int) (map[int]time.Duration, error) {
+	prepTimes := make(map[int]time.Duration)
+	if len(productIDs) == 0 {
+		return prepTimes, nil
+	}
+
+	rows, err := q.Query(`
+		WITH started AS (
+			SELECT order_id, MIN(created_at) AS started_at
+			FROM order_history
+			WHERE event = 'status' AND status = 'preparing' AND created_at > CURRENT_TIMESTAMP - INTERVAL '30 days'
+			GROUP BY order_id
+		), learned AS (
+			SELECT oi.product_id,
+				   AVG(EXTRACT(EPOCH FROM (oi.prepared_at - s.started_at))) AS avg_seconds,
+				   COUNT(*) AS samples
+			FROM started s
+			JOIN order_items oi ON oi.order_id = s.order_id
+			WHERE oi.prepared_at > s.started_at
+			GROUP BY oi.product_id
+		)
+		SELECT p.id, p.prep_minutes, COALESCE(l.avg_seconds, 0), COALESCE(l.samples, 0)
+		FROM products p
+		LEFT JOIN learned l ON l.product_id = p.id
+		WHERE p.id = ANY($1)
+	`, pq.Array(productIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var productID, prepMinutes, samples int
+		var avgSeconds float64
+		if err := rows.Scan(&productID, &prepMinutes, &avgSeconds, &samples); err != nil {
+			return nil, err
+		}
+		configured := time.Duration(prepMinutes) * time.Minute
+		learned := time.Duration(avgSeconds * float64(time.Second))
+		prepTimes[productID] = blendPrepTime(configured, learned, samples)
+	}
+	return prepTimes, rows.Err()
+}
+
+// blendPrepTime mistura o tempo cadastrado com a média medida
+// Poucas medições quase não mexem no cadastrado; muitas medições passam a mandar
+func blendPrepTime(configured, learned time.Duration, samples int) time.Duration {
+	if configured <= 0 {
+		configured = defaultPrepTime
+	}
+	if samples <= 0 || learned <= 0 {
+		return configured
+	}
+	total := configured*prepTimePriorWeight + learned*time.Duration(samples)
+	return total / time.Duration(prepTimePriorWeight+samples)
+}
+
+// orderPrepTime estima quanto a cozinha leva para preparar o pedido inteiro
+// As estações trabalham em paralelo, então vale o item mais demorado
+func orderPrepTime(items []prepItem, prepTimes map[int]time.Duration) time.Duration {
+	var longest time.Duration
+	for _, item := range items {
+		if item.Quantity <= 0 {
+			continue
+		}
+		prep, ok := prepTimes[item.ProductID]
+		if !ok {
+			prep = defaultPrepTime
+		}
+		if prep > longest {
+			longest = prep
+		}
+	}
+	return longest
+}
+
+// kitchenQueueDelay estima quanto os itens novos esperam pelos itens já em aberto nas suas estações
+// Cada estação prepara StationSize itens por rodada (AvgPrepTime); vale a estação mais atrasada.
+// Ao contrário da verificação de capacidade, considera também as estações sem limite configurado
+func kitchenQueueDelay(load kitchenLoad, newItems map[string]int, capacity config.KitchenCapacity) time.Duration {
+	var longest time.Duration
+	for station, items := range newItems {
+		if items <= 0 {
+			continue
+		}
+		size := capacity.StationSize(station)
+		total := load.StationItems[station] + items
+		rounds := (total + size - 1) / size
+		if delay := time.Duration(rounds-1) * capacity.AvgPrepTime; delay > longest {
+			longest = delay
+		}
+	}
+	return longest
+}
+
+// loadOrderPrepItems lê os itens de um pedido já gravado no formato usado pela estimativa
+func loadOrderPrepItems(q queryer, orderID int) ([]prepItem, error) {
+	rows, err := q.Query(`SELECT product_id, quantity FROM order_items WHERE order_id = $1`, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []prepItem
+	for rows.Next() {
+		var item prepItem
+		if err := rows.Scan(&item.ProductID, &item.Quantity); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+// estimateOrderReadyAt estima quando um pedido gravado fica pronto se começar em "start"
+func estimateOrderReadyAt(q queryer, orderID int, start time.Time) (time.Time, error) {
+	items, err := loadOrderPrepItems(q, orderID)
+	if err != nil {
+		return time.Time{}, err
+	}
+	prepTimes, err := loadPrepTimes(q, prepProductIDs(items))
+	if err != nil {
+		return time.Time{}, err
+	}
+	return start.Add(orderPrepTime(items, prepTimes)), nil
+}
+
+// prepProductIDs extrai os IDs de produto (sem repetição) dos itens
+func prepProductIDs(items []prepItem) []int {
+	seen := make(map[int]bool)
+	var ids []int
+	for _, item := range items {
+		if !seen[item.ProductID] {
+			seen[item.ProductID] = true
+			ids = append(ids, item.ProductID)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"backend-hamburgueria/config"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// Teste para GetOrderHistory com ID inválido
+func TestGetOrderHistoryInvalidID(t *testing.T) {
+	router, mockDB := setupTest()
+
+	router.GET("/orders/:id/history", func(c *gin.Context) {
+		GetOrderHistory(c, mockDB)
+	})
+
+	req, _ := http.NewRequest("GET", "/orders/abc/history", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+// Teste da mistura entre tempo cadastrado e tempo medido
+func TestBlendPrepTime(t *testing.T) {
+	// Sem medições vale o cadastrado
+	assert.Equal(t, 10*time.Minute, blendPrepTime(10*time.Minute, 0, 0))
+	// Com 5 medições o peso é igual: (10*5 + 20*5) / 10 = 15
+	assert.Equal(t, 15*time.Minute, blendPrepTime(10*time.Minute, 20*time.Minute, 5))
+	// Com muitas medições o aprendido domina
+	assert.InDelta(t, float64(20*time.Minute), float64(blendPrepTime(10*time.Minute, 20*time.Minute, 995)), float64(5*time.Second))
+	// Produto sem tempo cadastrado usa o padrão
+	assert.Equal(t, defaultPrepTime, blendPrepTime(0, 0, 0))
+}
+
+// Teste do tempo de preparo do pedido: vale o item mais demorado
+func TestOrderPrepTime(t *testing.T) {
+	prepTimes := map[int]time.Duration{2: 12 * time.Minute, 5: 3 * time.Minute}
+	items := []prepItem{{ProductID: 2, Quantity: 1}, {ProductID: 5, Quantity: 2}}
+	assert.Equal(t, 12*time.Minute, orderPrepTime(items, prepTimes))
+
+	// Produto sem tempo conhecido usa o padrão
+	assert.Equal(t, defaultPrepTime, orderPrepTime([]prepItem{{ProductID: 9, Quantity: 1}, {ProductID: 5, Quantity: 1}}, prepTimes))
+}
+
+// Teste da espera pela fila da cozinha, inclusive em estações sem limite configurado
+func TestKitchenQueueDelay(t *testing.T) {
+	capacity := config.KitchenCapacity{
+		StationLimits:      map[string]int{"grill": 10},
+		DefaultStationSize: 4,
+		AvgPrepTime:        10 * time.Minute,
+	}
+	load := kitchenLoad{StationItems: map[string]int{"grill": 18, "fryer": 7}}
+
+	// Chapa: 18 + 2 = 20 itens em duas rodadas de 10, espera uma rodada
+	assert.Equal(t, 10*time.Minute, kitchenQueueDelay(load, map[string]int{"grill": 2}, capacity))
+	// Fritadeira sem limite: 7 + 1 = 8 itens em rodadas de 4, espera uma rodada
+	assert.Equal(t, 10*time.Minute, kitchenQueueDelay(load, map[string]int{"fryer": 1}, capacity))
+	// Fritadeira com 7 + 6 = 13 itens: espera três rodadas
+	assert.Equal(t, 30*time.Minute, kitchenQueueDelay(load, map[string]int{"grill": 1, "fryer": 6}, capacity))
+	// Estação vazia: sem espera
+	assert.Equal(t, time.Duration(0), kitchenQueueDelay(load, map[string]int{"bar": 3}, capacity))
+}
@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"sync"
+)
+
+// ===== EVENTOS DE PEDIDOS (STREAMS SSE) =====
+
+// Tipos de evento publicados para os streams
+const (
+	eventOrderCreated  = "order_created"  // Pedido novo
+	eventOrderStatus   = "order_status"   // Mudança de status
+	eventOrdersRelease = "orders_release" // Pedidos agendados liberados para a cozinha
+	eventOrderDispatch = "order_dispatch" // Pedido atribuído a um entregador / andamento da entrega
+	eventDriverMoved   = "driver_moved"   // Nova posição do entregador de um pedido
+	eventOrderMoved    = "order_moved"    // Pedido mudou de mesa/comanda
+)
+
+// orderEvent descreve uma mudança em pedidos publicada para os streams SSE
+type orderEvent struct {
+	Type    string `json:"type"`               // Tipo do evento
+	OrderID int    `json:"order_id,omitempty"` // Pedido afetado (0 = vários)
+	Status  string `json:"status,omitempty"`   // Status após o evento
+	// Pedido novo com itens que contêm alérgenos declarados pelo cliente
+	AllergyAlert bool `json:"allergy_alert,omitempty"`
+}
+
+// eventBroker distribui eventos para todas as conexões SSE abertas
+// A publicação nunca bloqueia: um assinante lento simplesmente perde eventos
+// (os streams reconsultam o banco a cada evento, então perder um não deixa a tela errada)
+type eventBroker struct {
+	mu          sync.Mutex
+	subscribers map[chan orderEvent]struct{}
+}
+
+// newEventBroker cria um broker sem assinantes
+func newEventBroker() *eventBroker {
+	return &eventBroker{subscribers: make(map[chan orderEvent]struct{})}
+}
+
+// orderEvents é o broker de eventos de pedidos usado pelos handlers
+var orderEvents = newEventBroker()
+
+// Subscribe registra um novo assinante e retorna o canal de eventos
+func (b *eventBroker) Subscribe() chan orderEvent {
+	ch := make(chan orderEvent, 16)
+	b.mu.Lock()
+	b.subscribers[ch] = struct{}{}
+	b.mu.Unlock()
+	return ch
+}
+
+// Unsubscribe remove o assinante e fecha o canal
+func (b *eventBroker) Unsubscribe(ch chan orderEvent) {
+	b.mu.Lock()
+	if _, ok := b.subscribers[ch]; ok {
+		delete(b.subscribers, ch)
+		close(ch)
+	}
+	b.mu.Unlock()
+}
+
+// Publish envia o evento para todos os assinantes sem bloquear
+func (b *eventBroker) Publish(event orderEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for ch := range b.subscribers {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
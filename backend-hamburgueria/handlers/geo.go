@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"math"
+
+	// Modelos de dados da aplicação
+	"backend-hamburgueria/models"
+)
+
+// ===== CÁLCULOS GEOGRÁFICOS =====
+
+// earthRadiusKm é o raio médio da Terra usado nas distâncias
+const earthRadiusKm = 6371.0
+
+// haversineKm calcula a distância em linha reta (km) entre dois pontos
+func haversineKm(a, b models.GeoPoint) float64 {
+	lat1 := a.Latitude * math.Pi / 180
+	lat2 := b.Latitude * math.Pi / 180
+	dLat := lat2 - lat1
+	dLng := (b.Longitude - a.Longitude) * math.Pi / 180
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(h))
+}
+
+// pointInPolygon verifica se o ponto está dentro do polígono (ray casting)
+// Longitude é o eixo x e latitude o eixo y; vale para áreas do tamanho de uma cidade
+func pointInPolygon(point models.GeoPoint, polygon []models.GeoPoint) bool {
+	inside := false
+	for i, j := 0, len(polygon)-1; i < len(polygon); j, i = i, i+1 {
+		a, b := polygon[i], polygon[j]
+		if (a.Latitude > point.Latitude) != (b.Latitude > point.Latitude) {
+			crossLng := a.Longitude + (point.Latitude-a.Latitude)/(b.Latitude-a.Latitude)*(b.Longitude-a.Longitude)
+			if point.Longitude < crossLng {
+				inside = !inside
+			}
+		}
+	}
+	return inside
+}
+
+// validGeoPoint verifica se as coordenadas estão dentro dos limites
+func validGeoPoint(point models.GeoPoint) bool {
+	return point.Latitude >= -90 && point.Latitude <= 90 && point.Longitude >= -180 && point.Longitude <= 180
+}
@@ -5,12 +5,18 @@ import (
 	"database/sql"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
+	// Configurações da aplicação
+	"backend-hamburgueria/config"
 	// Modelos de dados da aplicação
 	"backend-hamburgueria/models"
 
 	// Framework web Gin
 	"github.com/gin-gonic/gin"
+	// Driver do PostgreSQL (arrays)
+	"github.com/lib/pq"
 )
 
 // DBInterface define a interface para operações de banco de dados
@@ -22,6 +28,31 @@ type DBInterface interface {
 	Begin() (*sql.Tx, error)
 }
 
+// queryer reúne as operações comuns a DBInterface e *sql.Tx
+// Permite que funções auxiliares rodem dentro ou fora de uma transação
+type queryer interface {
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+	Exec(query string, args ...interface{}) (sql.Result, error)
+}
+
+// rowScanner é implementado por *sql.Row e *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// orderColumns lista as colunas de orders na ordem lida por scanOrder
+const orderColumns = `id, COALESCE(ticket_number, 0), COALESCE(to_char(service_day, 'YYYY-MM-DD'), ''), order_type, customer_name, table_number, COALESCE(pickup_name, ''), COALESCE(customer_phone, ''), COALESCE(delivery_address, ''), delivery_address_id, delivery_latitude, delivery_longitude, delivery_zone_id, COALESCE(delivery_fee, 0), driver_id, COALESCE(delivery_status, ''), table_session_id, total_amount, COALESCE(subtotal, 0), COALESCE(discount_amount, 0), COALESCE(service_charge, 0), COALESCE(tip_amount, 0), status, notes, release_at, scheduled_for, allergies, allergy_alert, estimated_ready_at, created_at, updated_at`
+
+// scanOrder lê uma linha de orders selecionada com orderColumns
+func scanOrder(row rowScanner, order *models.Order) error {
+	err := row.Scan(&order.ID, &order.TicketNumber, &order.ServiceDay, &order.OrderType, &order.CustomerName, &order.TableNumber, &order.PickupName, &order.CustomerPhone, &order.DeliveryAddress, &order.DeliveryAddressID, &order.DeliveryLatitude, &order.DeliveryLongitude, &order.DeliveryZoneID, &order.DeliveryFee, &order.DriverID, &order.DeliveryStatus, &order.TableSessionID, &order.TotalAmount, &order.Pricing.Subtotal, &order.Pricing.Discount, &order.Pricing.ServiceCharge, &order.Pricing.Tip, &order.Status, &order.Notes, &order.ReleaseAt, &order.ScheduledFor, pq.Array(&order.Allergies), &order.AllergyAlert, &order.EstimatedReadyAt, &order.CreatedAt, &order.UpdatedAt)
+	// Taxa de entrega e total completam a composição do preço
+	order.Pricing.DeliveryFee = order.DeliveryFee
+	order.Pricing.Total = order.TotalAmount
+	return err
+}
+
 
 // ===== HANDLERS DE PRODUTOS E CATEGORIAS =====
 
@@ -32,15 +63,30 @@ type DBInterface interface {
 
 // GetProducts godoc
 // @Summary    Lista todos os produtos
-// @Description Retorna todos os produtos disponíveis 
+// @Description Retorna todos os produtos disponíveis, com alérgenos, dietas e calorias da receita
 // @Tags 	 Products
 // @Produce    json
+// @Param      exclude_allergens query string false "Alérgenos a evitar, separados por vírgula (ex.: gluten,lactose)"
+// @Param      diet              query string false "Somente produtos da dieta (vegan, vegetarian)"
 // @Success    200 {array} models.Product
+// @Failure    400 {object} models.ErrorResponse "Filtro inválido"
 // @Router     /api/products [get]
 func GetProducts(c *gin.Context, db DBInterface) {
+	// ===== FILTROS DE ALÉRGENOS E DIETA =====
+	excludeAllergens, err := normalizeAllergens(parseTagList(c.Query("exclude_allergens")))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	diet := strings.ToLower(strings.TrimSpace(c.Query("diet")))
+	if diet != "" && !containsString(knownDiets, diet) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dieta inválida: " + c.Query("diet")})
+		return
+	}
+
 	// Query SQL com JOIN para buscar produtos e suas categorias
 	query := `
-		SELECT p.id, p.name, p.description, p.price, p.category_id, p.image_url, p.is_available, p.created_at,
+		SELECT p.id, p.name, p.description, p.price, p.category_id, p.image_url, p.is_available, p.station, p.prep_minutes, p.is_bundle, p.created_at,
 			   c.id, c.name, c.description, c.created_at
 		FROM products p
 		LEFT JOIN categories c ON p.category_id = c.id
@@ -63,7 +109,7 @@ func GetProducts(c *gin.Context, db DBInterface) {
 		var cat models.Category
 		// Ler cada linha do resultado
 		err := rows.Scan(
-			&p.ID, &p.Name, &p.Description, &p.Price, &p.CategoryID, &p.ImageURL, &p.IsAvailable, &p.CreatedAt,
+			&p.ID, &p.Name, &p.Description, &p.Price, &p.CategoryID, &p.ImageURL, &p.IsAvailable, &p.Station, &p.PrepMinutes, &p.IsBundle, &p.CreatedAt,
 			&cat.ID, &cat.Name, &cat.Description, &cat.CreatedAt,
 		)
 		if err != nil {
@@ -75,6 +121,48 @@ func GetProducts(c *gin.Context, db DBInterface) {
 		products = append(products, p)
 	}
 
+	// ===== HORÁRIO DE VENDA =====
+	// Produtos fora da janela de venda (do produto ou da categoria) não aparecem no cardápio
+	schedules, err := loadMenuSchedules(db)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar horários do cardápio"})
+		return
+	}
+	now := time.Now()
+	var available []models.Product
+	for _, p := range products {
+		if schedules.productAvailable(p.ID, p.CategoryID, now) {
+			available = append(available, p)
+		}
+	}
+	products = available
+
+	// ===== VARIAÇÕES DISPONÍVEIS =====
+	variants, err := loadAvailableVariants(db)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar variações"})
+		return
+	}
+	for i := range products {
+		products[i].Variants = variants[products[i].ID]
+	}
+
+	// ===== ALÉRGENOS E DIETAS =====
+	// Vêm da receita do produto; produtos sem receita não declaram alérgenos nem dietas
+	dietary, err := loadMenuDietary(db)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar alérgenos"})
+		return
+	}
+	var filtered []models.Product
+	for _, p := range products {
+		p.Allergens, p.DietTags, p.Calories = dietary.product(p.ID)
+		if matchesDietaryFilter(p, excludeAllergens, diet) {
+			filtered = append(filtered, p)
+		}
+	}
+	products = filtered
+
 	// Retornar produtos como JSON
 	c.JSON(http.StatusOK, products)
 }
@@ -115,6 +203,21 @@ func GetCategories(c *gin.Context, db DBInterface) {
 		categories = append(categories, cat)
 	}
 
+	// Categorias fora do horário de venda não aparecem no cardápio
+	schedules, err := loadMenuSchedules(db)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar horários do cardápio"})
+		return
+	}
+	now := time.Now()
+	var available []models.Category
+	for _, cat := range categories {
+		if schedules.categoryAvailable(cat.ID, now) {
+			available = append(available, cat)
+		}
+	}
+	categories = available
+
 	// Retornar categorias como JSON
 	c.JSON(http.StatusOK, categories)
 }
@@ -125,14 +228,14 @@ func GetCategories(c *gin.Context, db DBInterface) {
 
 // GetIngredients godoc
 // @Summary Lista todos os ingredientes
-// @Description Retorna todos os ingredientes disponíveis
+// @Description Retorna todos os ingredientes disponíveis, com alérgenos, dietas e calorias por porção
 // @Tags Ingredients
 // @Produce json
 // @Success 200 {array} models.Ingredient
 // @Router /api/ingredients [get]
 func GetIngredients(c *gin.Context, db DBInterface) {
 	// Query SQL para buscar ingredientes disponíveis ordenados por categoria e nome
-	query := `SELECT id, name, price, category, is_available, created_at FROM ingredients WHERE is_available = true ORDER BY category, name`
+	query := `SELECT ` + ingredientColumns + ` FROM ingredients WHERE is_available = true ORDER BY category, name`
 
 	// Executar a query
 	rows, err := db.Query(query)
@@ -145,9 +248,8 @@ func GetIngredients(c *gin.Context, db DBInterface) {
 	// ===== PROCESSAR RESULTADOS =====
 	var ingredients []models.Ingredient
 	for rows.Next() {
-		var ing models.Ingredient
-		// Ler cada linha do resultado
-		err := rows.Scan(&ing.ID, &ing.Name, &ing.Price, &ing.Category, &ing.IsAvailable, &ing.CreatedAt)
+		// Ler cada linha do resultado (com alérgenos, dietas e calorias)
+		ing, err := scanIngredient(rows)
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao ler ingrediente"})
 			return
@@ -179,6 +281,11 @@ func CreateOrder(c *gin.Context, db DBInterface) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos"})
 		return
 	}
+	// Mesa do QR e campos obrigatórios de cada tipo (mesa, retirada ou entrega)
+	if err := prepareOrderRequest(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
 	// ===== INICIAR TRANSAÇÃO =====
 	// Usar transação para garantir consistência dos dados
@@ -189,118 +296,401 @@ func CreateOrder(c *gin.Context, db DBInterface) {
 	}
 	defer tx.Rollback() // Rollback em caso de erro
 
-	// ===== CALCULAR TOTAL DO PEDIDO =====
-	var totalAmount float64
-	for _, item := range req.Items {
-		// Para produtos customizados (ID 1), usar preço calculado no frontend
-		if item.ProductID == 1 {
-			// Extrair preço dos ingredientes se disponível
-			if item.Ingredients != "" {
-				// Tentar extrair preço do JSON de ingredientes
-				// Por enquanto, usar preço padrão para customizados
-				totalAmount += 15.00 // Preço padrão para lanches customizados
+	// ===== CRIAR PEDIDO =====
+	placed, ok := placeOrder(c, tx, &req)
+	if !ok {
+		return
+	}
+
+	// ===== COMMIT DA TRANSAÇÃO =====
+	if err := tx.Commit(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao finalizar pedido"})
+		return
+	}
+	orderEvents.Publish(orderEvent{Type: eventOrderCreated, OrderID: placed.ID, Status: placed.Status, AllergyAlert: placed.AllergyAlert})
+
+	// Retornar resposta de sucesso
+	c.JSON(http.StatusCreated, placed.response())
+}
+
+// placedOrder é um pedido gravado por placeOrder, ainda dentro da transação
+type placedOrder struct {
+	ID               int
+	TicketNumber     int
+	OrderType        string
+	Status           string
+	ReleaseAt        *time.Time
+	ScheduledFor     *time.Time
+	AllergyAlert     bool
+	EstimatedReadyAt time.Time
+	TableSessionID   *int
+	Pricing          orderPricing
+}
+
+// response monta a resposta da criação do pedido
+func (p placedOrder) response() gin.H {
+	response := gin.H{
+		"message":            "Pedido criado com sucesso",
+		"order_id":           p.ID,
+		"ticket_number":      p.TicketNumber,
+		"order_type":         p.OrderType,
+		"total_amount":       p.Pricing.Breakdown.Total,
+		"pricing":            p.Pricing.Breakdown,
+		"status":             p.Status,
+		"estimated_ready_at": p.EstimatedReadyAt,
+	}
+	if discounts := p.Pricing.discounts(); len(discounts) > 0 {
+		response["discounts"] = discounts
+	}
+	if p.Pricing.ComboSaving > 0 {
+		response["combo_saving"] = fromCents(p.Pricing.ComboSaving)
+	}
+	if p.ReleaseAt != nil {
+		response["release_at"] = p.ReleaseAt
+	}
+	if p.ScheduledFor != nil {
+		response["scheduled_for"] = p.ScheduledFor
+	}
+	if conflicts := p.Pricing.allergyConflicts(); len(conflicts) > 0 {
+		response["allergy_alert"] = true
+		response["allergy_conflicts"] = conflicts
+	}
+	if p.TableSessionID != nil {
+		response["table_session_id"] = *p.TableSessionID
+	}
+	if p.OrderType == orderTypeDelivery {
+		response["delivery_fee"] = p.Pricing.Delivery.Quote.DeliveryFee
+		response["delivery_zone"] = p.Pricing.Delivery.Quote.ZoneName
+	}
+	return response
+}
+
+// checkOrderRules confere a loja aberta, o horário agendado e a mesa, antes do cálculo de preço
+// Usado na criação do pedido e na cotação; em caso de erro já responde a requisição e retorna false
+func checkOrderRules(c *gin.Context, q queryer, req *models.CreateOrderRequest, scheduling config.ScheduledOrders) (*scheduledSlot, bool) {
+	// ===== LOJA ABERTA =====
+	// Fora do horário de funcionamento, em datas fechadas ou com os pedidos pausados, o pedido é recusado
+	// Pedidos agendados podem ser feitos com a loja fechada, desde que o horário escolhido esteja dentro do funcionamento
+	storeSchedule, err := loadStoreSchedule(q, time.Now())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao verificar horário da loja"})
+		return nil, false
+	}
+	store := storeSchedule.status(time.Now())
+	if !store.AcceptingOrders && (req.ScheduledFor == nil || store.Pause != nil) {
+		respondStoreUnavailable(c, store)
+		return nil, false
+	}
+
+	// ===== HORÁRIO AGENDADO =====
+	// Retirada/entrega para mais tarde: antecedência, limite de dias e funcionamento da loja no horário
+	var scheduled *scheduledSlot
+	if req.ScheduledFor != nil {
+		slot, err := planScheduledOrder(storeSchedule, *req.ScheduledFor, time.Now(), scheduling)
+		if err != nil {
+			respondScheduleError(c, err, scheduling)
+			return nil, false
+		}
+		scheduled = &slot
+	}
+
+	// ===== VALIDAR MESA =====
+	// Pedidos no salão só para mesas cadastradas e ativas
+	if req.OrderType == orderTypeDineIn {
+		if err := checkOrderTable(q, config.StoreID(), req.TableNumber); err != nil {
+			if err == errTableNotFound || err == errTableInactive {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			} else {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao validar mesa"})
+			}
+			return nil, false
+		}
+	}
+
+	return scheduled, true
+}
+
+// placeOrder valida, calcula e grava o pedido com seus itens dentro da transação
+// Compartilhado pela criação direta e pelo fechamento do carrinho; em caso de erro já
+// responde a requisição e retorna false. Commit e evento ficam com quem chamou.
+func placeOrder(c *gin.Context, tx *sql.Tx, req *models.CreateOrderRequest) (placedOrder, bool) {
+	var placed placedOrder
+
+	// ===== LOJA, HORÁRIO AGENDADO E MESA =====
+	// Mesmas verificações da cotação
+	scheduling := config.LoadScheduledOrders()
+	scheduled, ok := checkOrderRules(c, tx, req, scheduling)
+	if !ok {
+		return placed, false
+	}
+
+	// ===== CALCULAR PREÇO DO PEDIDO =====
+	// Itens, descontos, taxa de entrega, taxa de serviço e gorjeta (mesmo cálculo da cotação)
+	pricing, err := priceOrder(tx, req)
+	if err != nil {
+		respondPricingError(c, err)
+		return placed, false
+	}
+	totalAmount := pricing.Breakdown.Total
+	delivery := pricing.Delivery
+
+	// ===== ALERGIAS DO CLIENTE =====
+	// Itens com alérgenos declarados pelo cliente só entram com a confirmação explícita;
+	// confirmados, ficam em destaque na cozinha e no ticket impresso
+	allergyConflicts := pricing.allergyConflicts()
+	if len(allergyConflicts) > 0 && !req.AllowAllergyConflicts {
+		respondAllergyConflict(c, allergyConflicts)
+		return placed, false
+	}
+
+	// Itens novos por estação da cozinha (usado na verificação de capacidade)
+	stationItems := make(map[string]int)
+	// Itens no formato usado pela previsão de preparo
+	var prepItems []prepItem
+	for _, line := range pricing.Lines {
+		// Combos contam pelos produtos escolhidos, cada um na sua estação
+		if len(line.Components) > 0 {
+			for _, part := range line.Components {
+				stationItems[part.Station] += line.Item.Quantity
+				prepItems = append(prepItems, prepItem{ProductID: part.ProductID, Quantity: line.Item.Quantity})
+			}
+			continue
+		}
+		stationItems[line.Station] += line.Item.Quantity
+		prepItems = append(prepItems, prepItem{ProductID: line.Item.ProductID, Quantity: line.Item.Quantity})
+	}
+
+	// ===== CAPACIDADE DA COZINHA =====
+	// Com a cozinha no limite, o pedido é recusado ou agendado conforme KITCHEN_OVERLOAD_MODE
+	status := "pending"
+	var releaseAt *time.Time
+	capacity := config.LoadKitchenCapacity()
+	// Fila atual da cozinha (carregada uma vez e reaproveitada na previsão de pronto)
+	var load *kitchenLoad
+	if capacity.Enabled() && scheduled == nil {
+		// Serializar a verificação entre pedidos simultâneos até o fim da transação
+		if _, err := tx.Exec("SELECT pg_advisory_xact_lock($1)", kitchenCapacityLockKey); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao verificar capacidade da cozinha"})
+			return placed, false
+		}
+		now := time.Now()
+		current, err := loadKitchenLoad(tx, capacity, now)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao verificar capacidade da cozinha"})
+			return placed, false
+		}
+		load = &current
+		estimate := estimateKitchenDelay(current, stationItems, capacity, now)
+		if estimate.Delay > 0 {
+			if capacity.OverloadMode != config.OverloadSchedule {
+				c.Header("Retry-After", strconv.Itoa(int(estimate.Delay.Seconds())))
+				c.JSON(http.StatusServiceUnavailable, gin.H{
+					"error":                  "Cozinha no limite de capacidade, tente novamente mais tarde",
+					"estimated_wait_minutes": ceilMinutes(capacity.AvgPrepTime + estimate.Delay),
+				})
+				return placed, false
+			}
+			// Pedido aceito, mas só entra na fila quando houver capacidade
+			status = "scheduled"
+			release := now.Add(estimate.Delay)
+			releaseAt = &release
+		}
+	}
+
+	// ===== PEDIDO AGENDADO =====
+	// Limite de pedidos por horário; o pedido fica fora da fila da cozinha até a antecedência do horário
+	if scheduled != nil {
+		if err := reserveScheduledSlot(tx, *scheduled, scheduling); err != nil {
+			if isScheduleError(err) {
+				respondScheduleError(c, err, scheduling)
 			} else {
-				totalAmount += 15.00
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao reservar horário agendado"})
 			}
-		} else {
-			// Buscar preço do produto no banco
-			var productPrice float64
-			err := tx.QueryRow("SELECT price FROM products WHERE id = $1", item.ProductID).Scan(&productPrice)
+			return placed, false
+		}
+		status = "scheduled"
+		releaseAt = &scheduled.ReleaseAt
+	}
+
+	// ===== PREVISÃO DE PRONTO =====
+	// Começa depois da fila atual da cozinha (ou quando o pedido agendado entra na fila) e dura o item mais demorado
+	prepTimes, err := loadPrepTimes(tx, prepProductIDs(prepItems))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao calcular previsão do pedido"})
+		return placed, false
+	}
+	prepStart := time.Now()
+	if releaseAt != nil {
+		prepStart = *releaseAt
+	} else {
+		if load == nil {
+			current, err := loadKitchenLoad(tx, capacity, prepStart)
 			if err != nil {
-				c.JSON(http.StatusBadRequest, gin.H{"error": "Produto não encontrado"})
-				return
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao calcular previsão do pedido"})
+				return placed, false
 			}
+			load = &current
+		}
+		prepStart = prepStart.Add(kitchenQueueDelay(*load, stationItems, capacity))
+	}
+	estimatedReadyAt := prepStart.Add(orderPrepTime(prepItems, prepTimes))
+	// Pedido agendado fica pronto no horário escolhido (ou depois, se o preparo for mais longo que a antecedência)
+	if scheduled != nil && estimatedReadyAt.Before(scheduled.PickupAt) {
+		estimatedReadyAt = scheduled.PickupAt
+	}
 
-			// Calcular preço total do item
-			itemTotal := productPrice * float64(item.Quantity)
-			totalAmount += itemTotal
+	// ===== SENHA DO DIA =====
+	// Número curto e sequencial por loja e dia de operação, gerado na mesma transação
+	storeID := config.StoreID()
+	// Pedidos agendados recebem a senha do dia da retirada
+	serviceDayAt := time.Now()
+	if scheduled != nil {
+		serviceDayAt = scheduled.PickupAt
+	}
+	serviceDay := config.ServiceDayStart(serviceDayAt).Format("2006-01-02")
+	ticketNumber, err := nextTicketNumber(tx, storeID, serviceDay)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao gerar senha do pedido"})
+		return placed, false
+	}
+
+	// ===== COMANDA DA MESA =====
+	// Pedidos no salão entram na comanda aberta da mesa (abre uma se ainda não houver)
+	var tableSessionID *int
+	if req.OrderType == orderTypeDineIn {
+		sessionID, err := attachTableSession(tx, storeID, req.TableNumber)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao abrir comanda da mesa"})
+			return placed, false
+		}
+		// A nova rodada muda a conta: a divisão em vigor deixa de valer
+		if err := cancelSessionSplits(tx, sessionID); err != nil {
+			if err == errSplitPaidShares {
+				c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			} else {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao atualizar divisão da conta"})
+			}
+			return placed, false
 		}
+		tableSessionID = &sessionID
 	}
 
 	// ===== INSERIR PEDIDO =====
 	var orderID int
 	err = tx.QueryRow(`
-		INSERT INTO orders (customer_name, table_number, total_amount, notes)
-		VALUES ($1, $2, $3, $4)
+		INSERT INTO orders (customer_name, table_number, total_amount, notes, status, release_at, estimated_ready_at, store_id, service_day, ticket_number,
+			order_type, pickup_name, customer_phone, delivery_address,
+			delivery_address_id, delivery_latitude, delivery_longitude, delivery_zone_id, delivery_fee, table_session_id,
+			subtotal, discount_amount, service_charge, tip_amount, scheduled_for, allergies, allergy_alert)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, NULLIF($12, ''), NULLIF($13, ''), NULLIF($14, ''),
+			$15, $16, $17, NULLIF($18, 0), $19, $20, $21, $22, $23, $24, $25, $26, $27)
 		RETURNING id
-	`, req.CustomerName, req.TableNumber, totalAmount, req.Notes).Scan(&orderID)
+	`, req.CustomerName, req.TableNumber, totalAmount, req.Notes, status, releaseAt, estimatedReadyAt, storeID, serviceDay, ticketNumber,
+		req.OrderType, req.PickupName, req.CustomerPhone, req.DeliveryAddress,
+		delivery.AddressID, deliveryCoordinate(req.OrderType, delivery.Point.Latitude), deliveryCoordinate(req.OrderType, delivery.Point.Longitude),
+		delivery.Quote.ZoneID, pricing.Breakdown.DeliveryFee, tableSessionID,
+		pricing.Breakdown.Subtotal, pricing.Breakdown.Discount, pricing.Breakdown.ServiceCharge, pricing.Breakdown.Tip, req.ScheduledFor,
+		pq.Array(req.Allergies), len(allergyConflicts) > 0).Scan(&orderID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao criar pedido"})
-		return
+		return placed, false
+	}
+	if err := recordOrderEvent(tx, orderID, orderEventCreated, status, ""); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao registrar histórico do pedido"})
+		return placed, false
 	}
 
-	// ===== INSERIR ITENS DO PEDIDO =====
-	for _, item := range req.Items {
-		var productPrice float64
-		var totalPrice float64
-
-		if item.ProductID == 1 {
-			// Para produtos customizados, usar preço padrão
-			productPrice = 15.00
-			totalPrice = 15.00
-		} else {
-			// Buscar preço do produto
-			err := tx.QueryRow("SELECT price FROM products WHERE id = $1", item.ProductID).Scan(&productPrice)
-			if err != nil {
-				c.JSON(http.StatusBadRequest, gin.H{"error": "Produto não encontrado"})
-				return
+	// ===== RESGATE DO CUPOM =====
+	// Uso registrado na mesma transação: se o pedido falhar, o uso não é contado
+	if pricing.Coupon != nil {
+		if err := redeemCoupon(tx, pricing.Coupon, orderID, req.CustomerPhone); err != nil {
+			if isCouponError(err) {
+				respondCouponError(c, err)
+			} else {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao registrar uso do cupom"})
 			}
-			totalPrice = productPrice * float64(item.Quantity)
+			return placed, false
 		}
+	}
 
-		// Inserir item do pedido
-		_, err = tx.Exec(`
-			INSERT INTO order_items (order_id, product_id, ingredients, quantity, unit_price, total_price, notes)
-			VALUES ($1, $2, $3, $4, $5, $6, $7)
-		`, orderID, item.ProductID, item.Ingredients, item.Quantity, productPrice, totalPrice, item.Notes)
+	// ===== INSERIR ITENS DO PEDIDO =====
+	for i, line := range pricing.Lines {
+		itemID, err := insertOrderLine(tx, orderID, i+1, line)
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao adicionar item ao pedido"})
-			return
+			return placed, false
+		}
+		// Promoções aplicadas no item, para conferência e relatórios
+		for _, promotion := range line.Promotions {
+			_, err = tx.Exec(`
+				INSERT INTO order_item_promotions (order_id, order_item_id, promotion_id, name, quantity, discount_amount)
+				VALUES ($1, $2, $3, $4, $5, $6)
+			`, orderID, itemID, promotion.PromotionID, promotion.Name, promotion.Quantity, promotion.Discount)
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao registrar promoções do pedido"})
+				return placed, false
+			}
 		}
 	}
 
-	// ===== COMMIT DA TRANSAÇÃO =====
-	if err := tx.Commit(); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao finalizar pedido"})
-		return
-	}
-
-	// Retornar resposta de sucesso
-	c.JSON(http.StatusCreated, gin.H{
-		"message":      "Pedido criado com sucesso",
-		"order_id":     orderID,
-		"total_amount": totalAmount,
-	})
+	return placedOrder{
+		ID:               orderID,
+		TicketNumber:     ticketNumber,
+		OrderType:        req.OrderType,
+		Status:           status,
+		ReleaseAt:        releaseAt,
+		ScheduledFor:     req.ScheduledFor,
+		AllergyAlert:     len(allergyConflicts) > 0,
+		EstimatedReadyAt: estimatedReadyAt,
+		TableSessionID:   tableSessionID,
+		Pricing:          pricing,
+	}, true
 }
 
 // GetOrders retorna todos os pedidos
 // Endpoint: GET /api/orders
-// Suporta filtro por status: GET /api/orders?status=preparing
+// Suporta filtros por status e tipo: GET /api/orders?status=preparing&type=delivery
 
 // GetOrders godoc
 // @Summary Lista todos os pedidos
-// @Description Retorna todos os pedidos
+// @Description Retorna todos os pedidos, com filtros opcionais por status e tipo
 // @Tags Orders
 // @Produce json
+// @Param status query string false "Status do pedido"
+// @Param type query string false "Tipo do pedido (dine_in, takeaway, delivery)"
 // @Success 200 {array} models.Order
+// @Failure 400 {object} models.ErrorResponse "Tipo de pedido inválido"
 // @Router /api/orders [get]
 func GetOrders(c *gin.Context, db DBInterface) {
-	// Obter parâmetro de status da query string
+	// Obter filtros da query string
 	status := c.Query("status")
+	orderType := c.Query("type")
+	if orderType != "" && !validOrderType(orderType) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Tipo de pedido inválido (use dine_in, takeaway ou delivery)"})
+		return
+	}
 
 	// ===== CONSTRUIR QUERY DINÂMICA =====
-	var query string
+	var conditions []string
 	var args []interface{}
 
 	if status != "" {
-		// Query com filtro por status
-		query = `SELECT id, customer_name, table_number, total_amount, status, notes, created_at, updated_at FROM orders WHERE status = $1 ORDER BY created_at DESC`
+		// Filtro por status
 		args = append(args, status)
-	} else {
-		// Query sem filtro - todos os pedidos
-		query = `SELECT id, customer_name, table_number, total_amount, status, notes, created_at, updated_at FROM orders ORDER BY created_at DESC`
+		conditions = append(conditions, "status = $"+strconv.Itoa(len(args)))
+	}
+	if orderType != "" {
+		// Filtro por tipo (salão, retirada ou entrega)
+		args = append(args, orderType)
+		conditions = append(conditions, "order_type = $"+strconv.Itoa(len(args)))
 	}
 
+	query := `SELECT ` + orderColumns + ` FROM orders`
+	if len(conditions) > 0 {
+		query += ` WHERE ` + strings.Join(conditions, " AND ")
+	}
+	query += ` ORDER BY created_at DESC`
+
 	// ===== EXECUTAR QUERY =====
 	rows, err := db.Query(query, args...)
 	if err != nil {
@@ -314,7 +704,7 @@ func GetOrders(c *gin.Context, db DBInterface) {
 	for rows.Next() {
 		var order models.Order
 		// Ler cada linha do resultado
-		err := rows.Scan(&order.ID, &order.CustomerName, &order.TableNumber, &order.TotalAmount, &order.Status, &order.Notes, &order.CreatedAt, &order.UpdatedAt)
+		err := scanOrder(rows, &order)
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao ler pedido"})
 			return
@@ -339,6 +729,7 @@ func GetOrders(c *gin.Context, db DBInterface) {
 // @Param        body  body      models.UpdateOrderStatusRequest  true  "Novo status do pedido"
 // @Success      200   {object}  models.StatusResponse "Status atualizado com sucesso"
 // @Failure      400   {object}  models.ErrorResponse "Status inválido"
+// @Failure      404   {object}  models.ErrorResponse "Pedido não encontrado"
 // @Failure      500   {object}  models.ErrorResponse "Erro ao atualizar status"
 // @Router       /api/orders/{id}/status [put]
 func UpdateOrderStatus(c *gin.Context, db DBInterface) {
@@ -358,7 +749,7 @@ func UpdateOrderStatus(c *gin.Context, db DBInterface) {
 
 	// ===== VALIDAR STATUS =====
 	// Lista de status válidos
-	validStatuses := []string{"pending", "preparing", "ready", "delivered"}
+	validStatuses := []string{"scheduled", "pending", "preparing", "ready", "delivered"}
 	isValid := false
 	for _, status := range validStatuses {
 		if req.Status == status {
@@ -371,16 +762,61 @@ func UpdateOrderStatus(c *gin.Context, db DBInterface) {
 		return
 	}
 
+	// ===== INICIAR TRANSAÇÃO =====
+	// Status e histórico são gravados juntos
+	tx, err := db.Begin()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao iniciar transação"})
+		return
+	}
+	defer tx.Rollback()
+
+	// ===== REFINAR PREVISÃO DE PRONTO =====
+	// Em preparo: agora + tempo de preparo dos itens | Pronto: o horário real
+	var estimatedReadyAt *time.Time
+	now := time.Now()
+	switch req.Status {
+	case "preparing":
+		readyAt, err := estimateOrderReadyAt(tx, orderID, now)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao calcular previsão do pedido"})
+			return
+		}
+		estimatedReadyAt = &readyAt
+	case "ready":
+		estimatedReadyAt = &now
+	}
+
 	// ===== ATUALIZAR STATUS =====
 	// Executar UPDATE no banco de dados
-	_, err = db.Exec("UPDATE orders SET status = $1, updated_at = CURRENT_TIMESTAMP WHERE id = $2", req.Status, orderID)
+	var ticketNumber int
+	err = tx.QueryRow(`
+		UPDATE orders SET status = $1, updated_at = CURRENT_TIMESTAMP, estimated_ready_at = COALESCE($3, estimated_ready_at)
+		WHERE id = $2
+		RETURNING id, COALESCE(ticket_number, 0)
+	`, req.Status, orderID, estimatedReadyAt).Scan(&orderID, &ticketNumber)
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Pedido não encontrado"})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao atualizar status"})
 		return
 	}
+	if err := recordOrderEvent(tx, orderID, orderEventStatus, req.Status, ""); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao registrar histórico do pedido"})
+		return
+	}
+
+	// ===== COMMIT DA TRANSAÇÃO =====
+	if err := tx.Commit(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao atualizar status"})
+		return
+	}
+	orderEvents.Publish(orderEvent{Type: eventOrderStatus, OrderID: orderID, Status: req.Status})
 
 	// Retornar resposta de sucesso
-	c.JSON(http.StatusOK, gin.H{"message": "Status atualizado com sucesso"})
+	c.JSON(http.StatusOK, gin.H{"message": "Status atualizado com sucesso", "order_id": orderID, "ticket_number": ticketNumber})
 }
 
 // GetOrderDetails retorna os detalhes de um pedido específico
@@ -404,12 +840,15 @@ func GetOrderDetails(c *gin.Context, db DBInterface) {
 		return
 	}
 
+	respondOrderDetails(c, db, orderID)
+}
+
+// respondOrderDetails busca o pedido com seus itens e responde com o JSON completo
+// Compartilhado pela busca por ID e pela busca por senha do dia
+func respondOrderDetails(c *gin.Context, db DBInterface, orderID int) {
 	// ===== BUSCAR PEDIDO =====
 	var order models.Order
-	err = db.QueryRow(`
-		SELECT id, customer_name, table_number, total_amount, status, notes, created_at, updated_at
-		FROM orders WHERE id = $1
-	`, orderID).Scan(&order.ID, &order.CustomerName, &order.TableNumber, &order.TotalAmount, &order.Status, &order.Notes, &order.CreatedAt, &order.UpdatedAt)
+	err := scanOrder(db.QueryRow(`SELECT `+orderColumns+` FROM orders WHERE id = $1`, orderID), &order)
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Pedido não encontrado"})
 		return
@@ -418,11 +857,13 @@ func GetOrderDetails(c *gin.Context, db DBInterface) {
 	// ===== BUSCAR ITENS DO PEDIDO =====
 	// Query com JOIN para buscar itens e produtos
 	rows, err := db.Query(`
-		SELECT oi.id, oi.order_id, oi.product_id, oi.ingredients, oi.quantity, oi.unit_price, oi.total_price, oi.notes, oi.created_at,
-			   p.id, p.name, p.description, p.price, p.category_id, p.image_url, p.is_available, p.created_at
+		SELECT oi.id, oi.order_id, oi.product_id, oi.ingredients, oi.quantity, oi.unit_price, oi.total_price, oi.notes, oi.prepared_at,
+			   oi.bundle_id, oi.bundle_seq, COALESCE(oi.bundle_slot, ''), oi.variant_id, COALESCE(oi.variant_name, ''), COALESCE(oi.variant_sku, ''), oi.allergies, oi.allergen_conflicts, oi.created_at,
+			   p.id, p.name, p.description, p.price, p.category_id, p.image_url, p.is_available, p.station, p.prep_minutes, p.is_bundle, p.created_at
 		FROM order_items oi
 		LEFT JOIN products p ON oi.product_id = p.id
 		WHERE oi.order_id = $1
+		ORDER BY oi.id
 	`, orderID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar itens do pedido"})
@@ -436,8 +877,9 @@ func GetOrderDetails(c *gin.Context, db DBInterface) {
 		var product models.Product
 		// Ler cada linha do resultado
 		err := rows.Scan(
-			&item.ID, &item.OrderID, &item.ProductID, &item.Ingredients, &item.Quantity, &item.UnitPrice, &item.TotalPrice, &item.Notes, &item.CreatedAt,
-			&product.ID, &product.Name, &product.Description, &product.Price, &product.CategoryID, &product.ImageURL, &product.IsAvailable, &product.CreatedAt,
+			&item.ID, &item.OrderID, &item.ProductID, &item.Ingredients, &item.Quantity, &item.UnitPrice, &item.TotalPrice, &item.Notes, &item.PreparedAt,
+			&item.BundleID, &item.BundleSeq, &item.BundleSlot, &item.VariantID, &item.VariantName, &item.VariantSKU, pq.Array(&item.Allergies), pq.Array(&item.AllergenConflicts), &item.CreatedAt,
+			&product.ID, &product.Name, &product.Description, &product.Price, &product.CategoryID, &product.ImageURL, &product.IsAvailable, &product.Station, &product.PrepMinutes, &product.IsBundle, &product.CreatedAt,
 		)
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao ler item do pedido"})
@@ -450,6 +892,4 @@ func GetOrderDetails(c *gin.Context, db DBInterface) {
 
 	// Retornar pedido completo como JSON
 	c.JSON(http.StatusOK, order)
-
-	
 }
@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	// Modelos de dados da aplicação
+	"backend-hamburgueria/models"
+
+	// Framework web Gin
+	"github.com/gin-gonic/gin"
+)
+
+// ===== HISTÓRICO DE PEDIDOS =====
+
+// Tipos de evento gravados em order_history
+const (
+	orderEventCreated     = "created"     // Pedido criado
+	orderEventStatus      = "status"      // Mudança de status
+	orderEventDispatch    = "dispatch"    // Atribuído a um entregador
+	orderEventDelivery    = "delivery"    // Andamento da entrega (saiu da loja / entregue)
+	orderEventTransferred = "transferred" // Movido para outra mesa
+	orderEventMerged      = "merged"      // Comanda juntada a outra mesa
+)
+
+// recordOrderEvent registra um evento no histórico do pedido
+// Deve rodar na mesma transação da alteração que está sendo registrada
+func recordOrderEvent(q queryer, orderID int, event, status, details string) error {
+	_, err := q.Exec(`
+		INSERT INTO order_history (order_id, event, status, details)
+		VALUES ($1, $2, $3, $4)
+	`, orderID, event, status, details)
+	return err
+}
+
+// GetOrderHistory godoc
+// @Summary      Histórico de um pedido
+// @Description  Retorna os eventos do pedido (criação e mudanças de status) em ordem cronológica
+// @Tags         Orders
+// @Produce      json
+// @Param        id   path      int  true  "ID do pedido"
+// @Success      200  {array}   models.OrderHistoryEntry
+// @Failure      400  {object}  models.ErrorResponse "ID do pedido inválido"
+// @Failure      500  {object}  models.ErrorResponse "Erro ao buscar histórico"
+// @Router       /api/orders/{id}/history [get]
+func GetOrderHistory(c *gin.Context, db DBInterface) {
+	// ===== VALIDAR ID DO PEDIDO =====
+	orderID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do pedido inválido"})
+		return
+	}
+
+	rows, err := db.Query(`
+		SELECT id, order_id, event, status, details, created_at
+		FROM order_history
+		WHERE order_id = $1
+		ORDER BY created_at, id
+	`, orderID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar histórico"})
+		return
+	}
+	defer rows.Close()
+
+	// ===== PROCESSAR RESULTADOS =====
+	history := []models.OrderHistoryEntry{}
+	for rows.Next() {
+		var entry models.OrderHistoryEntry
+		if err := rows.Scan(&entry.ID, &entry.OrderID, &entry.Event, &entry.Status, &entry.Details, &entry.CreatedAt); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao ler histórico"})
+			return
+		}
+		history = append(history, entry)
+	}
+
+	c.JSON(http.StatusOK, history)
+}
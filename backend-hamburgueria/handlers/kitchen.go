@@ -0,0 +1,463 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	// Modelos de dados da aplicação
+	"backend-hamburgueria/models"
+
+	// Framework web Gin
+	"github.com/gin-gonic/gin"
+	// Driver PostgreSQL - usado para passar arrays como parâmetro (ANY)
+	"github.com/lib/pq"
+)
+
+// ===== HANDLERS DA COZINHA =====
+
+// openKitchenItem representa um item ainda não finalizado pela cozinha
+// É a linha bruta usada para montar a visão "all-day" e os bumps em lote
+type openKitchenItem struct {
+	ItemID      int
+	OrderID     int
+	ProductID   int
+	VariantID   *int // Variação do produto (tamanho)
+	ProductName string
+	Ingredients string
+	Quantity    int
+	OrderedAt   time.Time
+	// Alérgenos declarados pelo cliente presentes no item
+	AllergenConflicts []string
+	// Unidades que continuam em aberto quando o bump finaliza só parte do item
+	Open int
+}
+
+// GetAllDayCounts godoc
+// @Summary      Contagem "all-day" da cozinha
+// @Description  Agrupa os itens em aberto (pedidos pendentes/em preparo) por produto e customização; itens com alerta de alergia ficam em grupos separados, no topo
+// @Tags         Kitchen
+// @Produce      json
+// @Success      200  {array}   models.AllDayCount
+// @Failure      500  {object}  models.ErrorResponse "Erro ao buscar itens em aberto"
+// @Router       /api/kitchen/all-day [get]
+func GetAllDayCounts(c *gin.Context, db DBInterface) {
+	// Itens ainda não finalizados de pedidos que estão na fila da cozinha
+	rows, err := db.Query(`
+		SELECT oi.id, oi.order_id, oi.product_id, oi.variant_id, COALESCE(p.name, '') || COALESCE(' (' || oi.variant_name || ')', ''),
+			COALESCE(oi.ingredients, ''), oi.quantity, o.created_at, oi.allergen_conflicts
+		FROM order_items oi
+		JOIN orders o ON o.id = oi.order_id
+		LEFT JOIN products p ON p.id = oi.product_id
+		WHERE o.status IN ('pending', 'preparing') AND oi.prepared_at IS NULL
+		ORDER BY o.created_at, oi.id
+	`)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar itens em aberto"})
+		return
+	}
+	defer rows.Close()
+
+	// ===== PROCESSAR RESULTADOS =====
+	var items []openKitchenItem
+	for rows.Next() {
+		var it openKitchenItem
+		if err := rows.Scan(&it.ItemID, &it.OrderID, &it.ProductID, &it.VariantID, &it.ProductName, &it.Ingredients, &it.Quantity, &it.OrderedAt,
+			pq.Array(&it.AllergenConflicts)); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao ler item em aberto"})
+			return
+		}
+		items = append(items, it)
+	}
+
+	// Retornar os grupos como JSON
+	c.JSON(http.StatusOK, aggregateAllDay(items))
+}
+
+// BumpAllDayBatch godoc
+// @Summary      Finaliza um lote de itens iguais
+// @Description  Marca como preparados os itens em aberto de um produto/customização em vários pedidos de uma vez (do mais antigo para o mais novo); um item com mais unidades que o restante do lote é dividido
+// @Tags         Kitchen
+// @Accept       json
+// @Produce      json
+// @Param        body  body      models.BumpBatchRequest  true  "Lote a finalizar"
+// @Success      200   {object}  models.BumpBatchResponse
+// @Failure      400   {object}  models.ErrorResponse "Dados inválidos"
+// @Failure      404   {object}  models.ErrorResponse "Nenhum item em aberto para este lote"
+// @Failure      500   {object}  models.ErrorResponse "Erro ao finalizar lote"
+// @Router       /api/kitchen/all-day/bump [post]
+func BumpAllDayBatch(c *gin.Context, db DBInterface) {
+	// ===== VALIDAR DADOS DE ENTRADA =====
+	var req models.BumpBatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil || req.ProductID <= 0 || req.Quantity < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos"})
+		return
+	}
+	// Itens com alerta de alergia só são finalizados no próprio grupo
+	allergenConflicts, err := normalizeAllergens(req.AllergenConflicts)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// ===== INICIAR TRANSAÇÃO =====
+	tx, err := db.Begin()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao iniciar transação"})
+		return
+	}
+	defer tx.Rollback()
+
+	// ===== BUSCAR CANDIDATOS =====
+	// FOR UPDATE trava os itens para que dois bumps simultâneos não peguem o mesmo item
+	rows, err := tx.Query(`
+		SELECT oi.id, oi.order_id, oi.product_id, COALESCE(oi.ingredients, ''), oi.quantity, o.created_at
+		FROM order_items oi
+		JOIN orders o ON o.id = oi.order_id
+		WHERE oi.product_id = $1 AND oi.variant_id IS NOT DISTINCT FROM $2 AND oi.allergen_conflicts = $3
+			AND oi.prepared_at IS NULL AND o.status IN ('pending', 'preparing')
+		ORDER BY o.created_at, oi.id
+		FOR UPDATE OF oi
+	`, req.ProductID, req.VariantID, pq.Array(allergenConflicts))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar itens do lote"})
+		return
+	}
+	var candidates []openKitchenItem
+	for rows.Next() {
+		var it openKitchenItem
+		if err := rows.Scan(&it.ItemID, &it.OrderID, &it.ProductID, &it.Ingredients, &it.Quantity, &it.OrderedAt); err != nil {
+			rows.Close()
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao ler item do lote"})
+			return
+		}
+		candidates = append(candidates, it)
+	}
+	rows.Close()
+
+	// ===== SELECIONAR O LOTE =====
+	batch := selectBumpBatch(candidates, canonicalIngredients(req.Ingredients), req.Quantity)
+	if len(batch) == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Nenhum item em aberto para este lote"})
+		return
+	}
+
+	// ===== DIVIDIR ITEM PARCIAL =====
+	// A parte que continua em aberto vira um item novo, com os mesmos dados
+	for _, it := range batch {
+		if it.Open > 0 {
+			if _, err := splitOrderItem(tx, it.ItemID, it.Open); err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao dividir item do lote"})
+				return
+			}
+		}
+	}
+
+	var itemIDs []int
+	var orderIDs []int
+	seenOrders := make(map[int]bool)
+	units := 0
+	for _, it := range batch {
+		itemIDs = append(itemIDs, it.ItemID)
+		units += it.Quantity
+		if !seenOrders[it.OrderID] {
+			seenOrders[it.OrderID] = true
+			orderIDs = append(orderIDs, it.OrderID)
+		}
+	}
+
+	// ===== MARCAR ITENS COMO PREPARADOS =====
+	if _, err := tx.Exec(`UPDATE order_items SET prepared_at = CURRENT_TIMESTAMP WHERE id = ANY($1)`, pq.Array(itemIDs)); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao finalizar lote"})
+		return
+	}
+
+	// ===== ATUALIZAR STATUS DOS PEDIDOS =====
+	// Pedido com todos os itens preparados fica pronto; os demais passam para "preparing"
+	statusRows, err := tx.Query(`
+		WITH previous AS (
+			SELECT id, status FROM orders WHERE id = ANY($1) FOR UPDATE
+		)
+		UPDATE orders o SET
+			status = CASE WHEN EXISTS (
+				SELECT 1 FROM order_items oi WHERE oi.order_id = o.id AND oi.prepared_at IS NULL
+			) THEN 'preparing' ELSE 'ready' END,
+			updated_at = CURRENT_TIMESTAMP
+		FROM previous
+		WHERE o.id = previous.id
+		RETURNING o.id, o.status, previous.status
+	`, pq.Array(orderIDs))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao atualizar pedidos do lote"})
+		return
+	}
+	readyOrderIDs := []int{}
+	changed := make(map[int]string)
+	for statusRows.Next() {
+		var id int
+		var status, previousStatus string
+		if err := statusRows.Scan(&id, &status, &previousStatus); err != nil {
+			statusRows.Close()
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao atualizar pedidos do lote"})
+			return
+		}
+		if status != previousStatus {
+			changed[id] = status
+		}
+		if status == "ready" {
+			readyOrderIDs = append(readyOrderIDs, id)
+		}
+	}
+	statusRows.Close()
+
+	// ===== HISTÓRICO E PREVISÃO =====
+	for id, status := range changed {
+		if err := recordOrderEvent(tx, id, orderEventStatus, status, "bump em lote"); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao registrar histórico do pedido"})
+			return
+		}
+	}
+	if len(readyOrderIDs) > 0 {
+		// Pedido pronto: a previsão passa a ser o horário real
+		if _, err := tx.Exec(`UPDATE orders SET estimated_ready_at = CURRENT_TIMESTAMP WHERE id = ANY($1)`, pq.Array(readyOrderIDs)); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao atualizar pedidos do lote"})
+			return
+		}
+	}
+
+	// ===== COMMIT DA TRANSAÇÃO =====
+	if err := tx.Commit(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao finalizar lote"})
+		return
+	}
+	for id, status := range changed {
+		orderEvents.Publish(orderEvent{Type: eventOrderStatus, OrderID: id, Status: status})
+	}
+
+	sort.Ints(readyOrderIDs)
+	c.JSON(http.StatusOK, models.BumpBatchResponse{
+		Message:       "Lote finalizado com sucesso",
+		BumpedItemIDs: itemIDs,
+		BumpedUnits:   units,
+		ReadyOrderIDs: readyOrderIDs,
+	})
+}
+
+// ===== FUNÇÕES AUXILIARES =====
+
+// aggregateAllDay agrupa itens em aberto por produto + customização
+// Itens com alerta de alergia formam grupos próprios (preparo separado) e vêm antes dos demais
+// Os itens devem chegar ordenados do pedido mais antigo para o mais novo
+// O resultado vem ordenado pela maior quantidade (empate: pedido mais antigo primeiro)
+func aggregateAllDay(items []openKitchenItem) []models.AllDayCount {
+	groups := []models.AllDayCount{}
+	index := make(map[string]int)
+
+	for _, it := range items {
+		ingredients := canonicalIngredients(it.Ingredients)
+		key := allDayKey(it.ProductID, it.VariantID, ingredients) + "|" + strings.Join(it.AllergenConflicts, ",")
+
+		pos, ok := index[key]
+		if !ok {
+			pos = len(groups)
+			index[key] = pos
+			groups = append(groups, models.AllDayCount{
+				ProductID:         it.ProductID,
+				VariantID:         it.VariantID,
+				ProductName:       it.ProductName,
+				Ingredients:       ingredients,
+				Customization:     customizationLabel(ingredients),
+				AllergenConflicts: it.AllergenConflicts,
+				OrderIDs:          []int{},
+				OldestAt:          it.OrderedAt,
+			})
+		}
+
+		g := &groups[pos]
+		g.Quantity += it.Quantity
+		if n := len(g.OrderIDs); n == 0 || g.OrderIDs[n-1] != it.OrderID {
+			g.OrderIDs = append(g.OrderIDs, it.OrderID)
+		}
+		if it.OrderedAt.Before(g.OldestAt) {
+			g.OldestAt = it.OrderedAt
+		}
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		if alertI, alertJ := len(groups[i].AllergenConflicts) > 0, len(groups[j].AllergenConflicts) > 0; alertI != alertJ {
+			return alertI
+		}
+		if groups[i].Quantity != groups[j].Quantity {
+			return groups[i].Quantity > groups[j].Quantity
+		}
+		return groups[i].OldestAt.Before(groups[j].OldestAt)
+	})
+	return groups
+}
+
+// selectBumpBatch escolhe, em ordem FIFO, os itens do lote a finalizar
+// Um item com quantidade 2 conta como 2 unidades; se ele passar do que falta no lote, só parte dele
+// é finalizada: no lote, Quantity é a parte finalizada e Open a que continua em aberto
+// quantity = 0 seleciona todos os itens com a mesma customização
+func selectBumpBatch(candidates []openKitchenItem, ingredients string, quantity int) []openKitchenItem {
+	var batch []openKitchenItem
+	units := 0
+	for _, it := range candidates {
+		if quantity > 0 && units >= quantity {
+			break
+		}
+		if canonicalIngredients(it.Ingredients) != ingredients {
+			continue
+		}
+		if quantity > 0 && units+it.Quantity > quantity {
+			it.Open = units + it.Quantity - quantity
+			it.Quantity -= it.Open
+		}
+		batch = append(batch, it)
+		units += it.Quantity
+	}
+	return batch
+}
+
+// splitOrderItem separa quantity unidades do item em um item novo, com os mesmos dados (inclusive o preparo)
+// O preço total e as promoções do item acompanham as unidades: a soma dos dois itens é a do item original
+func splitOrderItem(q queryer, itemID, quantity int) (int, error) {
+	var total int
+	if err := q.QueryRow(`SELECT quantity FROM order_items WHERE id = $1`, itemID).Scan(&total); err != nil {
+		return 0, err
+	}
+	var newID int
+	err := q.QueryRow(`
+		INSERT INTO order_items (order_id, product_id, ingredients, quantity, unit_price, total_price, notes, prepared_at,
+			bundle_id, bundle_seq, bundle_slot, variant_id, variant_name, variant_sku, allergies, allergen_conflicts)
+		SELECT order_id, product_id, ingredients, $2, unit_price, unit_price * $2, notes, prepared_at,
+			bundle_id, bundle_seq, bundle_slot, variant_id, variant_name, variant_sku, allergies, allergen_conflicts
+		FROM order_items WHERE id = $1
+		RETURNING id
+	`, itemID, quantity).Scan(&newID)
+	if err != nil {
+		return 0, err
+	}
+	_, err = q.Exec(`
+		UPDATE order_items SET quantity = quantity - $2, total_price = total_price - unit_price * $2 WHERE id = $1
+	`, itemID, quantity)
+	if err != nil {
+		return 0, err
+	}
+	return newID, splitItemPromotions(q, itemID, newID, quantity, total)
+}
+
+// splitItemPromotions divide as promoções do item entre ele e o item separado, na proporção das unidades
+func splitItemPromotions(q queryer, itemID, newItemID, quantity, total int) error {
+	rows, err := q.Query(`SELECT id, quantity, discount_amount FROM order_item_promotions WHERE order_item_id = $1`, itemID)
+	if err != nil {
+		return err
+	}
+	type itemPromotion struct {
+		ID       int
+		Quantity int
+		Discount float64
+	}
+	var promotions []itemPromotion
+	for rows.Next() {
+		var promotion itemPromotion
+		if err := rows.Scan(&promotion.ID, &promotion.Quantity, &promotion.Discount); err != nil {
+			rows.Close()
+			return err
+		}
+		promotions = append(promotions, promotion)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, promotion := range promotions {
+		movedQuantity, movedDiscount := splitPromotionShare(promotion.Quantity, promotion.Discount, quantity, total)
+		if _, err := q.Exec(`
+			INSERT INTO order_item_promotions (order_id, order_item_id, promotion_id, name, quantity, discount_amount)
+			SELECT order_id, $2, promotion_id, name, $3, $4 FROM order_item_promotions WHERE id = $1
+		`, promotion.ID, newItemID, movedQuantity, movedDiscount); err != nil {
+			return err
+		}
+		if _, err := q.Exec(`
+			UPDATE order_item_promotions SET quantity = quantity - $2, discount_amount = discount_amount - $3 WHERE id = $1
+		`, promotion.ID, movedQuantity, movedDiscount); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// splitPromotionShare calcula a parte de uma promoção que acompanha "part" das "total" unidades do item
+// Quantidade promocional e desconto são rateados sem sobra: as duas partes somam o valor original
+func splitPromotionShare(quantity int, discount float64, part, total int) (int, float64) {
+	weights := []int64{int64(part), int64(total - part)}
+	movedQuantity := allocateCents(int64(quantity), weights)[0]
+	movedDiscount := allocateCents(toCents(discount), weights)[0]
+	return int(movedQuantity), fromCents(movedDiscount)
+}
+
+// allDayKey monta a chave de agrupamento produto + variação + customização
+func allDayKey(productID int, variantID *int, ingredients string) string {
+	key := strconv.Itoa(productID) + "|"
+	if variantID != nil {
+		key += strconv.Itoa(*variantID)
+	}
+	return key + "|" + ingredients
+}
+
+// canonicalIngredients normaliza o JSON de ingredientes para comparação
+// json.Marshal ordena as chaves, então o mesmo lanche sempre gera o mesmo texto
+// Conteúdo que não é JSON válido é comparado como texto (sem espaços nas pontas)
+func canonicalIngredients(raw string) string {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return ""
+	}
+	var parsed interface{}
+	if err := json.Unmarshal([]byte(raw), &parsed); err != nil {
+		return raw
+	}
+	normalized, err := json.Marshal(parsed)
+	if err != nil {
+		return raw
+	}
+	return string(normalized)
+}
+
+// customizationLabel gera um texto legível a partir do JSON de ingredientes
+// Exemplo: {"bread":{"name":"Pão Brioche"},"meat":{"name":"Carne Angus"}} -> "Pão Brioche, Carne Angus"
+func customizationLabel(ingredients string) string {
+	if ingredients == "" {
+		return ""
+	}
+	var parsed map[string]interface{}
+	if err := json.Unmarshal([]byte(ingredients), &parsed); err != nil {
+		return ingredients
+	}
+
+	keys := make([]string, 0, len(parsed))
+	for k := range parsed {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var names []string
+	for _, k := range keys {
+		switch v := parsed[k].(type) {
+		case string:
+			if v != "" {
+				names = append(names, v)
+			}
+		case map[string]interface{}:
+			if name, ok := v["name"].(string); ok && name != "" {
+				names = append(names, name)
+			}
+		}
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"bytes"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// Teste para GetAllDayCounts
+func TestGetAllDayCounts(t *testing.T) {
+	router, mockDB := setupTest()
+
+	// Configurar rota
+	router.GET("/kitchen/all-day", func(c *gin.Context) {
+		GetAllDayCounts(c, mockDB)
+	})
+
+	// Mock da resposta do banco - retornar erro para simular falha
+	mockDB.QueryFunc = func(query string, args ...interface{}) (*sql.Rows, error) {
+		return nil, sql.ErrConnDone
+	}
+
+	req, _ := http.NewRequest("GET", "/kitchen/all-day", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Verificar resposta - esperamos erro interno devido ao mock
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+// Teste para BumpAllDayBatch sem produto informado
+func TestBumpAllDayBatchInvalid(t *testing.T) {
+	router, mockDB := setupTest()
+
+	router.POST("/kitchen/all-day/bump", func(c *gin.Context) {
+		BumpAllDayBatch(c, mockDB)
+	})
+
+	req, _ := http.NewRequest("POST", "/kitchen/all-day/bump", bytes.NewBufferString(`{"quantity": 2}`))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Sem product_id a requisição é inválida antes de tocar no banco
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+// Teste do agrupamento all-day: mesma customização com chaves em ordem diferente vira um grupo só
+func TestAggregateAllDay(t *testing.T) {
+	base := time.Date(2026, 10, 19, 19, 0, 0, 0, time.UTC)
+	items := []openKitchenItem{
+		{ItemID: 1, OrderID: 10, ProductID: 2, ProductName: "Classic", Quantity: 2, OrderedAt: base},
+		{ItemID: 2, OrderID: 10, ProductID: 1, ProductName: "Personalizado", Ingredients: `{"meat":{"name":"Angus"},"bread":{"name":"Brioche"}}`, Quantity: 1, OrderedAt: base},
+		{ItemID: 3, OrderID: 11, ProductID: 3, ProductName: "Bacon Deluxe", Quantity: 1, OrderedAt: base.Add(time.Minute)},
+		{ItemID: 4, OrderID: 12, ProductID: 2, ProductName: "Classic", Quantity: 1, OrderedAt: base.Add(2 * time.Minute)},
+		{ItemID: 5, OrderID: 12, ProductID: 1, ProductName: "Personalizado", Ingredients: `{"bread": {"name": "Brioche"}, "meat": {"name": "Angus"}}`, Quantity: 1, OrderedAt: base.Add(2 * time.Minute)},
+	}
+
+	groups := aggregateAllDay(items)
+
+	assert.Len(t, groups, 3)
+	assert.Equal(t, "Classic", groups[0].ProductName)
+	assert.Equal(t, 3, groups[0].Quantity)
+	assert.Equal(t, []int{10, 12}, groups[0].OrderIDs)
+	assert.Equal(t, "Personalizado", groups[1].ProductName)
+	assert.Equal(t, 2, groups[1].Quantity)
+	assert.Equal(t, "Brioche, Angus", groups[1].Customization)
+	assert.Equal(t, "Bacon Deluxe", groups[2].ProductName)
+}
+
+// Teste do agrupamento all-day com variações: cada tamanho é um grupo
+func TestAggregateAllDayVariants(t *testing.T) {
+	base := time.Date(2026, 10, 19, 19, 0, 0, 0, time.UTC)
+	small, large := 1, 3
+	items := []openKitchenItem{
+		{ItemID: 1, OrderID: 10, ProductID: 5, VariantID: &small, ProductName: "Batata (P)", Quantity: 1, OrderedAt: base},
+		{ItemID: 2, OrderID: 11, ProductID: 5, VariantID: &large, ProductName: "Batata (G)", Quantity: 2, OrderedAt: base},
+		{ItemID: 3, OrderID: 12, ProductID: 5, VariantID: &small, ProductName: "Batata (P)", Quantity: 1, OrderedAt: base},
+	}
+
+	groups := aggregateAllDay(items)
+
+	assert.Len(t, groups, 2)
+	assert.Equal(t, 2, groups[0].Quantity)
+	assert.Equal(t, "Batata (P)", groups[0].ProductName)
+	assert.Equal(t, &small, groups[0].VariantID)
+	assert.Equal(t, "Batata (G)", groups[1].ProductName)
+}
+
+// Teste do all-day com alerta de alergia: grupo separado e no topo
+func TestAggregateAllDayAllergyAlert(t *testing.T) {
+	base := time.Date(2026, 10, 19, 19, 0, 0, 0, time.UTC)
+	items := []openKitchenItem{
+		{ItemID: 1, OrderID: 10, ProductID: 2, ProductName: "Classic Burger", Quantity: 3, OrderedAt: base},
+		{ItemID: 2, OrderID: 11, ProductID: 2, ProductName: "Classic Burger", Quantity: 1, OrderedAt: base,
+			AllergenConflicts: []string{"lactose"}},
+		{ItemID: 3, OrderID: 12, ProductID: 2, ProductName: "Classic Burger", Quantity: 1, OrderedAt: base,
+			AllergenConflicts: []string{}},
+	}
+
+	groups := aggregateAllDay(items)
+
+	assert.Len(t, groups, 2)
+	assert.Equal(t, []string{"lactose"}, groups[0].AllergenConflicts)
+	assert.Equal(t, 1, groups[0].Quantity)
+	assert.Equal(t, []int{11}, groups[0].OrderIDs)
+	assert.Empty(t, groups[1].AllergenConflicts)
+	assert.Equal(t, 4, groups[1].Quantity)
+}
+
+// Teste da seleção do lote: FIFO, só a customização pedida, sem passar da quantidade
+func TestSelectBumpBatch(t *testing.T) {
+	candidates := []openKitchenItem{
+		{ItemID: 1, OrderID: 10, Quantity: 2},
+		{ItemID: 2, OrderID: 11, Ingredients: `{"extra":"bacon"}`, Quantity: 1},
+		{ItemID: 3, OrderID: 12, Quantity: 1},
+		{ItemID: 4, OrderID: 13, Quantity: 1},
+	}
+
+	batch := selectBumpBatch(candidates, "", 3)
+	assert.Len(t, batch, 2)
+	assert.Equal(t, 1, batch[0].ItemID)
+	assert.Equal(t, 3, batch[1].ItemID)
+
+	all := selectBumpBatch(candidates, canonicalIngredients(`{ "extra": "bacon" }`), 0)
+	assert.Len(t, all, 1)
+	assert.Equal(t, 2, all[0].ItemID)
+}
+
+// Teste do lote que termina no meio de um item: o item é dividido
+func TestSelectBumpBatchSplit(t *testing.T) {
+	candidates := []openKitchenItem{
+		{ItemID: 1, OrderID: 10, Quantity: 2},
+		{ItemID: 2, OrderID: 11, Quantity: 2},
+	}
+
+	batch := selectBumpBatch(candidates, "", 3)
+	assert.Len(t, batch, 2)
+	assert.Equal(t, 2, batch[0].Quantity)
+	assert.Equal(t, 0, batch[0].Open)
+	assert.Equal(t, 2, batch[1].ItemID)
+	assert.Equal(t, 1, batch[1].Quantity)
+	assert.Equal(t, 1, batch[1].Open)
+
+	// Os candidatos não são alterados
+	assert.Equal(t, 2, candidates[1].Quantity)
+
+	single := selectBumpBatch(candidates, "", 1)
+	assert.Len(t, single, 1)
+	assert.Equal(t, 1, single[0].Quantity)
+	assert.Equal(t, 1, single[0].Open)
+}
@@ -0,0 +1,154 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	// Configurações da aplicação (fuso horário da loja)
+	"backend-hamburgueria/config"
+	// Modelos de dados da aplicação
+	"backend-hamburgueria/models"
+
+	// Framework web Gin
+	"github.com/gin-gonic/gin"
+	// Driver do PostgreSQL (arrays)
+	"github.com/lib/pq"
+)
+
+// ===== TICKET IMPRESSO DA COZINHA =====
+
+// kitchenTicketWidth é a largura em caracteres da impressora térmica (bobina de 80 mm)
+const kitchenTicketWidth = 42
+
+// kitchenTicketLine é um item do pedido como impresso no ticket da cozinha
+type kitchenTicketLine struct {
+	Quantity          int
+	ProductName       string
+	VariantName       string
+	BundleSlot        string
+	Ingredients       string
+	Notes             string
+	AllergenConflicts []string
+}
+
+// GetKitchenTicket godoc
+// @Summary      Ticket impresso da cozinha
+// @Description  Texto pronto para a impressora térmica, com as alergias do cliente e os itens em conflito em destaque
+// @Tags         Kitchen
+// @Produce      plain
+// @Param        id   path      int  true  "ID do pedido"
+// @Success      200  {string}  string "Ticket em texto"
+// @Failure      400  {object}  models.ErrorResponse "ID inválido"
+// @Failure      404  {object}  models.ErrorResponse "Pedido não encontrado"
+// @Router       /api/orders/{id}/kitchen-ticket [get]
+func GetKitchenTicket(c *gin.Context, db DBInterface) {
+	orderID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do pedido inválido"})
+		return
+	}
+
+	// ===== BUSCAR PEDIDO E ITENS =====
+	var order models.Order
+	if err := scanOrder(db.QueryRow(`SELECT `+orderColumns+` FROM orders WHERE id = $1`, orderID), &order); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Pedido não encontrado"})
+		return
+	}
+	rows, err := db.Query(`
+		SELECT oi.quantity, COALESCE(p.name, ''), COALESCE(oi.variant_name, ''), COALESCE(oi.bundle_slot, ''),
+			COALESCE(oi.ingredients, ''), COALESCE(oi.notes, ''), oi.allergen_conflicts
+		FROM order_items oi
+		LEFT JOIN products p ON p.id = oi.product_id
+		WHERE oi.order_id = $1
+		ORDER BY oi.id
+	`, orderID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar itens do pedido"})
+		return
+	}
+	defer rows.Close()
+
+	var lines []kitchenTicketLine
+	for rows.Next() {
+		var line kitchenTicketLine
+		if err := rows.Scan(&line.Quantity, &line.ProductName, &line.VariantName, &line.BundleSlot,
+			&line.Ingredients, &line.Notes, pq.Array(&line.AllergenConflicts)); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao ler item do pedido"})
+			return
+		}
+		lines = append(lines, line)
+	}
+	if err := rows.Err(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar itens do pedido"})
+		return
+	}
+
+	c.Data(http.StatusOK, "text/plain; charset=utf-8", []byte(renderKitchenTicket(order, lines)))
+}
+
+// ===== FUNÇÕES AUXILIARES =====
+
+// renderKitchenTicket monta o texto do ticket: cabeçalho, alergias do cliente e itens
+// Itens com alérgenos declarados recebem a marcação "!!! ALERGIA" logo abaixo do nome
+func renderKitchenTicket(order models.Order, lines []kitchenTicketLine) string {
+	var b strings.Builder
+	rule := strings.Repeat("-", kitchenTicketWidth) + "\n"
+	location := config.StoreLocation()
+
+	fmt.Fprintf(&b, "SENHA %d - %s\n", order.TicketNumber, orderTypeTicketLabel(order.OrderType))
+	if order.OrderType == orderTypeDineIn && order.TableNumber > 0 {
+		fmt.Fprintf(&b, "Mesa %d\n", order.TableNumber)
+	}
+	fmt.Fprintf(&b, "Pedido #%d - %s\n", order.ID, order.CreatedAt.In(location).Format("02/01 15:04"))
+	if order.ScheduledFor != nil {
+		fmt.Fprintf(&b, "Agendado para %s\n", order.ScheduledFor.In(location).Format("02/01 15:04"))
+	}
+
+	// Alergias do cliente em destaque no topo
+	if len(order.Allergies) > 0 {
+		banner := strings.Repeat("*", kitchenTicketWidth) + "\n"
+		b.WriteString(banner)
+		fmt.Fprintf(&b, "*** ALERGIA: %s\n", strings.ToUpper(strings.Join(order.Allergies, ", ")))
+		b.WriteString(banner)
+	}
+
+	b.WriteString(rule)
+	for _, line := range lines {
+		name := line.ProductName
+		if line.VariantName != "" {
+			name += " (" + line.VariantName + ")"
+		}
+		if line.BundleSlot != "" {
+			name += " [" + line.BundleSlot + "]"
+		}
+		fmt.Fprintf(&b, "%dx %s\n", line.Quantity, name)
+		if len(line.AllergenConflicts) > 0 {
+			fmt.Fprintf(&b, "   !!! ALERGIA - CONTÉM %s !!!\n", strings.ToUpper(strings.Join(line.AllergenConflicts, ", ")))
+		}
+		if label := customizationLabel(canonicalIngredients(line.Ingredients)); label != "" {
+			fmt.Fprintf(&b, "   %s\n", label)
+		}
+		if line.Notes != "" {
+			fmt.Fprintf(&b, "   Obs: %s\n", line.Notes)
+		}
+	}
+	b.WriteString(rule)
+	if order.Notes != "" {
+		fmt.Fprintf(&b, "Obs. do pedido: %s\n", order.Notes)
+	}
+	return b.String()
+}
+
+// orderTypeTicketLabel é o tipo do pedido como impresso no ticket
+func orderTypeTicketLabel(orderType string) string {
+	switch orderType {
+	case orderTypeDineIn:
+		return "SALÃO"
+	case orderTypeDelivery:
+		return "ENTREGA"
+	default:
+		return "RETIRADA"
+	}
+}
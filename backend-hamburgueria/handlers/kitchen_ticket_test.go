@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"backend-hamburgueria/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Teste do ticket impresso com alergia em destaque
+func TestRenderKitchenTicket(t *testing.T) {
+	t.Setenv("STORE_TIMEZONE", "UTC")
+	order := models.Order{
+		ID:           123,
+		TicketNumber: 42,
+		OrderType:    orderTypeTakeaway,
+		Allergies:    []string{"lactose"},
+		Notes:        "Sem sacola",
+		CreatedAt:    time.Date(2026, 10, 19, 19, 40, 0, 0, time.UTC),
+	}
+	lines := []kitchenTicketLine{
+		{Quantity: 2, ProductName: "Classic Burger", Notes: "sem cebola", AllergenConflicts: []string{"lactose"}},
+		{Quantity: 1, ProductName: "Batata", VariantName: "G"},
+	}
+
+	ticket := renderKitchenTicket(order, lines)
+
+	assert.Contains(t, ticket, "SENHA 42 - RETIRADA\n")
+	assert.Contains(t, ticket, "Pedido #123 - 19/10 19:40\n")
+	assert.Contains(t, ticket, "*** ALERGIA: LACTOSE\n")
+	assert.Contains(t, ticket, "2x Classic Burger\n   !!! ALERGIA - CONTÉM LACTOSE !!!\n   Obs: sem cebola\n")
+	assert.Contains(t, ticket, "1x Batata (G)\n---")
+	assert.Equal(t, 1, strings.Count(ticket, "CONTÉM"))
+	assert.Contains(t, ticket, "Obs. do pedido: Sem sacola\n")
+}
@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"errors"
+	"strings"
+
+	// Configurações da aplicação (loja e QR das mesas)
+	"backend-hamburgueria/config"
+	// Modelos de dados da aplicação
+	"backend-hamburgueria/models"
+)
+
+// ===== TIPOS DE PEDIDO =====
+
+// Tipos de pedido: define como o pedido sai da loja
+const (
+	orderTypeDineIn   = "dine_in"  // Consumo no salão (exige mesa)
+	orderTypeTakeaway = "takeaway" // Retirada no balcão (exige nome ou telefone)
+	orderTypeDelivery = "delivery" // Entrega (exige endereço e telefone ou endereço salvo)
+)
+
+// validOrderType informa se o tipo de pedido é conhecido
+func validOrderType(orderType string) bool {
+	switch orderType {
+	case orderTypeDineIn, orderTypeTakeaway, orderTypeDelivery:
+		return true
+	}
+	return false
+}
+
+// prepareOrderRequest aplica a mesa do QR e valida os campos do tipo do pedido
+// Compartilhado pela criação e pela cotação do pedido
+func prepareOrderRequest(req *models.CreateOrderRequest) error {
+	// Pedido feito pelo QR da mesa: o token assinado define a mesa
+	if req.TableToken != "" {
+		tableNumber, ok := verifyTableToken(config.TableQRSecret(), config.StoreID(), req.TableToken)
+		if !ok || (req.OrderType != "" && req.OrderType != orderTypeDineIn) {
+			return errors.New("QR da mesa inválido")
+		}
+		req.OrderType = orderTypeDineIn
+		req.TableNumber = tableNumber
+	}
+	return validateOrderType(req)
+}
+
+// validateOrderType normaliza e valida os campos que dependem do tipo do pedido
+// Sem tipo informado, mesa > 0 vira salão e mesa 0 vira retirada (como o balcão lançava antes);
+// a retirada deduzida aceita pedidos sem nome nem telefone, como antes dos tipos de pedido
+func validateOrderType(req *models.CreateOrderRequest) error {
+	req.OrderType = strings.TrimSpace(strings.ToLower(req.OrderType))
+	req.PickupName = strings.TrimSpace(req.PickupName)
+	req.DeliveryAddress = strings.TrimSpace(req.DeliveryAddress)
+	req.CustomerPhone = normalizePhone(req.CustomerPhone)
+
+	inferred := req.OrderType == ""
+	if inferred {
+		if req.TableNumber > 0 {
+			req.OrderType = orderTypeDineIn
+		} else {
+			req.OrderType = orderTypeTakeaway
+		}
+	}
+
+	// Telefone é opcional em alguns tipos, mas quando informado precisa ter DDD + número
+	if req.CustomerPhone != "" && (len(req.CustomerPhone) < 10 || len(req.CustomerPhone) > 13) {
+		return errors.New("Telefone inválido, informe DDD e número")
+	}
+
+	switch req.OrderType {
+	case orderTypeDineIn:
+		if req.TableNumber <= 0 {
+			return errors.New("Número da mesa é obrigatório para pedidos no salão")
+		}
+		if req.ScheduledFor != nil {
+			return errors.New("Pedidos no salão não podem ser agendados")
+		}
+	case orderTypeTakeaway:
+		if req.PickupName == "" {
+			req.PickupName = strings.TrimSpace(req.CustomerName)
+		}
+		if req.PickupName == "" && req.CustomerPhone == "" && !inferred {
+			return errors.New("Informe o nome ou o telefone de quem vai retirar o pedido")
+		}
+		req.TableNumber = 0
+	case orderTypeDelivery:
+		// Endereço salvo já traz endereço e telefone do cadastro
+		if req.DeliveryAddressID <= 0 {
+			if req.DeliveryAddress == "" {
+				return errors.New("Endereço de entrega é obrigatório para pedidos de entrega")
+			}
+			if req.CustomerPhone == "" {
+				return errors.New("Telefone é obrigatório para pedidos de entrega")
+			}
+		}
+		req.TableNumber = 0
+	default:
+		return errors.New("Tipo de pedido inválido (use dine_in, takeaway ou delivery)")
+	}
+	return nil
+}
+
+// normalizePhone mantém apenas os dígitos do telefone
+func normalizePhone(phone string) string {
+	var digits strings.Builder
+	for _, r := range phone {
+		if r >= '0' && r <= '9' {
+			digits.WriteRune(r)
+		}
+	}
+	return digits.String()
+}
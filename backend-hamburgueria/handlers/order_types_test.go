@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"backend-hamburgueria/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// Teste da validação por tipo de pedido
+func TestValidateOrderType(t *testing.T) {
+	// Sem tipo: mesa > 0 é salão, mesa 0 é retirada com o nome do cliente
+	req := models.CreateOrderRequest{CustomerName: "Ana", TableNumber: 4}
+	assert.NoError(t, validateOrderType(&req))
+	assert.Equal(t, orderTypeDineIn, req.OrderType)
+
+	req = models.CreateOrderRequest{CustomerName: "Ana"}
+	assert.NoError(t, validateOrderType(&req))
+	assert.Equal(t, orderTypeTakeaway, req.OrderType)
+	assert.Equal(t, "Ana", req.PickupName)
+
+	// Sem tipo e sem nome: retirada deduzida segue aceita; com o tipo informado, não
+	req = models.CreateOrderRequest{}
+	assert.NoError(t, validateOrderType(&req))
+	assert.Equal(t, orderTypeTakeaway, req.OrderType)
+	req = models.CreateOrderRequest{OrderType: "takeaway"}
+	assert.Error(t, validateOrderType(&req))
+
+	// Salão sem mesa
+	req = models.CreateOrderRequest{OrderType: "dine_in"}
+	assert.Error(t, validateOrderType(&req))
+
+	// Entrega exige endereço e telefone; o telefone é normalizado e a mesa zerada
+	req = models.CreateOrderRequest{OrderType: "delivery", TableNumber: 3, DeliveryAddress: "Rua A, 10"}
+	assert.Error(t, validateOrderType(&req))
+	req.CustomerPhone = "(11) 98765-4321"
+	assert.NoError(t, validateOrderType(&req))
+	assert.Equal(t, "11987654321", req.CustomerPhone)
+	assert.Equal(t, 0, req.TableNumber)
+
+	// Telefone curto demais e tipo desconhecido
+	req = models.CreateOrderRequest{OrderType: "takeaway", CustomerPhone: "1234"}
+	assert.Error(t, validateOrderType(&req))
+	req = models.CreateOrderRequest{OrderType: "drive_thru", TableNumber: 1}
+	assert.Error(t, validateOrderType(&req))
+}
+
+// Teste para CreateOrder rejeitando entrega sem endereço antes de tocar no banco
+func TestCreateOrderMissingDeliveryAddress(t *testing.T) {
+	router, mockDB := setupTest()
+
+	router.POST("/orders", func(c *gin.Context) {
+		CreateOrder(c, mockDB)
+	})
+
+	body := `{"order_type": "delivery", "customer_phone": "11987654321", "items": [{"product_id": 2, "quantity": 1}]}`
+	req, _ := http.NewRequest("POST", "/orders", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+// Teste para CreateOrder com o corpo usado pelo balcão antes dos tipos de pedido:
+// mesa 0 sem nome nem telefone segue aceito como retirada e chega ao banco
+func TestCreateOrderLegacyCounterBody(t *testing.T) {
+	router, mockDB := setupTest()
+
+	router.POST("/orders", func(c *gin.Context) {
+		CreateOrder(c, mockDB)
+	})
+	mockDB.BeginFunc = func() (*sql.Tx, error) {
+		return nil, sql.ErrConnDone
+	}
+
+	body := `{"customer_name": "", "table_number": 0, "items": [{"product_id": 2, "quantity": 1}], "notes": ""}`
+	req, _ := http.NewRequest("POST", "/orders", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Contains(t, w.Body.String(), "Erro ao iniciar transação")
+
+	// Retirada informada explicitamente continua exigindo nome ou telefone
+	body = `{"order_type": "takeaway", "table_number": 0, "items": [{"product_id": 2, "quantity": 1}]}`
+	req, _ = http.NewRequest("POST", "/orders", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+// Teste para GetOrders com filtro de tipo inválido
+func TestGetOrdersInvalidType(t *testing.T) {
+	router, mockDB := setupTest()
+
+	router.GET("/orders", func(c *gin.Context) {
+		GetOrders(c, mockDB)
+	})
+
+	req, _ := http.NewRequest("GET", "/orders?type=drive_thru", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+// Teste para pedido no salão com horário agendado
+func TestValidateOrderTypeDineInScheduled(t *testing.T) {
+	later := time.Now().Add(2 * time.Hour)
+	req := models.CreateOrderRequest{OrderType: "dine_in", TableNumber: 3, ScheduledFor: &later}
+	assert.Error(t, validateOrderType(&req))
+}
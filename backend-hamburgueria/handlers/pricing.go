@@ -0,0 +1,406 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"sort"
+	"time"
+
+	// Configurações da aplicação (taxa de serviço)
+	"backend-hamburgueria/config"
+	// Modelos de dados da aplicação
+	"backend-hamburgueria/models"
+
+	// Framework web Gin
+	"github.com/gin-gonic/gin"
+	// Driver do PostgreSQL (arrays)
+	"github.com/lib/pq"
+)
+
+// ===== PREÇO DO PEDIDO =====
+
+// customBurgerProductID é o produto dos lanches montados pelo cliente
+// O preço é a soma dos ingredientes escolhidos
+const customBurgerProductID = 1
+
+// Erros do cálculo de preço
+var (
+	errProductNotFound       = errors.New("Produto não encontrado")
+	errInvalidTip            = errors.New("Gorjeta inválida")
+	errInvalidQuantity       = errors.New("Quantidade inválida")
+	errCustomIngredients     = errors.New("Escolha os ingredientes do lanche personalizado")
+	errIngredientUnavailable = errors.New("Ingrediente indisponível")
+)
+
+// minOrderError indica um pedido de entrega abaixo do mínimo da zona
+type minOrderError struct {
+	MinOrder float64
+}
+
+func (e *minOrderError) Error() string {
+	return "Pedido abaixo do valor mínimo para entrega nesta região"
+}
+
+// pricedLine é um item do pedido com o preço calculado
+type pricedLine struct {
+	Item        models.OrderItemRequest
+	Index       int                    // Posição do item no pedido enviado (-1 nos combos automáticos)
+	Station     string                 // Estação da cozinha
+	CategoryID  int                    // Categoria do produto (cupons por categoria)
+	UnitPrice   float64                // Preço unitário
+	TotalPrice  float64                // Preço da linha
+	Modifiers   []models.PriceModifier // Ingredientes do lanche personalizado
+	Discount    int64                  // Desconto das promoções no item, em centavos
+	Promotions  []models.LinePromotion // Promoções aplicadas no item
+	Components  []bundlePart           // Produtos escolhidos (combos)
+	ComboSaving int64                  // Economia do combo montado automaticamente, em centavos
+	Variant     *models.ProductVariant // Variação escolhida (tamanho)
+	Allergens   []string               // Alérgenos do item, considerando a customização
+	Allergies   []string               // Alergias declaradas para o item (pedido + item)
+	// Alérgenos do item que o cliente declarou ter alergia
+	AllergenConflicts []string
+}
+
+// orderPricing é o resultado do cálculo de preço de um pedido
+type orderPricing struct {
+	Lines     []pricedLine
+	Breakdown models.PriceBreakdown
+	Delivery  orderDelivery  // Zona e taxa (somente entregas)
+	Coupon    *appliedCoupon // Cupom aceito (resgatado ao gravar o pedido)
+	// Economia dos combos montados automaticamente com itens avulsos, em centavos
+	ComboSaving int64
+}
+
+// priceOrder calcula os itens, a taxa de entrega, a taxa de serviço e o total do pedido
+// Valores somados em centavos para que o total feche com a composição gravada
+func priceOrder(q queryer, req *models.CreateOrderRequest) (orderPricing, error) {
+	var pricing orderPricing
+	if req.Tip < 0 {
+		return pricing, errInvalidTip
+	}
+	if err := normalizeOrderAllergies(req); err != nil {
+		return pricing, err
+	}
+
+	// ===== ITENS =====
+	for i, item := range req.Items {
+		line, err := priceLine(q, item)
+		if err != nil {
+			return pricing, err
+		}
+		line.Index = i
+		pricing.Lines = append(pricing.Lines, line)
+	}
+
+	// ===== HORÁRIO DE VENDA =====
+	// Itens fora da janela de venda (café da manhã, madrugada...) são recusados
+	// Pedidos agendados são conferidos no horário escolhido
+	availableAt := pricingTime(req, time.Now())
+	var schedules menuSchedules
+	if len(pricing.Lines) > 0 {
+		loaded, err := loadMenuSchedules(q)
+		if err != nil {
+			return pricing, err
+		}
+		if err := loaded.checkLines(pricing.Lines, availableAt); err != nil {
+			return pricing, err
+		}
+		schedules = loaded
+	}
+
+	// ===== COMBOS AUTOMÁTICOS =====
+	// Itens avulsos que formam um combo mais barato (e no horário de venda) são reagrupados no combo
+	lines, comboSaving, err := regroupCombos(q, pricing.Lines, func(productID, categoryID int) bool {
+		return schedules.productAvailable(productID, categoryID, availableAt)
+	})
+	if err != nil {
+		return pricing, err
+	}
+	pricing.Lines = lines
+	pricing.ComboSaving = comboSaving
+
+	// ===== ALÉRGENOS =====
+	// Calculados sobre os itens finais (receita, ingredientes escolhidos ou produtos do combo)
+	// e conferidos com as alergias declaradas pelo cliente
+	if len(pricing.Lines) > 0 {
+		dietary, err := loadMenuDietary(q)
+		if err != nil {
+			return pricing, err
+		}
+		applyAllergies(pricing.Lines, dietary, req.Allergies)
+	}
+
+	var subtotal int64
+	for _, line := range pricing.Lines {
+		subtotal += toCents(line.TotalPrice)
+	}
+
+	// ===== ENDEREÇO DE ENTREGA =====
+	// Entregas: localizar a zona do endereço antes dos descontos, porque o endereço salvo
+	// define o telefone do cliente usado nos limites de uso do cupom
+	if req.OrderType == orderTypeDelivery {
+		delivery, err := resolveOrderDelivery(q, req)
+		if err != nil {
+			return pricing, err
+		}
+		pricing.Delivery = delivery
+	}
+
+	// ===== DESCONTOS =====
+	// Promoções do horário do pedido (melhor combinação sem conflito entre os itens);
+	// pedidos agendados usam as promoções do horário escolhido
+	discount, err := applyPromotions(q, pricing.Lines, availableAt)
+	if err != nil {
+		return pricing, err
+	}
+	// Cupom informado: validade, pedido mínimo, limites de uso e itens participantes,
+	// calculado sobre os itens já com as promoções
+	if req.CouponCode != "" {
+		coupon, err := applyCoupon(q, req, pricing.Lines, subtotal-discount)
+		if err != nil {
+			return pricing, err
+		}
+		discount += coupon.Discount
+		pricing.Coupon = &coupon
+	}
+
+	// ===== TAXA DE ENTREGA =====
+	// Pedido mínimo da zona conferido após os descontos
+	var deliveryFee int64
+	if req.OrderType == orderTypeDelivery {
+		if subtotal-discount < toCents(pricing.Delivery.Quote.MinOrder) {
+			return pricing, &minOrderError{MinOrder: pricing.Delivery.Quote.MinOrder}
+		}
+		deliveryFee = toCents(pricing.Delivery.Quote.DeliveryFee)
+	}
+
+	// ===== TAXA DE SERVIÇO E GORJETA =====
+	var serviceCharge int64
+	if req.IncludeServiceCharge == nil || *req.IncludeServiceCharge {
+		percent := config.ServiceChargePercent(req.OrderType)
+		serviceCharge = int64(math.Round(float64(subtotal-discount) * float64(percent) / 100))
+	}
+	tip := toCents(req.Tip)
+
+	pricing.Breakdown = models.PriceBreakdown{
+		Subtotal:      fromCents(subtotal),
+		Discount:      fromCents(discount),
+		ServiceCharge: fromCents(serviceCharge),
+		DeliveryFee:   fromCents(deliveryFee),
+		Tip:           fromCents(tip),
+		Total:         fromCents(subtotal - discount + serviceCharge + deliveryFee + tip),
+	}
+	return pricing, nil
+}
+
+// pricingTime é o horário em que o pedido é vendido: o escolhido, nos pedidos agendados
+// Usado na janela de venda dos itens, nos combos e nas promoções
+func pricingTime(req *models.CreateOrderRequest, now time.Time) time.Time {
+	if req.ScheduledFor != nil {
+		return *req.ScheduledFor
+	}
+	return now
+}
+
+// discounts lista os descontos aplicados no cálculo (exibidos na cotação e na criação do pedido)
+func (p orderPricing) discounts() []models.AppliedDiscount {
+	var discounts []models.AppliedDiscount
+	for _, line := range p.Lines {
+		for _, promotion := range line.Promotions {
+			if promotion.Discount == 0 {
+				continue
+			}
+			found := false
+			for i := range discounts {
+				if discounts[i].PromotionID == promotion.PromotionID {
+					discounts[i].Amount = fromCents(toCents(discounts[i].Amount) + toCents(promotion.Discount))
+					found = true
+					break
+				}
+			}
+			if !found {
+				discounts = append(discounts, models.AppliedDiscount{
+					Source:      "promotion",
+					PromotionID: promotion.PromotionID,
+					Name:        promotion.Name,
+					Amount:      promotion.Discount,
+				})
+			}
+		}
+	}
+	if p.Coupon != nil {
+		discounts = append(discounts, models.AppliedDiscount{
+			Source: "coupon",
+			Code:   p.Coupon.Coupon.Code,
+			Name:   p.Coupon.Coupon.Description,
+			Amount: fromCents(p.Coupon.Discount),
+		})
+	}
+	return discounts
+}
+
+// priceLine calcula o preço de um item do pedido
+func priceLine(q queryer, item models.OrderItemRequest) (pricedLine, error) {
+	line := pricedLine{Item: item}
+	if item.Quantity <= 0 {
+		return line, errInvalidQuantity
+	}
+	if item.ProductID == customBurgerProductID {
+		if item.VariantID != nil {
+			return line, errVariantNotFound
+		}
+		// Lanche personalizado: soma dos ingredientes, com o preço do banco (não o do frontend)
+		modifiers, err := priceCustomIngredients(q, item.Ingredients)
+		if err != nil {
+			return line, err
+		}
+		var unit int64
+		for _, modifier := range modifiers {
+			unit += toCents(modifier.Price)
+		}
+		line.Station = defaultStation
+		line.Modifiers = modifiers
+		line.UnitPrice = fromCents(unit)
+		// Categoria do produto base (sem cadastro, o lanche fica fora dos cupons por categoria)
+		err = q.QueryRow("SELECT COALESCE(category_id, 0) FROM products WHERE id = $1", customBurgerProductID).Scan(&line.CategoryID)
+		if err != nil && err != sql.ErrNoRows {
+			return line, err
+		}
+	} else {
+		// Buscar preço, estação e categoria do produto no banco
+		var isBundle bool
+		err := q.QueryRow("SELECT price, station, COALESCE(category_id, 0), is_bundle FROM products WHERE id = $1", item.ProductID).
+			Scan(&line.UnitPrice, &line.Station, &line.CategoryID, &isBundle)
+		if err == sql.ErrNoRows {
+			return line, errProductNotFound
+		}
+		if err != nil {
+			return line, err
+		}
+		// Combo: preço base + acréscimos das escolhas
+		if isBundle {
+			if item.VariantID != nil {
+				return line, errVariantNotFound
+			}
+			if err := priceBundle(q, &line, line.UnitPrice); err != nil {
+				return line, err
+			}
+		} else if len(item.Choices) > 0 {
+			return line, errNotBundle
+		} else if err := priceVariant(q, &line); err != nil {
+			// Produto com tamanhos: preço da variação escolhida
+			return line, err
+		}
+	}
+	line.TotalPrice = fromCents(toCents(line.UnitPrice) * int64(item.Quantity))
+	return line, nil
+}
+
+// toCents converte um valor em reais para centavos
+func toCents(value float64) int64 {
+	return int64(math.Round(value * 100))
+}
+
+// fromCents converte centavos para reais
+func fromCents(cents int64) float64 {
+	return float64(cents) / 100
+}
+
+// respondPricingError converte um erro de priceOrder na resposta HTTP
+func respondPricingError(c *gin.Context, err error) {
+	var minOrder *minOrderError
+	var outOfSchedule *outOfScheduleError
+	var invalidTag *invalidTagError
+	switch {
+	case errors.As(err, &minOrder):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "min_order": minOrder.MinOrder})
+	case errors.As(err, &outOfSchedule):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "product_id": outOfSchedule.ProductID})
+	case errors.As(err, &invalidTag):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case err == errProductNotFound || err == errInvalidTip || err == errInvalidQuantity ||
+		err == errCustomIngredients || err == errIngredientUnavailable || err == errNotBundle ||
+		err == errBundleChoice || err == errBundleIncomplete || err == errBundleOptionUnavailable || err == errBundleOptionVariant ||
+		err == errVariantRequired || err == errVariantNotFound || err == errVariantUnavailable:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case isCouponError(err):
+		respondCouponError(c, err)
+	case err == errAddressNotFound || err == errAddressWithoutLocation || err == errOutsideDeliveryArea:
+		respondDeliveryError(c, err)
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao calcular preço do pedido"})
+	}
+}
+
+// priceCustomIngredients lê os ingredientes escolhidos no lanche personalizado
+// O frontend envia um JSON por etapa ({"bread": {"id": 1, ...}, "meat": {...}}); só os IDs são
+// usados, e o preço vem do cadastro de ingredientes. Etapas vazias (null) são ignoradas.
+func priceCustomIngredients(q queryer, raw string) ([]models.PriceModifier, error) {
+	ids, err := parseCustomIngredients(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := q.Query(`
+		SELECT id, name, price FROM ingredients WHERE id = ANY($1) AND is_available = true
+	`, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	available := make(map[int]models.PriceModifier)
+	for rows.Next() {
+		var modifier models.PriceModifier
+		if err := rows.Scan(&modifier.IngredientID, &modifier.Name, &modifier.Price); err != nil {
+			return nil, err
+		}
+		available[modifier.IngredientID] = modifier
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	modifiers := make([]models.PriceModifier, 0, len(ids))
+	for _, id := range ids {
+		modifier, ok := available[id]
+		if !ok {
+			return nil, errIngredientUnavailable
+		}
+		modifiers = append(modifiers, modifier)
+	}
+	return modifiers, nil
+}
+
+// parseCustomIngredients extrai os IDs dos ingredientes, na ordem alfabética das etapas
+func parseCustomIngredients(raw string) ([]int, error) {
+	var steps map[string]*struct {
+		ID int `json:"id"`
+	}
+	if raw == "" || json.Unmarshal([]byte(raw), &steps) != nil {
+		return nil, errCustomIngredients
+	}
+
+	names := make([]string, 0, len(steps))
+	for name := range steps {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var ids []int
+	for _, name := range names {
+		if step := steps[name]; step != nil {
+			if step.ID <= 0 {
+				return nil, errCustomIngredients
+			}
+			ids = append(ids, step.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil, errCustomIngredients
+	}
+	return ids, nil
+}
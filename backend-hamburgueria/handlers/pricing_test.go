@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"backend-hamburgueria/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// Teste da gorjeta negativa, recusada antes de consultar o banco
+func TestPriceOrderInvalidTip(t *testing.T) {
+	_, mockDB := setupTest()
+
+	_, err := priceOrder(mockDB, &models.CreateOrderRequest{OrderType: orderTypeTakeaway, Tip: -1})
+	assert.Equal(t, errInvalidTip, err)
+}
+
+// Teste do preço sem itens: só a gorjeta compõe o total
+func TestPriceOrderTipOnly(t *testing.T) {
+	_, mockDB := setupTest()
+	t.Setenv("SERVICE_CHARGE_TAKEAWAY_PERCENT", "")
+
+	pricing, err := priceOrder(mockDB, &models.CreateOrderRequest{OrderType: orderTypeTakeaway, Tip: 2.5})
+	assert.NoError(t, err)
+	assert.Equal(t, 2.5, pricing.Breakdown.Tip)
+	assert.Equal(t, 2.5, pricing.Breakdown.Total)
+}
+
+// Teste da ordem do cálculo: o endereço de entrega é resolvido antes do cupom,
+// porque o endereço salvo é que informa o telefone usado nos limites por cliente
+func TestPriceOrderResolvesDeliveryBeforeCoupon(t *testing.T) {
+	_, mockDB := setupTest()
+	mockDB.QueryRowFunc = func(query string, args ...interface{}) *sql.Row {
+		t.Fatalf("cupom consultado antes do endereço de entrega: %s", query)
+		return nil
+	}
+
+	_, err := priceOrder(mockDB, &models.CreateOrderRequest{OrderType: orderTypeDelivery, CouponCode: "BEMVINDO"})
+	assert.Equal(t, errAddressWithoutLocation, err)
+}
+
+// Teste da conversão dos erros de preço em respostas HTTP
+func TestRespondPricingError(t *testing.T) {
+	cases := map[error]int{
+		errProductNotFound:           http.StatusBadRequest,
+		&minOrderError{MinOrder: 30}: http.StatusBadRequest,
+		errAddressNotFound:           http.StatusNotFound,
+		errOutsideDeliveryArea:       http.StatusBadRequest,
+		errCouponNotFound:            http.StatusBadRequest,
+		errCouponExhausted:           http.StatusConflict,
+		assert.AnError:               http.StatusInternalServerError,
+	}
+	for err, code := range cases {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		respondPricingError(c, err)
+		assert.Equal(t, code, w.Code, err.Error())
+	}
+}
+
+// Teste da conversão entre reais e centavos
+func TestCents(t *testing.T) {
+	assert.Equal(t, int64(1990), toCents(19.9))
+	assert.Equal(t, int64(30), toCents(0.1+0.2))
+	assert.Equal(t, 19.9, fromCents(1990))
+}
+
+// Teste da leitura dos ingredientes do lanche personalizado
+func TestParseCustomIngredients(t *testing.T) {
+	ids, err := parseCustomIngredients(`{"meat": {"id": 4, "price": 0.01}, "bread": {"id": 1}, "cheese": null}`)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 4}, ids)
+
+	for _, raw := range []string{"", "texto", `{}`, `{"bread": null}`, `{"bread": {"id": 0}}`} {
+		_, err := parseCustomIngredients(raw)
+		assert.Equal(t, errCustomIngredients, err, raw)
+	}
+}
+
+// Teste de quantidade inválida, recusada antes de consultar o banco
+func TestPriceOrderInvalidQuantity(t *testing.T) {
+	_, mockDB := setupTest()
+
+	_, err := priceOrder(mockDB, &models.CreateOrderRequest{Items: []models.OrderItemRequest{{ProductID: 2, Quantity: 0}}})
+	assert.Equal(t, errInvalidQuantity, err)
+}
+
+// Teste para QuoteOrder com QR inválido e falha no banco
+func TestQuoteOrder(t *testing.T) {
+	router, mockDB := setupTest()
+
+	router.POST("/orders/quote", func(c *gin.Context) {
+		QuoteOrder(c, mockDB)
+	})
+
+	req, _ := http.NewRequest("POST", "/orders/quote", strings.NewReader(`{"table_token": "3-abc", "items": []}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	mockDB.QueryFunc = func(query string, args ...interface{}) (*sql.Rows, error) {
+		return nil, sql.ErrConnDone
+	}
+	body := `{"customer_name": "Ana", "items": [{"product_id": 1, "quantity": 2, "ingredients": "{\"bread\": {\"id\": 1}}"}]}`
+	req, _ = http.NewRequest("POST", "/orders/quote", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	// A cotação confere a loja aberta antes do preço, como a criação do pedido
+	assert.Contains(t, w.Body.String(), "Erro ao verificar horário da loja")
+}
@@ -0,0 +1,517 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	// Configurações da aplicação (fuso horário da loja)
+	"backend-hamburgueria/config"
+	// Modelos de dados da aplicação
+	"backend-hamburgueria/models"
+
+	// Framework web Gin
+	"github.com/gin-gonic/gin"
+	// Driver do PostgreSQL (arrays)
+	"github.com/lib/pq"
+)
+
+// ===== PROMOÇÕES AUTOMÁTICAS =====
+
+// Tipos de promoção
+const (
+	promotionPercentage = "percentage"  // Percentual sobre os itens participantes
+	promotionFixedPrice = "fixed_price" // Preço promocional por unidade (ex.: happy hour)
+	promotionBuyXGetY   = "buy_x_get_y" // Leve X, ganhe Y (brinde com value% de desconto)
+)
+
+// maxPromotionSearch é o número de promoções aplicáveis até o qual todas as ordens de
+// aplicação são testadas; acima disso a escolha é gulosa (maior economia primeiro)
+const maxPromotionSearch = 6
+
+// promotionColumns são as colunas lidas por scanPromotion
+const promotionColumns = `id, name, kind, value, product_ids, category_ids, buy_quantity, get_quantity,
+	reward_product_ids, reward_category_ids, weekdays, COALESCE(to_char(start_time, 'HH24:MI'), ''),
+	COALESCE(to_char(end_time, 'HH24:MI'), ''), starts_at, ends_at, is_active, created_at`
+
+// promoUnit é uma unidade de um item do pedido, disputada pelas promoções
+type promoUnit struct {
+	Line  int   // Índice do item em pricing.Lines
+	Price int64 // Preço unitário em centavos
+}
+
+// promoClaim é uma unidade usada por uma promoção, com o desconto concedido nela
+type promoClaim struct {
+	Promotion int   // Índice da promoção na lista avaliada
+	Unit      int   // Índice da unidade
+	Discount  int64 // Desconto em centavos (zero nas unidades que só ativam a promoção)
+}
+
+// GetPromotions godoc
+// @Summary      Lista as promoções
+// @Description  Retorna as promoções automáticas cadastradas
+// @Tags         Promotions
+// @Produce      json
+// @Success      200  {array}   models.Promotion
+// @Failure      500  {object}  models.ErrorResponse "Erro ao buscar promoções"
+// @Router       /api/promotions [get]
+func GetPromotions(c *gin.Context, db DBInterface) {
+	promotions, err := loadPromotions(db, false)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar promoções"})
+		return
+	}
+	c.JSON(http.StatusOK, promotions)
+}
+
+// CreatePromotion godoc
+// @Summary      Cria uma promoção
+// @Description  Cadastra uma promoção automática: percentual, preço promocional ou leve X ganhe Y, com dias e horário
+// @Tags         Promotions
+// @Accept       json
+// @Produce      json
+// @Param        body  body      models.Promotion  true  "Promoção"
+// @Success      201   {object}  models.Promotion
+// @Failure      400   {object}  models.ErrorResponse "Dados inválidos"
+// @Failure      500   {object}  models.ErrorResponse "Erro ao criar promoção"
+// @Router       /api/promotions [post]
+func CreatePromotion(c *gin.Context, db DBInterface) {
+	// ===== VALIDAR DADOS DE ENTRADA =====
+	var promotion models.Promotion
+	if err := c.ShouldBindJSON(&promotion); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos"})
+		return
+	}
+	if err := validatePromotion(&promotion); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// ===== INSERIR PROMOÇÃO =====
+	row := db.QueryRow(`
+		INSERT INTO promotions (name, kind, value, product_ids, category_ids, buy_quantity, get_quantity,
+			reward_product_ids, reward_category_ids, weekdays, start_time, end_time, starts_at, ends_at, is_active)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, NULLIF($11, '')::time, NULLIF($12, '')::time, $13, $14, TRUE)
+		RETURNING `+promotionColumns,
+		promotion.Name, promotion.Kind, promotion.Value, pq.Array(promotion.ProductIDs), pq.Array(promotion.CategoryIDs),
+		promotion.BuyQuantity, promotion.GetQuantity, pq.Array(promotion.RewardProductIDs), pq.Array(promotion.RewardCategoryIDs),
+		pq.Array(promotion.Weekdays), promotion.StartTime, promotion.EndTime, promotion.StartsAt, promotion.EndsAt)
+	created, err := scanPromotion(row)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao criar promoção"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, created)
+}
+
+// UpdatePromotion godoc
+// @Summary      Altera uma promoção
+// @Description  Substitui as regras da promoção (is_active=false desativa)
+// @Tags         Promotions
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int               true  "ID da promoção"
+// @Param        body  body      models.Promotion  true  "Promoção"
+// @Success      200   {object}  models.Promotion
+// @Failure      400   {object}  models.ErrorResponse "Dados inválidos"
+// @Failure      404   {object}  models.ErrorResponse "Promoção não encontrada"
+// @Router       /api/promotions/{id} [put]
+func UpdatePromotion(c *gin.Context, db DBInterface) {
+	// ===== VALIDAR DADOS DE ENTRADA =====
+	promotionID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+	// Sem is_active no corpo, a promoção continua ativa
+	promotion := models.Promotion{IsActive: true}
+	if err := c.ShouldBindJSON(&promotion); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos"})
+		return
+	}
+	if err := validatePromotion(&promotion); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// ===== ATUALIZAR PROMOÇÃO =====
+	row := db.QueryRow(`
+		UPDATE promotions SET name = $2, kind = $3, value = $4, product_ids = $5, category_ids = $6,
+			buy_quantity = $7, get_quantity = $8, reward_product_ids = $9, reward_category_ids = $10, weekdays = $11,
+			start_time = NULLIF($12, '')::time, end_time = NULLIF($13, '')::time, starts_at = $14, ends_at = $15, is_active = $16
+		WHERE id = $1
+		RETURNING `+promotionColumns,
+		promotionID, promotion.Name, promotion.Kind, promotion.Value, pq.Array(promotion.ProductIDs), pq.Array(promotion.CategoryIDs),
+		promotion.BuyQuantity, promotion.GetQuantity, pq.Array(promotion.RewardProductIDs), pq.Array(promotion.RewardCategoryIDs),
+		pq.Array(promotion.Weekdays), promotion.StartTime, promotion.EndTime, promotion.StartsAt, promotion.EndsAt, promotion.IsActive)
+	updated, err := scanPromotion(row)
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Promoção não encontrada"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao atualizar promoção"})
+		return
+	}
+
+	c.JSON(http.StatusOK, updated)
+}
+
+// ===== FUNÇÕES AUXILIARES =====
+
+// validatePromotion confere os campos da promoção conforme o tipo
+func validatePromotion(promotion *models.Promotion) error {
+	promotion.Name = strings.TrimSpace(promotion.Name)
+	if promotion.Name == "" {
+		return errors.New("Nome da promoção é obrigatório")
+	}
+	switch promotion.Kind {
+	case promotionPercentage:
+		if promotion.Value <= 0 || promotion.Value > 100 {
+			return errors.New("Percentual da promoção deve estar entre 0 e 100")
+		}
+	case promotionFixedPrice:
+		if promotion.Value < 0 {
+			return errors.New("Preço promocional não pode ser negativo")
+		}
+	case promotionBuyXGetY:
+		if promotion.BuyQuantity <= 0 || promotion.GetQuantity <= 0 {
+			return errors.New("Informe buy_quantity e get_quantity")
+		}
+		if promotion.Value == 0 {
+			promotion.Value = 100 // Brinde grátis
+		}
+		if promotion.Value < 0 || promotion.Value > 100 {
+			return errors.New("Desconto do brinde deve estar entre 0 e 100")
+		}
+	default:
+		return errors.New("Tipo de promoção inválido (use percentage, fixed_price ou buy_x_get_y)")
+	}
+	if promotion.Kind != promotionBuyXGetY {
+		promotion.BuyQuantity, promotion.GetQuantity = 0, 0
+		promotion.RewardProductIDs, promotion.RewardCategoryIDs = nil, nil
+	}
+	for _, day := range promotion.Weekdays {
+		if day < 0 || day > 6 {
+			return errors.New("Dias da semana vão de 0 (domingo) a 6 (sábado)")
+		}
+	}
+	if (promotion.StartTime == "") != (promotion.EndTime == "") {
+		return errors.New("Informe o início e o fim do horário da promoção")
+	}
+	if promotion.StartTime != "" {
+		if _, err := time.Parse("15:04", promotion.StartTime); err != nil {
+			return errors.New("Horário inválido (use HH:MM)")
+		}
+		if _, err := time.Parse("15:04", promotion.EndTime); err != nil {
+			return errors.New("Horário inválido (use HH:MM)")
+		}
+	}
+	if promotion.StartsAt != nil && promotion.EndsAt != nil && !promotion.EndsAt.After(*promotion.StartsAt) {
+		return errors.New("Fim da campanha deve ser depois do início")
+	}
+	for _, ids := range []*[]int{&promotion.ProductIDs, &promotion.CategoryIDs, &promotion.RewardProductIDs,
+		&promotion.RewardCategoryIDs, &promotion.Weekdays} {
+		if *ids == nil {
+			*ids = []int{}
+		}
+	}
+	return nil
+}
+
+// scanPromotion lê uma promoção nas colunas de promotionColumns
+func scanPromotion(row rowScanner) (models.Promotion, error) {
+	var promotion models.Promotion
+	var productIDs, categoryIDs, rewardProductIDs, rewardCategoryIDs, weekdays pq.Int64Array
+	err := row.Scan(&promotion.ID, &promotion.Name, &promotion.Kind, &promotion.Value, &productIDs, &categoryIDs,
+		&promotion.BuyQuantity, &promotion.GetQuantity, &rewardProductIDs, &rewardCategoryIDs, &weekdays,
+		&promotion.StartTime, &promotion.EndTime, &promotion.StartsAt, &promotion.EndsAt, &promotion.IsActive, &promotion.CreatedAt)
+	if err != nil {
+		return promotion, err
+	}
+	promotion.ProductIDs = intsFromArray(productIDs)
+	promotion.CategoryIDs = intsFromArray(categoryIDs)
+	promotion.RewardProductIDs = intsFromArray(rewardProductIDs)
+	promotion.RewardCategoryIDs = intsFromArray(rewardCategoryIDs)
+	promotion.Weekdays = intsFromArray(weekdays)
+	return promotion, nil
+}
+
+// loadPromotions busca as promoções cadastradas (somente as ativas, se pedido)
+func loadPromotions(q queryer, activeOnly bool) ([]models.Promotion, error) {
+	rows, err := q.Query(`
+		SELECT `+promotionColumns+` FROM promotions
+		WHERE is_active OR NOT $1
+		ORDER BY id
+	`, activeOnly)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	promotions := []models.Promotion{}
+	for rows.Next() {
+		promotion, err := scanPromotion(rows)
+		if err != nil {
+			return nil, err
+		}
+		promotions = append(promotions, promotion)
+	}
+	return promotions, rows.Err()
+}
+
+// applyPromotions avalia as promoções ativas no momento e grava o desconto e as promoções de cada item
+// Retorna o desconto total em centavos
+func applyPromotions(q queryer, lines []pricedLine, now time.Time) (int64, error) {
+	if len(lines) == 0 {
+		return 0, nil
+	}
+	all, err := loadPromotions(q, true)
+	if err != nil {
+		return 0, err
+	}
+	var current []models.Promotion
+	for _, promotion := range all {
+		if promotionRunning(promotion, now) {
+			current = append(current, promotion)
+		}
+	}
+	return distributePromotions(current, lines), nil
+}
+
+// promotionRunning indica se a promoção vale no momento (campanha, dia da semana e horário da loja)
+func promotionRunning(promotion models.Promotion, now time.Time) bool {
+	if (promotion.StartsAt != nil && now.Before(*promotion.StartsAt)) || (promotion.EndsAt != nil && !now.Before(*promotion.EndsAt)) {
+		return false
+	}
+	local := now.In(config.StoreLocation())
+	if len(promotion.Weekdays) > 0 && !containsInt(promotion.Weekdays, int(local.Weekday())) {
+		return false
+	}
+	if promotion.StartTime == "" {
+		return true
+	}
+	inside, _ := inClockWindow(promotion.StartTime, promotion.EndTime, local)
+	return inside
+}
+
+// distributePromotions escolhe o melhor conjunto de promoções sem conflito e grava o resultado nos itens
+// Cada unidade de um item entra em no máximo uma promoção (inclusive as que só ativam o "leve X ganhe Y")
+func distributePromotions(promotions []models.Promotion, lines []pricedLine) int64 {
+	var units []promoUnit
+	for i, line := range lines {
+		for n := 0; n < line.Item.Quantity; n++ {
+			units = append(units, promoUnit{Line: i, Price: toCents(line.UnitPrice)})
+		}
+	}
+
+	claims := bestPromotionClaims(promotions, lines, units)
+
+	// ===== RESULTADO POR ITEM =====
+	var total int64
+	for _, claim := range claims {
+		line := &lines[units[claim.Unit].Line]
+		promotion := promotions[claim.Promotion]
+		line.Discount += claim.Discount
+		total += claim.Discount
+
+		found := false
+		for i := range line.Promotions {
+			if line.Promotions[i].PromotionID == promotion.ID {
+				line.Promotions[i].Quantity++
+				line.Promotions[i].Discount = fromCents(toCents(line.Promotions[i].Discount) + claim.Discount)
+				found = true
+				break
+			}
+		}
+		if !found {
+			line.Promotions = append(line.Promotions, models.LinePromotion{
+				PromotionID: promotion.ID,
+				Name:        promotion.Name,
+				Quantity:    1,
+				Discount:    fromCents(claim.Discount),
+			})
+		}
+	}
+	return total
+}
+
+// bestPromotionClaims testa as ordens de aplicação das promoções e fica com a de maior desconto
+// Com muitas promoções aplicáveis, aplica de forma gulosa a de maior economia a cada passo
+func bestPromotionClaims(promotions []models.Promotion, lines []pricedLine, units []promoUnit) []promoClaim {
+	// Só disputam as promoções que dariam algum desconto sozinhas
+	var candidates []int
+	for i := range promotions {
+		if _, saving := claimPromotion(i, promotions[i], lines, units, make([]bool, len(units))); saving > 0 {
+			candidates = append(candidates, i)
+		}
+	}
+
+	if len(candidates) > maxPromotionSearch {
+		used := make([]bool, len(units))
+		var claims []promoClaim
+		for len(candidates) > 0 {
+			best, bestSaving := -1, int64(0)
+			var bestClaims []promoClaim
+			for k, index := range candidates {
+				found, saving := claimPromotion(index, promotions[index], lines, units, used)
+				if saving > bestSaving {
+					best, bestSaving, bestClaims = k, saving, found
+				}
+			}
+			if best < 0 {
+				break
+			}
+			for _, claim := range bestClaims {
+				used[claim.Unit] = true
+			}
+			claims = append(claims, bestClaims...)
+			candidates = append(candidates[:best], candidates[best+1:]...)
+		}
+		return claims
+	}
+
+	var bestClaims []promoClaim
+	var bestSaving int64
+	permutePromotions(candidates, 0, func(order []int) {
+		used := make([]bool, len(units))
+		var claims []promoClaim
+		var saving int64
+		for _, index := range order {
+			found, s := claimPromotion(index, promotions[index], lines, units, used)
+			if s == 0 {
+				continue
+			}
+			for _, claim := range found {
+				used[claim.Unit] = true
+			}
+			claims = append(claims, found...)
+			saving += s
+		}
+		if saving > bestSaving {
+			bestSaving, bestClaims = saving, claims
+		}
+	})
+	return bestClaims
+}
+
+// permutePromotions chama visit para cada ordem dos índices
+func permutePromotions(order []int, k int, visit func([]int)) {
+	if k >= len(order)-1 {
+		visit(order)
+		return
+	}
+	for i := k; i < len(order); i++ {
+		order[k], order[i] = order[i], order[k]
+		permutePromotions(order, k+1, visit)
+		order[k], order[i] = order[i], order[k]
+	}
+}
+
+// claimPromotion aplica uma promoção às unidades ainda livres e retorna as unidades usadas e o desconto
+func claimPromotion(index int, promotion models.Promotion, lines []pricedLine, units []promoUnit, used []bool) ([]promoClaim, int64) {
+	var claims []promoClaim
+	var saving int64
+	matches := func(unit promoUnit, productIDs, categoryIDs []int) bool {
+		if len(productIDs) == 0 && len(categoryIDs) == 0 {
+			return true
+		}
+		line := lines[unit.Line]
+		return containsInt(productIDs, line.Item.ProductID) || containsInt(categoryIDs, line.CategoryID)
+	}
+
+	switch promotion.Kind {
+	case promotionPercentage, promotionFixedPrice:
+		for u, unit := range units {
+			if used[u] || !matches(unit, promotion.ProductIDs, promotion.CategoryIDs) {
+				continue
+			}
+			var discount int64
+			if promotion.Kind == promotionPercentage {
+				discount = int64(math.Round(float64(unit.Price) * promotion.Value / 100))
+			} else {
+				discount = unit.Price - toCents(promotion.Value)
+			}
+			if discount > 0 {
+				claims = append(claims, promoClaim{Promotion: index, Unit: u, Discount: discount})
+				saving += discount
+			}
+		}
+
+	case promotionBuyXGetY:
+		// Ativam a promoção as unidades mais caras; o brinde são as mais baratas
+		rewardProducts, rewardCategories := promotion.RewardProductIDs, promotion.RewardCategoryIDs
+		if len(rewardProducts) == 0 && len(rewardCategories) == 0 {
+			rewardProducts, rewardCategories = promotion.ProductIDs, promotion.CategoryIDs
+		}
+		var triggers, rewards []int
+		for u, unit := range units {
+			if used[u] {
+				continue
+			}
+			if matches(unit, promotion.ProductIDs, promotion.CategoryIDs) {
+				triggers = append(triggers, u)
+			}
+			if matches(unit, rewardProducts, rewardCategories) {
+				rewards = append(rewards, u)
+			}
+		}
+		sort.SliceStable(triggers, func(a, b int) bool { return units[triggers[a]].Price > units[triggers[b]].Price })
+		sort.SliceStable(rewards, func(a, b int) bool { return units[rewards[a]].Price < units[rewards[b]].Price })
+
+		taken := make(map[int]bool)
+		for {
+			set := pickUnits(triggers, taken, promotion.BuyQuantity)
+			if set == nil {
+				break
+			}
+			for _, u := range set {
+				taken[u] = true
+			}
+			gifts := pickUnits(rewards, taken, promotion.GetQuantity)
+			if gifts == nil {
+				for _, u := range set {
+					delete(taken, u)
+				}
+				break
+			}
+			for _, u := range set {
+				claims = append(claims, promoClaim{Promotion: index, Unit: u})
+			}
+			for _, u := range gifts {
+				taken[u] = true
+				discount := int64(math.Round(float64(units[u].Price) * promotion.Value / 100))
+				claims = append(claims, promoClaim{Promotion: index, Unit: u, Discount: discount})
+				saving += discount
+			}
+		}
+	}
+
+	if saving == 0 {
+		return nil, 0
+	}
+	return claims, saving
+}
+
+// pickUnits retorna as próximas n unidades da lista ainda não usadas (nil se não houver n)
+func pickUnits(candidates []int, taken map[int]bool, n int) []int {
+	var picked []int
+	for _, u := range candidates {
+		if len(picked) == n {
+			break
+		}
+		if !taken[u] {
+			picked = append(picked, u)
+		}
+	}
+	if len(picked) < n {
+		return nil
+	}
+	return picked
+}
@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"testing"
+	"time"
+
+	"backend-hamburgueria/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// promotionLines: 2 lanches (R$ 30 e R$ 25), 1 batata (R$ 12) e 2 refrigerantes (R$ 8)
+func promotionLines() []pricedLine {
+	return []pricedLine{
+		{Item: models.OrderItemRequest{ProductID: 2, Quantity: 1}, CategoryID: 1, UnitPrice: 30, TotalPrice: 30},
+		{Item: models.OrderItemRequest{ProductID: 3, Quantity: 1}, CategoryID: 1, UnitPrice: 25, TotalPrice: 25},
+		{Item: models.OrderItemRequest{ProductID: 5, Quantity: 1}, CategoryID: 2, UnitPrice: 12, TotalPrice: 12},
+		{Item: models.OrderItemRequest{ProductID: 7, Quantity: 2}, CategoryID: 3, UnitPrice: 8, TotalPrice: 16},
+	}
+}
+
+// Teste do happy hour e do "leve 2 lanches, ganhe a batata"
+func TestDistributePromotions(t *testing.T) {
+	lines := promotionLines()
+	promotions := []models.Promotion{
+		{ID: 1, Name: "Happy hour", Kind: promotionFixedPrice, Value: 5, CategoryIDs: []int{3}},
+		{ID: 2, Name: "Combo batata", Kind: promotionBuyXGetY, Value: 100, CategoryIDs: []int{1},
+			BuyQuantity: 2, GetQuantity: 1, RewardProductIDs: []int{5}},
+	}
+
+	total := distributePromotions(promotions, lines)
+	assert.Equal(t, int64(600+1200), total)
+	assert.Equal(t, int64(600), lines[3].Discount)
+	assert.Equal(t, []models.LinePromotion{{PromotionID: 1, Name: "Happy hour", Quantity: 2, Discount: 6}}, lines[3].Promotions)
+	assert.Equal(t, int64(1200), lines[2].Discount)
+	// Os lanches ativam a promoção, sem desconto próprio
+	assert.Equal(t, int64(0), lines[0].Discount)
+	assert.Equal(t, 2, lines[0].Promotions[0].PromotionID)
+}
+
+// Teste da escolha sem conflito: cada unidade entra em uma só promoção, a de maior desconto
+func TestDistributePromotionsBestCombination(t *testing.T) {
+	lines := promotionLines()
+	promotions := []models.Promotion{
+		// 10% nos lanches (R$ 5,50) perde para o combo, que usa os mesmos lanches (R$ 12,00)
+		{ID: 1, Name: "Terça do lanche", Kind: promotionPercentage, Value: 10, CategoryIDs: []int{1}},
+		{ID: 2, Name: "Combo batata", Kind: promotionBuyXGetY, Value: 100, CategoryIDs: []int{1},
+			BuyQuantity: 2, GetQuantity: 1, RewardProductIDs: []int{5}},
+		// Sem itens participantes: ignorada
+		{ID: 3, Name: "Sobremesa", Kind: promotionPercentage, Value: 50, CategoryIDs: []int{9}},
+	}
+
+	total := distributePromotions(promotions, lines)
+	assert.Equal(t, int64(1200), total)
+	for _, line := range lines {
+		for _, promotion := range line.Promotions {
+			assert.Equal(t, 2, promotion.PromotionID)
+		}
+	}
+}
+
+// Teste do leve X ganhe Y no mesmo grupo: o brinde é a unidade mais barata
+func TestDistributePromotionsSameGroup(t *testing.T) {
+	lines := promotionLines()
+	promotions := []models.Promotion{
+		{ID: 4, Name: "Leve 2 pague 1", Kind: promotionBuyXGetY, Value: 100, CategoryIDs: []int{1}, BuyQuantity: 1, GetQuantity: 1},
+	}
+	assert.Equal(t, int64(2500), distributePromotions(promotions, lines))
+	assert.Equal(t, int64(2500), lines[1].Discount)
+}
+
+// Teste da vigência: dias da semana e horário (inclusive atravessando a meia-noite)
+func TestPromotionRunning(t *testing.T) {
+	t.Setenv("STORE_TIMEZONE", "UTC")
+	// Segunda-feira, 18:30
+	now := time.Date(2026, 10, 19, 18, 30, 0, 0, time.UTC)
+
+	assert.True(t, promotionRunning(models.Promotion{StartTime: "17:00", EndTime: "19:00"}, now))
+	assert.False(t, promotionRunning(models.Promotion{StartTime: "19:00", EndTime: "21:00"}, now))
+	assert.True(t, promotionRunning(models.Promotion{StartTime: "18:00", EndTime: "02:00"}, now))
+	assert.True(t, promotionRunning(models.Promotion{Weekdays: []int{1}}, now))
+	assert.False(t, promotionRunning(models.Promotion{Weekdays: []int{2}}, now))
+
+	ended := now.Add(-time.Minute)
+	assert.False(t, promotionRunning(models.Promotion{EndsAt: &ended}, now))
+}
+
+// Teste das promoções em pedido agendado: valem as do horário escolhido, não as do momento do pedido
+func TestPromotionRunningScheduledOrder(t *testing.T) {
+	t.Setenv("STORE_TIMEZONE", "UTC")
+	// Pedido feito na segunda-feira às 14:00 para retirar às 18:30 (happy hour das 17 às 19)
+	now := time.Date(2026, 10, 19, 14, 0, 0, 0, time.UTC)
+	pickup := time.Date(2026, 10, 19, 18, 30, 0, 0, time.UTC)
+	happyHour := models.Promotion{StartTime: "17:00", EndTime: "19:00"}
+
+	scheduled := &models.CreateOrderRequest{ScheduledFor: &pickup}
+	assert.True(t, promotionRunning(happyHour, pricingTime(scheduled, now)))
+	assert.False(t, promotionRunning(happyHour, pricingTime(&models.CreateOrderRequest{}, now)))
+
+	// Promoção que termina antes do horário agendado não vale
+	ends := pickup.Add(-time.Hour)
+	assert.False(t, promotionRunning(models.Promotion{EndsAt: &ends}, pricingTime(scheduled, now)))
+}
+
+// Teste da validação do cadastro de promoções
+func TestValidatePromotion(t *testing.T) {
+	promotion := models.Promotion{Name: "Combo", Kind: promotionBuyXGetY, BuyQuantity: 2, GetQuantity: 1}
+	assert.NoError(t, validatePromotion(&promotion))
+	assert.Equal(t, 100.0, promotion.Value)
+	assert.Equal(t, []int{}, promotion.ProductIDs)
+
+	invalid := []models.Promotion{
+		{Name: "", Kind: promotionPercentage, Value: 10},
+		{Name: "X", Kind: "bogo", Value: 10},
+		{Name: "X", Kind: promotionPercentage, Value: 110},
+		{Name: "X", Kind: promotionBuyXGetY, BuyQuantity: 2},
+		{Name: "X", Kind: promotionFixedPrice, Value: 5, StartTime: "17:00"},
+		{Name: "X", Kind: promotionFixedPrice, Value: 5, StartTime: "17h", EndTime: "19:00"},
+	}
+	for _, promotion := range invalid {
+		assert.Error(t, validatePromotion(&promotion))
+	}
+}
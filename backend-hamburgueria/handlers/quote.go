@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"net/http"
+
+	// Configurações da aplicação (regras de agendamento)
+	"backend-hamburgueria/config"
+	// Modelos de dados da aplicação
+	"backend-hamburgueria/models"
+
+	// Framework web Gin
+	"github.com/gin-gonic/gin"
+)
+
+// ===== COTAÇÃO DO PEDIDO =====
+
+// QuoteOrder godoc
+// @Summary      Cota um pedido
+// @Description  Calcula os preços dos itens, ingredientes, descontos, taxas e o total com o mesmo cálculo e as mesmas verificações de CreateOrder (loja aberta, horário agendado, mesa e alergias), sem gravar nada
+// @Tags         Orders
+// @Accept       json
+// @Produce      json
+// @Param        body  body      models.CreateOrderRequest  true  "Mesmo corpo da criação do pedido"
+// @Success      200   {object}  models.OrderQuote
+// @Failure      400   {object}  models.ErrorResponse "Dados inválidos"
+// @Failure      409   {object}  models.ErrorResponse "Horário agendado esgotado ou alergia não confirmada"
+// @Failure      503   {object}  models.ErrorResponse "Loja fechada ou pedidos pausados"
+// @Router       /api/orders/quote [post]
+func QuoteOrder(c *gin.Context, db DBInterface) {
+	// ===== VALIDAR DADOS DE ENTRADA =====
+	var req models.CreateOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos"})
+		return
+	}
+	if err := prepareOrderRequest(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	quote, ok := quoteOrderRequest(c, db, &req)
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, quote)
+}
+
+// quoteOrderRequest faz as verificações da criação do pedido e calcula a cotação, sem gravar nada
+// Usado na cotação direta e na do carrinho; em caso de erro já responde a requisição e retorna false
+func quoteOrderRequest(c *gin.Context, db DBInterface, req *models.CreateOrderRequest) (models.OrderQuote, bool) {
+	// ===== LOJA, HORÁRIO AGENDADO E MESA =====
+	// A cotação recusa o que a criação do pedido recusaria
+	scheduling := config.LoadScheduledOrders()
+	scheduled, ok := checkOrderRules(c, db, req, scheduling)
+	if !ok {
+		return models.OrderQuote{}, false
+	}
+	if scheduled != nil {
+		// Sem reservar: só confere se o horário ainda tem vaga
+		if err := checkScheduledSlot(db, *scheduled, scheduling); err != nil {
+			if isScheduleError(err) {
+				respondScheduleError(c, err, scheduling)
+			} else {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao verificar horário agendado"})
+			}
+			return models.OrderQuote{}, false
+		}
+	}
+
+	// ===== CALCULAR PREÇO =====
+	pricing, err := priceOrder(db, req)
+	if err != nil {
+		respondPricingError(c, err)
+		return models.OrderQuote{}, false
+	}
+
+	// ===== ALERGIAS DO CLIENTE =====
+	// Como na criação: itens com alérgenos declarados exigem a confirmação explícita
+	allergyConflicts := pricing.allergyConflicts()
+	if len(allergyConflicts) > 0 && !req.AllowAllergyConflicts {
+		respondAllergyConflict(c, allergyConflicts)
+		return models.OrderQuote{}, false
+	}
+
+	return quoteFromPricing(req.OrderType, pricing), true
+}
+
+// quoteFromPricing monta a resposta da cotação a partir do cálculo de preço
+func quoteFromPricing(orderType string, pricing orderPricing) models.OrderQuote {
+	quote := models.OrderQuote{
+		OrderType:    orderType,
+		Lines:        []models.QuoteLine{},
+		Pricing:      pricing.Breakdown,
+		Discounts:    pricing.discounts(),
+		ComboSaving:  fromCents(pricing.ComboSaving),
+		DeliveryZone: pricing.Delivery.Quote.ZoneName,
+	}
+	for _, line := range pricing.Lines {
+		var components []models.BundleComponent
+		for _, part := range line.Components {
+			components = append(components, part.BundleComponent)
+		}
+		quoteLine := models.QuoteLine{
+			ProductID:         line.Item.ProductID,
+			Quantity:          line.Item.Quantity,
+			UnitPrice:         line.UnitPrice,
+			TotalPrice:        line.TotalPrice,
+			Modifiers:         line.Modifiers,
+			Allergens:         line.Allergens,
+			AllergenConflicts: line.AllergenConflicts,
+			Discount:          fromCents(line.Discount),
+			Promotions:        line.Promotions,
+			Components:        components,
+			ComboSaving:       fromCents(line.ComboSaving),
+		}
+		if line.Variant != nil {
+			quoteLine.VariantID = &line.Variant.ID
+			quoteLine.VariantName = line.Variant.Name
+		}
+		quote.Lines = append(quote.Lines, quoteLine)
+	}
+	return quote
+}
@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	// Configurações da aplicação (regras de agendamento)
+	"backend-hamburgueria/config"
+
+	// Framework web Gin
+	"github.com/gin-gonic/gin"
+)
+
+// ===== PEDIDOS AGENDADOS =====
+
+// scheduledSlotLockKey identifica o advisory lock que serializa a reserva dos horários agendados
+const scheduledSlotLockKey = 27002
+
+// Erros do horário escolhido para o pedido
+var (
+	errScheduleTooSoon = errors.New("Horário agendado muito próximo, escolha um horário mais tarde")
+	errScheduleTooFar  = errors.New("Horário agendado além do limite de dias para agendamento")
+	errScheduleClosed  = errors.New("Loja fechada no horário agendado")
+	errScheduleFull    = errors.New("Horário agendado esgotado, escolha outro horário")
+)
+
+// scheduledSlot é o horário agendado de um pedido, com a entrada na fila da cozinha
+type scheduledSlot struct {
+	PickupAt  time.Time // Horário escolhido pelo cliente
+	SlotStart time.Time // Início do horário (limite de pedidos por horário)
+	SlotEnd   time.Time // Fim do horário
+	ReleaseAt time.Time // Entrada na fila da cozinha (horário menos a antecedência)
+}
+
+// planScheduledOrder valida o horário escolhido e calcula o horário e a liberação para a cozinha
+// O horário precisa respeitar a antecedência, o limite de dias e o funcionamento da loja
+func planScheduledOrder(schedule storeSchedule, scheduledFor, now time.Time, rules config.ScheduledOrders) (scheduledSlot, error) {
+	var slot scheduledSlot
+	if scheduledFor.Before(now.Add(rules.LeadTime)) {
+		return slot, errScheduleTooSoon
+	}
+	maxDays := rules.MaxDaysAhead
+	if maxDays > storeLookaheadDays {
+		maxDays = storeLookaheadDays
+	}
+	if scheduledFor.After(now.AddDate(0, 0, maxDays)) {
+		return slot, errScheduleTooFar
+	}
+	if _, open := schedule.openAt(scheduledFor); !open {
+		return slot, errScheduleClosed
+	}
+
+	slot.PickupAt = scheduledFor
+	slot.SlotStart = slotStart(scheduledFor, rules.SlotSize, config.StoreLocation())
+	slot.SlotEnd = slot.SlotStart.Add(rules.SlotSize)
+	slot.ReleaseAt = scheduledFor.Add(-rules.LeadTime)
+	return slot, nil
+}
+
+// slotStart retorna o início da faixa de horário que contém t
+// As faixas contam a partir da meia-noite no fuso da loja (com faixas de 45 minutos: 19:30, 20:15...);
+// time.Truncate contaria a partir do tempo zero em UTC e deslocaria as faixas conforme o fuso
+func slotStart(t time.Time, size time.Duration, location *time.Location) time.Time {
+	local := t.In(location)
+	midnight := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, location)
+	if size <= 0 {
+		return local
+	}
+	elapsed := local.Sub(midnight)
+	return midnight.Add(elapsed - elapsed%size)
+}
+
+// reserveScheduledSlot confere o limite de pedidos do horário agendado
+// O advisory lock vale até o fim da transação, então pedidos simultâneos não passam do limite
+func reserveScheduledSlot(q queryer, slot scheduledSlot, rules config.ScheduledOrders) error {
+	if rules.SlotCapacity == 0 {
+		return nil
+	}
+	if _, err := q.Exec("SELECT pg_advisory_xact_lock($1)", scheduledSlotLockKey); err != nil {
+		return err
+	}
+	return checkScheduledSlot(q, slot, rules)
+}
+
+// checkScheduledSlot confere se o horário agendado ainda tem vaga, sem reservar (usado também na cotação)
+func checkScheduledSlot(q queryer, slot scheduledSlot, rules config.ScheduledOrders) error {
+	if rules.SlotCapacity == 0 {
+		return nil
+	}
+	var booked int
+	err := q.QueryRow(`
+		SELECT COUNT(*) FROM orders WHERE store_id = $1 AND scheduled_for >= $2 AND scheduled_for < $3
+	`, config.StoreID(), slot.SlotStart, slot.SlotEnd).Scan(&booked)
+	if err != nil {
+		return err
+	}
+	if booked >= rules.SlotCapacity {
+		return errScheduleFull
+	}
+	return nil
+}
+
+// isScheduleError indica se o erro é de um horário agendado recusado
+func isScheduleError(err error) bool {
+	return err == errScheduleTooSoon || err == errScheduleTooFar || err == errScheduleClosed || err == errScheduleFull
+}
+
+// respondScheduleError converte um erro do horário agendado na resposta HTTP
+func respondScheduleError(c *gin.Context, err error, rules config.ScheduledOrders) {
+	switch err {
+	case errScheduleFull:
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errScheduleTooSoon:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "lead_minutes": int(rules.LeadTime / time.Minute)})
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+}
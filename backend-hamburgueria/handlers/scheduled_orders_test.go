@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"testing"
+	"time"
+
+	"backend-hamburgueria/config"
+	"backend-hamburgueria/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// scheduledRules: 30 minutos de antecedência, faixas de 15 minutos, até 7 dias
+func scheduledRules() config.ScheduledOrders {
+	return config.ScheduledOrders{
+		LeadTime:     30 * time.Minute,
+		SlotSize:     15 * time.Minute,
+		SlotCapacity: 10,
+		MaxDaysAhead: 7,
+	}
+}
+
+// Teste do horário agendado dentro do funcionamento da loja
+func TestPlanScheduledOrder(t *testing.T) {
+	t.Setenv("STORE_TIMEZONE", "UTC")
+	schedule := storeSchedule{Hours: weeklyDinner(), Exceptions: map[string]models.StoreException{}}
+
+	// Pedido feito na terça às 10:00 (loja fechada) para as 19:40
+	now := time.Date(2026, 10, 20, 10, 0, 0, 0, time.UTC)
+	pickup := time.Date(2026, 10, 20, 19, 40, 0, 0, time.UTC)
+	slot, err := planScheduledOrder(schedule, pickup, now, scheduledRules())
+	assert.NoError(t, err)
+	assert.Equal(t, pickup, slot.PickupAt)
+	assert.Equal(t, time.Date(2026, 10, 20, 19, 30, 0, 0, time.UTC), slot.SlotStart)
+	assert.Equal(t, time.Date(2026, 10, 20, 19, 45, 0, 0, time.UTC), slot.SlotEnd)
+	assert.Equal(t, time.Date(2026, 10, 20, 19, 10, 0, 0, time.UTC), slot.ReleaseAt)
+
+	// Sábado à 01:00 ainda está no expediente de sexta
+	_, err = planScheduledOrder(schedule, time.Date(2026, 10, 24, 1, 0, 0, 0, time.UTC), now, scheduledRules())
+	assert.NoError(t, err)
+}
+
+// Teste das faixas de horário contadas da meia-noite no fuso da loja
+func TestPlanScheduledOrderLocalSlots(t *testing.T) {
+	t.Setenv("STORE_TIMEZONE", "America/Sao_Paulo")
+	location := config.StoreLocation()
+	schedule := storeSchedule{Hours: weeklyDinner(), Exceptions: map[string]models.StoreException{}}
+	rules := scheduledRules()
+	rules.SlotSize = 45 * time.Minute
+
+	// Terça às 19:40 em São Paulo (UTC-3): faixa das 19:30 às 20:15 no horário da loja
+	now := time.Date(2026, 10, 20, 10, 0, 0, 0, location)
+	pickup := time.Date(2026, 10, 20, 19, 40, 0, 0, location)
+	slot, err := planScheduledOrder(schedule, pickup, now, rules)
+	assert.NoError(t, err)
+	assert.True(t, time.Date(2026, 10, 20, 19, 30, 0, 0, location).Equal(slot.SlotStart), slot.SlotStart.String())
+	assert.True(t, time.Date(2026, 10, 20, 20, 15, 0, 0, location).Equal(slot.SlotEnd), slot.SlotEnd.String())
+
+	// O mesmo horário informado em UTC cai na mesma faixa
+	assert.True(t, slot.SlotStart.Equal(slotStart(pickup.UTC(), rules.SlotSize, location)))
+	// Início exato de faixa
+	assert.True(t, pickup.Add(35*time.Minute).Equal(slotStart(pickup.Add(35*time.Minute), rules.SlotSize, location)))
+}
+
+// Teste dos horários agendados recusados
+func TestPlanScheduledOrderRejected(t *testing.T) {
+	t.Setenv("STORE_TIMEZONE", "UTC")
+	schedule := storeSchedule{
+		Hours: weeklyDinner(),
+		Exceptions: map[string]models.StoreException{
+			"2026-10-22": {Date: "2026-10-22", IsClosed: true},
+		},
+	}
+	now := time.Date(2026, 10, 20, 19, 0, 0, 0, time.UTC)
+	rules := scheduledRules()
+
+	// Antes da antecedência mínima
+	_, err := planScheduledOrder(schedule, now.Add(20*time.Minute), now, rules)
+	assert.Equal(t, errScheduleTooSoon, err)
+
+	// Além do limite de dias
+	_, err = planScheduledOrder(schedule, now.AddDate(0, 0, 8), now, rules)
+	assert.Equal(t, errScheduleTooFar, err)
+
+	// Segunda-feira: loja fechada
+	_, err = planScheduledOrder(schedule, time.Date(2026, 10, 26, 19, 0, 0, 0, time.UTC), now, rules)
+	assert.Equal(t, errScheduleClosed, err)
+
+	// Quinta-feira fechada por exceção
+	_, err = planScheduledOrder(schedule, time.Date(2026, 10, 22, 19, 0, 0, 0, time.UTC), now, rules)
+	assert.Equal(t, errScheduleClosed, err)
+}
@@ -0,0 +1,368 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	// Configurações da aplicação (fuso horário da loja)
+	"backend-hamburgueria/config"
+	// Modelos de dados da aplicação
+	"backend-hamburgueria/models"
+
+	// Framework web Gin
+	"github.com/gin-gonic/gin"
+	// Driver do PostgreSQL (arrays)
+	"github.com/lib/pq"
+)
+
+// ===== HORÁRIOS DO CARDÁPIO =====
+
+// outOfScheduleError indica um item do pedido fora do horário de venda
+type outOfScheduleError struct {
+	ProductID int
+}
+
+func (e *outOfScheduleError) Error() string {
+	return "Produto fora do horário de venda"
+}
+
+// scheduleColumns são as colunas lidas por scanSchedule
+const scheduleColumns = `id, weekdays, COALESCE(to_char(start_time, 'HH24:MI'), ''), COALESCE(to_char(end_time, 'HH24:MI'), ''),
+	COALESCE(to_char(start_date, 'YYYY-MM-DD'), ''), COALESCE(to_char(end_date, 'YYYY-MM-DD'), '')`
+
+// scheduleTarget é o dono das janelas de venda: produto ou categoria
+type scheduleTarget struct {
+	Table    string // Tabela do cadastro
+	Column   string // Coluna de availability_schedules que aponta para o cadastro
+	NotFound string // Mensagem quando o cadastro não existe
+}
+
+var (
+	productScheduleTarget  = scheduleTarget{Table: "products", Column: "product_id", NotFound: "Produto não encontrado"}
+	categoryScheduleTarget = scheduleTarget{Table: "categories", Column: "category_id", NotFound: "Categoria não encontrada"}
+)
+
+// menuSchedules são as janelas de venda cadastradas, por produto e por categoria
+type menuSchedules struct {
+	Products   map[int][]models.AvailabilitySchedule
+	Categories map[int][]models.AvailabilitySchedule
+}
+
+// GetProductSchedules godoc
+// @Summary      Horários de venda de um produto
+// @Description  Retorna as janelas em que o produto aparece no cardápio e pode ser pedido (vazio = o dia todo)
+// @Tags         Products
+// @Produce      json
+// @Param        id   path      int  true  "ID do produto"
+// @Success      200  {array}   models.AvailabilitySchedule
+// @Failure      400  {object}  models.ErrorResponse "ID inválido"
+// @Router       /api/products/{id}/schedules [get]
+func GetProductSchedules(c *gin.Context, db DBInterface) {
+	getSchedules(c, db, productScheduleTarget)
+}
+
+// SetProductSchedules godoc
+// @Summary      Define os horários de venda de um produto
+// @Description  Substitui as janelas de venda do produto (dias da semana, horário e período); lista vazia = o dia todo
+// @Tags         Products
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                         true  "ID do produto"
+// @Param        body  body      models.SetSchedulesRequest  true  "Janelas de venda"
+// @Success      200   {array}   models.AvailabilitySchedule
+// @Failure      400   {object}  models.ErrorResponse "Dados inválidos"
+// @Failure      404   {object}  models.ErrorResponse "Produto não encontrado"
+// @Router       /api/products/{id}/schedules [put]
+func SetProductSchedules(c *gin.Context, db DBInterface) {
+	setSchedules(c, db, productScheduleTarget)
+}
+
+// GetCategorySchedules godoc
+// @Summary      Horários de venda de uma categoria
+// @Description  Retorna as janelas em que os produtos da categoria podem ser pedidos (vazio = o dia todo)
+// @Tags         Categories
+// @Produce      json
+// @Param        id   path      int  true  "ID da categoria"
+// @Success      200  {array}   models.AvailabilitySchedule
+// @Failure      400  {object}  models.ErrorResponse "ID inválido"
+// @Router       /api/categories/{id}/schedules [get]
+func GetCategorySchedules(c *gin.Context, db DBInterface) {
+	getSchedules(c, db, categoryScheduleTarget)
+}
+
+// SetCategorySchedules godoc
+// @Summary      Define os horários de venda de uma categoria
+// @Description  Substitui as janelas de venda da categoria; valem para todos os produtos dela, junto com as do produto
+// @Tags         Categories
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                         true  "ID da categoria"
+// @Param        body  body      models.SetSchedulesRequest  true  "Janelas de venda"
+// @Success      200   {array}   models.AvailabilitySchedule
+// @Failure      400   {object}  models.ErrorResponse "Dados inválidos"
+// @Failure      404   {object}  models.ErrorResponse "Categoria não encontrada"
+// @Router       /api/categories/{id}/schedules [put]
+func SetCategorySchedules(c *gin.Context, db DBInterface) {
+	setSchedules(c, db, categoryScheduleTarget)
+}
+
+// getSchedules responde com as janelas de venda do produto ou categoria da URL
+func getSchedules(c *gin.Context, db DBInterface, target scheduleTarget) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+	schedules, err := loadSchedules(db, target, id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar horários"})
+		return
+	}
+	c.JSON(http.StatusOK, schedules)
+}
+
+// setSchedules substitui as janelas de venda do produto ou categoria da URL
+func setSchedules(c *gin.Context, db DBInterface, target scheduleTarget) {
+	// ===== VALIDAR DADOS DE ENTRADA =====
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+	var req models.SetSchedulesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos"})
+		return
+	}
+	for i := range req.Schedules {
+		if err := validateSchedule(&req.Schedules[i]); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	// ===== INICIAR TRANSAÇÃO =====
+	tx, err := db.Begin()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao iniciar transação"})
+		return
+	}
+	defer tx.Rollback()
+
+	var exists bool
+	if err := tx.QueryRow(`SELECT EXISTS (SELECT 1 FROM `+target.Table+` WHERE id = $1)`, id).Scan(&exists); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao salvar horários"})
+		return
+	}
+	if !exists {
+		c.JSON(http.StatusNotFound, gin.H{"error": target.NotFound})
+		return
+	}
+
+	// ===== SUBSTITUIR JANELAS =====
+	if _, err := tx.Exec(`DELETE FROM availability_schedules WHERE `+target.Column+` = $1`, id); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao salvar horários"})
+		return
+	}
+	for _, schedule := range req.Schedules {
+		if _, err := tx.Exec(`
+			INSERT INTO availability_schedules (`+target.Column+`, weekdays, start_time, end_time, start_date, end_date)
+			VALUES ($1, $2, NULLIF($3, '')::TIME, NULLIF($4, '')::TIME, NULLIF($5, '')::DATE, NULLIF($6, '')::DATE)
+		`, id, pq.Array(schedule.Weekdays), schedule.StartTime, schedule.EndTime, schedule.StartDate, schedule.EndDate); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao salvar horários"})
+			return
+		}
+	}
+
+	// ===== COMMIT DA TRANSAÇÃO =====
+	if err := tx.Commit(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao salvar horários"})
+		return
+	}
+	getSchedules(c, db, target)
+}
+
+// ===== FUNÇÕES AUXILIARES =====
+
+// validateSchedule confere dias da semana, horário e período de uma janela de venda
+func validateSchedule(schedule *models.AvailabilitySchedule) error {
+	for _, day := range schedule.Weekdays {
+		if day < 0 || day > 6 {
+			return errors.New("Dias da semana vão de 0 (domingo) a 6 (sábado)")
+		}
+	}
+	if schedule.Weekdays == nil {
+		schedule.Weekdays = []int{}
+	}
+	if (schedule.StartTime == "") != (schedule.EndTime == "") {
+		return errors.New("Informe o início e o fim do horário")
+	}
+	if schedule.StartTime != "" {
+		start, errStart := time.Parse("15:04", schedule.StartTime)
+		end, errEnd := time.Parse("15:04", schedule.EndTime)
+		if errStart != nil || errEnd != nil {
+			return errors.New("Horário inválido (use HH:MM)")
+		}
+		if start.Equal(end) {
+			return errors.New("Início e fim do horário devem ser diferentes")
+		}
+	}
+	for _, date := range []string{schedule.StartDate, schedule.EndDate} {
+		if date == "" {
+			continue
+		}
+		if _, err := time.Parse("2006-01-02", date); err != nil {
+			return errors.New("Data inválida (use AAAA-MM-DD)")
+		}
+	}
+	if schedule.StartDate != "" && schedule.EndDate != "" && schedule.EndDate < schedule.StartDate {
+		return errors.New("Último dia deve ser depois do primeiro")
+	}
+	return nil
+}
+
+// scanSchedule lê uma janela de venda nas colunas de scheduleColumns
+func scanSchedule(row rowScanner) (models.AvailabilitySchedule, error) {
+	var schedule models.AvailabilitySchedule
+	var weekdays pq.Int64Array
+	err := row.Scan(&schedule.ID, &weekdays, &schedule.StartTime, &schedule.EndTime, &schedule.StartDate, &schedule.EndDate)
+	schedule.Weekdays = intsFromArray(weekdays)
+	return schedule, err
+}
+
+// loadSchedules busca as janelas de venda de um produto ou categoria
+func loadSchedules(q queryer, target scheduleTarget, id int) ([]models.AvailabilitySchedule, error) {
+	rows, err := q.Query(`SELECT `+scheduleColumns+` FROM availability_schedules WHERE `+target.Column+` = $1 ORDER BY id`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	schedules := []models.AvailabilitySchedule{}
+	for rows.Next() {
+		schedule, err := scanSchedule(rows)
+		if err != nil {
+			return nil, err
+		}
+		schedules = append(schedules, schedule)
+	}
+	return schedules, rows.Err()
+}
+
+// loadMenuSchedules busca todas as janelas de venda, agrupadas por produto e por categoria
+func loadMenuSchedules(q queryer) (menuSchedules, error) {
+	menu := menuSchedules{
+		Products:   make(map[int][]models.AvailabilitySchedule),
+		Categories: make(map[int][]models.AvailabilitySchedule),
+	}
+	rows, err := q.Query(`
+		SELECT COALESCE(product_id, 0), COALESCE(category_id, 0), ` + scheduleColumns + `
+		FROM availability_schedules ORDER BY id
+	`)
+	if err != nil {
+		return menu, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var productID, categoryID int
+		var schedule models.AvailabilitySchedule
+		var weekdays pq.Int64Array
+		if err := rows.Scan(&productID, &categoryID, &schedule.ID, &weekdays, &schedule.StartTime, &schedule.EndTime,
+			&schedule.StartDate, &schedule.EndDate); err != nil {
+			return menu, err
+		}
+		schedule.Weekdays = intsFromArray(weekdays)
+		if productID > 0 {
+			menu.Products[productID] = append(menu.Products[productID], schedule)
+		} else {
+			menu.Categories[categoryID] = append(menu.Categories[categoryID], schedule)
+		}
+	}
+	return menu, rows.Err()
+}
+
+// categoryAvailable indica se a categoria está no horário de venda
+func (m menuSchedules) categoryAvailable(categoryID int, now time.Time) bool {
+	return schedulesActive(m.Categories[categoryID], now)
+}
+
+// productAvailable indica se o produto está no horário de venda: valem as janelas do produto e as da categoria
+func (m menuSchedules) productAvailable(productID, categoryID int, now time.Time) bool {
+	return schedulesActive(m.Products[productID], now) && m.categoryAvailable(categoryID, now)
+}
+
+// checkLines confere se os itens do pedido (e os produtos dos combos) estão no horário de venda
+func (m menuSchedules) checkLines(lines []pricedLine, now time.Time) error {
+	for _, line := range lines {
+		if !m.productAvailable(line.Item.ProductID, line.CategoryID, now) {
+			return &outOfScheduleError{ProductID: line.Item.ProductID}
+		}
+		for _, part := range line.Components {
+			if !m.productAvailable(part.ProductID, part.CategoryID, now) {
+				return &outOfScheduleError{ProductID: part.ProductID}
+			}
+		}
+	}
+	return nil
+}
+
+// schedulesActive indica se alguma das janelas vale no momento (sem janelas = sempre)
+func schedulesActive(schedules []models.AvailabilitySchedule, now time.Time) bool {
+	if len(schedules) == 0 {
+		return true
+	}
+	local := now.In(config.StoreLocation())
+	for _, schedule := range schedules {
+		if scheduleActive(schedule, local) {
+			return true
+		}
+	}
+	return false
+}
+
+// scheduleActive indica se a janela vale no horário local da loja
+// Na parte depois da meia-noite de um horário como 22:00-02:00, dia da semana e período são os do dia anterior
+func scheduleActive(schedule models.AvailabilitySchedule, local time.Time) bool {
+	day := local
+	if schedule.StartTime != "" {
+		inside, overnight := inClockWindow(schedule.StartTime, schedule.EndTime, local)
+		if !inside {
+			return false
+		}
+		if overnight {
+			day = local.AddDate(0, 0, -1)
+		}
+	}
+	if len(schedule.Weekdays) > 0 && !containsInt(schedule.Weekdays, int(day.Weekday())) {
+		return false
+	}
+	date := day.Format("2006-01-02")
+	if (schedule.StartDate != "" && date < schedule.StartDate) || (schedule.EndDate != "" && date > schedule.EndDate) {
+		return false
+	}
+	return true
+}
+
+// inClockWindow indica se o horário local está entre start e end (HH:MM, fim exclusivo)
+// overnight = true quando o horário atravessa a meia-noite e o momento já está no dia seguinte
+func inClockWindow(start, end string, local time.Time) (inside, overnight bool) {
+	from, errStart := time.Parse("15:04", start)
+	to, errEnd := time.Parse("15:04", end)
+	if errStart != nil || errEnd != nil {
+		return false, false
+	}
+	minute := local.Hour()*60 + local.Minute()
+	fromMinute := from.Hour()*60 + from.Minute()
+	toMinute := to.Hour()*60 + to.Minute()
+	if fromMinute <= toMinute {
+		return minute >= fromMinute && minute < toMinute, false
+	}
+	// Horário que atravessa a meia-noite (ex.: 22:00 às 02:00)
+	if minute < toMinute {
+		return true, true
+	}
+	return minute >= fromMinute, false
+}
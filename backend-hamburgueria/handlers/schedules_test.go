@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"backend-hamburgueria/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// Teste das janelas de venda: horário, dias da semana, período e madrugada
+func TestScheduleActive(t *testing.T) {
+	// Segunda-feira, 08:30
+	morning := time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC)
+	breakfast := models.AvailabilitySchedule{Weekdays: []int{1, 2, 3, 4, 5}, StartTime: "07:00", EndTime: "11:00"}
+	assert.True(t, scheduleActive(breakfast, morning))
+	assert.False(t, scheduleActive(breakfast, morning.Add(3*time.Hour)))
+	assert.False(t, scheduleActive(breakfast, morning.AddDate(0, 0, -1)))
+
+	// Madrugada de sexta para sábado: sábado 01:00 ainda é a janela de sexta
+	lateNight := models.AvailabilitySchedule{Weekdays: []int{5}, StartTime: "22:00", EndTime: "02:00"}
+	saturday := time.Date(2026, 10, 24, 1, 0, 0, 0, time.UTC)
+	assert.True(t, scheduleActive(lateNight, saturday))
+	assert.False(t, scheduleActive(lateNight, saturday.Add(2*time.Hour)))
+	assert.True(t, scheduleActive(lateNight, time.Date(2026, 10, 23, 23, 0, 0, 0, time.UTC)))
+
+	// Cardápio de temporada (datas inclusivas)
+	season := models.AvailabilitySchedule{StartDate: "2026-10-01", EndDate: "2026-10-19"}
+	assert.True(t, scheduleActive(season, morning))
+	assert.False(t, scheduleActive(season, morning.AddDate(0, 0, 1)))
+}
+
+// Teste da disponibilidade combinada de produto e categoria
+func TestMenuSchedulesProductAvailable(t *testing.T) {
+	t.Setenv("STORE_TIMEZONE", "UTC")
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	menu := menuSchedules{
+		Products: map[int][]models.AvailabilitySchedule{
+			2: {{StartTime: "07:00", EndTime: "11:00"}, {StartTime: "11:30", EndTime: "15:00"}},
+		},
+		Categories: map[int][]models.AvailabilitySchedule{
+			4: {{StartTime: "18:00", EndTime: "23:00"}},
+		},
+	}
+
+	assert.True(t, menu.productAvailable(2, 1, now))
+	assert.False(t, menu.productAvailable(2, 4, now))
+	assert.False(t, menu.productAvailable(3, 4, now))
+	assert.True(t, menu.productAvailable(3, 1, now))
+	assert.False(t, menu.categoryAvailable(4, now))
+
+	// Produto de um combo fora do horário
+	lines := []pricedLine{{Item: models.OrderItemRequest{ProductID: 20}, CategoryID: 1, Components: []bundlePart{
+		{BundleComponent: models.BundleComponent{ProductID: 7}, CategoryID: 4},
+	}}}
+	err := menu.checkLines(lines, now)
+	assert.Equal(t, &outOfScheduleError{ProductID: 7}, err)
+}
+
+// Teste da validação das janelas de venda
+func TestValidateSchedule(t *testing.T) {
+	schedule := models.AvailabilitySchedule{StartTime: "22:00", EndTime: "02:00"}
+	assert.NoError(t, validateSchedule(&schedule))
+	assert.Equal(t, []int{}, schedule.Weekdays)
+
+	invalid := []models.AvailabilitySchedule{
+		{Weekdays: []int{7}},
+		{StartTime: "07:00"},
+		{StartTime: "7h", EndTime: "11:00"},
+		{StartTime: "07:00", EndTime: "07:00"},
+		{StartDate: "19/10/2026"},
+		{StartDate: "2026-10-19", EndDate: "2026-10-01"},
+	}
+	for _, schedule := range invalid {
+		assert.Error(t, validateSchedule(&schedule))
+	}
+}
+
+// Teste para SetProductSchedules com dados inválidos (recusado antes do banco)
+func TestSetProductSchedulesInvalid(t *testing.T) {
+	router, mockDB := setupTest()
+
+	router.PUT("/products/:id/schedules", func(c *gin.Context) {
+		SetProductSchedules(c, mockDB)
+	})
+
+	body := `{"schedules": [{"start_time": "07:00"}]}`
+	req, _ := http.NewRequest("PUT", "/products/2/schedules", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
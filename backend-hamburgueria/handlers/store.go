@@ -0,0 +1,531 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	// Configurações da aplicação (loja e fuso horário)
+	"backend-hamburgueria/config"
+	// Modelos de dados da aplicação
+	"backend-hamburgueria/models"
+
+	// Framework web Gin
+	"github.com/gin-gonic/gin"
+)
+
+// ===== FUNCIONAMENTO DA LOJA =====
+
+// Situações da loja para receber pedidos
+const (
+	storeOpen   = "open"   // Aberta e aceitando pedidos
+	storeClosed = "closed" // Fora do horário ou em data fechada
+	storePaused = "paused" // Aberta, mas com os pedidos pausados
+)
+
+// storeLookaheadDays é quantos dias à frente são considerados para a próxima abertura
+const storeLookaheadDays = 7
+
+// storeSchedule é o horário da loja carregado do banco, usado no status e na validação dos pedidos
+type storeSchedule struct {
+	Hours      []models.StoreHours
+	Exceptions map[string]models.StoreException // Por data (YYYY-MM-DD)
+	Pause      *models.StorePause
+}
+
+// openInterval é um período de funcionamento em horário absoluto
+type openInterval struct {
+	Start time.Time
+	End   time.Time
+}
+
+// GetStoreStatus godoc
+// @Summary      Situação da loja
+// @Description  Informa se a loja está aberta, fechada ou com os pedidos pausados, com o próximo horário de abertura ou fechamento
+// @Tags         Store
+// @Produce      json
+// @Success      200  {object}  models.StoreStatus
+// @Failure      500  {object}  models.ErrorResponse "Erro ao buscar horário da loja"
+// @Router       /api/store/status [get]
+func GetStoreStatus(c *gin.Context, db DBInterface) {
+	status, err := loadStoreStatus(db, time.Now())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar horário da loja"})
+		return
+	}
+	c.JSON(http.StatusOK, status)
+}
+
+// GetStoreHours godoc
+// @Summary      Horário de funcionamento
+// @Description  Retorna o horário semanal da loja e as exceções (feriados, horários especiais) de hoje em diante
+// @Tags         Store
+// @Produce      json
+// @Success      200  {object}  models.StoreHoursConfig
+// @Failure      500  {object}  models.ErrorResponse "Erro ao buscar horário da loja"
+// @Router       /api/store/hours [get]
+func GetStoreHours(c *gin.Context, db DBInterface) {
+	storeID := config.StoreID()
+	hours, err := loadStoreHours(db, storeID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar horário da loja"})
+		return
+	}
+	today := time.Now().In(config.StoreLocation()).Format("2006-01-02")
+	exceptions, err := loadStoreExceptions(db, storeID, today, "")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar horário da loja"})
+		return
+	}
+	c.JSON(http.StatusOK, models.StoreHoursConfig{Hours: hours, Exceptions: exceptions})
+}
+
+// SetStoreHours godoc
+// @Summary      Define o horário semanal
+// @Description  Substitui os períodos de funcionamento de cada dia da semana; lista vazia = aberta o dia todo
+// @Tags         Store
+// @Accept       json
+// @Produce      json
+// @Param        body  body      models.SetStoreHoursRequest  true  "Horário semanal"
+// @Success      200   {object}  models.StoreHoursConfig
+// @Failure      400   {object}  models.ErrorResponse "Dados inválidos"
+// @Router       /api/store/hours [put]
+func SetStoreHours(c *gin.Context, db DBInterface) {
+	// ===== VALIDAR DADOS DE ENTRADA =====
+	var req models.SetStoreHoursRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos"})
+		return
+	}
+	for _, hours := range req.Hours {
+		if hours.Weekday < 0 || hours.Weekday > 6 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Dias da semana vão de 0 (domingo) a 6 (sábado)"})
+			return
+		}
+		if err := validateOpeningTimes(hours.OpenTime, hours.CloseTime); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	// ===== SUBSTITUIR HORÁRIO =====
+	tx, err := db.Begin()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao iniciar transação"})
+		return
+	}
+	defer tx.Rollback()
+
+	storeID := config.StoreID()
+	if _, err := tx.Exec(`DELETE FROM store_hours WHERE store_id = $1`, storeID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao salvar horário da loja"})
+		return
+	}
+	for _, hours := range req.Hours {
+		if _, err := tx.Exec(`
+			INSERT INTO store_hours (store_id, weekday, open_time, close_time) VALUES ($1, $2, $3, $4)
+		`, storeID, hours.Weekday, hours.OpenTime, hours.CloseTime); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao salvar horário da loja"})
+			return
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao salvar horário da loja"})
+		return
+	}
+	GetStoreHours(c, db)
+}
+
+// SetStoreException godoc
+// @Summary      Define uma exceção de horário
+// @Description  Fecha a loja numa data (feriado) ou define um horário especial, substituindo o horário semanal do dia
+// @Tags         Store
+// @Accept       json
+// @Produce      json
+// @Param        date  path      string                 true  "Data (YYYY-MM-DD)"
+// @Param        body  body      models.StoreException  true  "Exceção"
+// @Success      200   {object}  models.StoreException
+// @Failure      400   {object}  models.ErrorResponse "Dados inválidos"
+// @Router       /api/store/exceptions/{date} [put]
+func SetStoreException(c *gin.Context, db DBInterface) {
+	// ===== VALIDAR DADOS DE ENTRADA =====
+	var req models.StoreException
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos"})
+		return
+	}
+	req.Date = c.Param("date")
+	if err := validateStoreException(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// ===== GRAVAR EXCEÇÃO =====
+	_, err := db.Exec(`
+		INSERT INTO store_hour_exceptions (store_id, date, is_closed, open_time, close_time, reason)
+		VALUES ($1, $2, $3, NULLIF($4, '')::TIME, NULLIF($5, '')::TIME, $6)
+		ON CONFLICT (store_id, date) DO UPDATE SET
+			is_closed = EXCLUDED.is_closed, open_time = EXCLUDED.open_time,
+			close_time = EXCLUDED.close_time, reason = EXCLUDED.reason
+	`, config.StoreID(), req.Date, req.IsClosed, req.OpenTime, req.CloseTime, req.Reason)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao salvar exceção de horário"})
+		return
+	}
+	c.JSON(http.StatusOK, req)
+}
+
+// DeleteStoreException godoc
+// @Summary      Remove uma exceção de horário
+// @Description  A data volta a seguir o horário semanal
+// @Tags         Store
+// @Produce      json
+// @Param        date  path      string  true  "Data (YYYY-MM-DD)"
+// @Success      200   {object}  models.StatusResponse
+// @Failure      404   {object}  models.ErrorResponse "Exceção não encontrada"
+// @Router       /api/store/exceptions/{date} [delete]
+func DeleteStoreException(c *gin.Context, db DBInterface) {
+	date := c.Param("date")
+	if _, err := time.Parse("2006-01-02", date); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Data inválida (use AAAA-MM-DD)"})
+		return
+	}
+	result, err := db.Exec(`DELETE FROM store_hour_exceptions WHERE store_id = $1 AND date = $2`, config.StoreID(), date)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao remover exceção de horário"})
+		return
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Exceção não encontrada"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Exceção removida com sucesso"})
+}
+
+// PauseStore godoc
+// @Summary      Pausa os pedidos
+// @Description  Para de aceitar pedidos (cozinha cheia, falta de insumo...), com motivo e retomada automática opcional
+// @Tags         Store
+// @Accept       json
+// @Produce      json
+// @Param        body  body      models.PauseStoreRequest  true  "Motivo e retomada"
+// @Success      200   {object}  models.StoreStatus
+// @Failure      400   {object}  models.ErrorResponse "Dados inválidos"
+// @Router       /api/store/pause [put]
+func PauseStore(c *gin.Context, db DBInterface) {
+	// ===== VALIDAR DADOS DE ENTRADA =====
+	var req models.PauseStoreRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos"})
+		return
+	}
+	now := time.Now()
+	resumeAt, err := pauseResumeAt(req, now)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// ===== GRAVAR PAUSA =====
+	_, err = db.Exec(`
+		INSERT INTO store_pauses (store_id, reason, paused_at, resume_at) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (store_id) DO UPDATE SET reason = EXCLUDED.reason, paused_at = EXCLUDED.paused_at, resume_at = EXCLUDED.resume_at
+	`, config.StoreID(), req.Reason, now, resumeAt)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao pausar pedidos"})
+		return
+	}
+	GetStoreStatus(c, db)
+}
+
+// ResumeStore godoc
+// @Summary      Retoma os pedidos
+// @Description  Encerra a pausa manual; a loja volta a aceitar pedidos se estiver no horário
+// @Tags         Store
+// @Produce      json
+// @Success      200  {object}  models.StoreStatus
+// @Router       /api/store/pause [delete]
+func ResumeStore(c *gin.Context, db DBInterface) {
+	if _, err := db.Exec(`DELETE FROM store_pauses WHERE store_id = $1`, config.StoreID()); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao retomar pedidos"})
+		return
+	}
+	GetStoreStatus(c, db)
+}
+
+// ===== FUNÇÕES AUXILIARES =====
+
+// validateOpeningTimes confere abertura e fechamento (HH:MM)
+func validateOpeningTimes(open, close string) error {
+	if _, err := time.Parse("15:04", open); err != nil {
+		return errors.New("Horário inválido (use HH:MM)")
+	}
+	if _, err := time.Parse("15:04", close); err != nil {
+		return errors.New("Horário inválido (use HH:MM)")
+	}
+	return nil
+}
+
+// validateStoreException confere a data e o horário especial da exceção
+func validateStoreException(exception *models.StoreException) error {
+	if _, err := time.Parse("2006-01-02", exception.Date); err != nil {
+		return errors.New("Data inválida (use AAAA-MM-DD)")
+	}
+	if exception.IsClosed {
+		exception.OpenTime, exception.CloseTime = "", ""
+		return nil
+	}
+	return validateOpeningTimes(exception.OpenTime, exception.CloseTime)
+}
+
+// pauseResumeAt calcula a retomada automática da pausa (resume_at ou duration_minutes)
+func pauseResumeAt(req models.PauseStoreRequest, now time.Time) (*time.Time, error) {
+	if req.ResumeAt != nil && req.DurationMinutes != 0 {
+		return nil, errors.New("Informe resume_at ou duration_minutes, não os dois")
+	}
+	if req.DurationMinutes < 0 {
+		return nil, errors.New("Duração da pausa deve ser maior que zero")
+	}
+	if req.DurationMinutes > 0 {
+		resumeAt := now.Add(time.Duration(req.DurationMinutes) * time.Minute)
+		return &resumeAt, nil
+	}
+	if req.ResumeAt != nil && !req.ResumeAt.After(now) {
+		return nil, errors.New("Retomada deve ser no futuro")
+	}
+	return req.ResumeAt, nil
+}
+
+// loadStoreHours busca o horário semanal da loja
+func loadStoreHours(q queryer, storeID int) ([]models.StoreHours, error) {
+	rows, err := q.Query(`
+		SELECT weekday, to_char(open_time, 'HH24:MI'), to_char(close_time, 'HH24:MI')
+		FROM store_hours WHERE store_id = $1 ORDER BY weekday, open_time
+	`, storeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	hours := []models.StoreHours{}
+	for rows.Next() {
+		var h models.StoreHours
+		if err := rows.Scan(&h.Weekday, &h.OpenTime, &h.CloseTime); err != nil {
+			return nil, err
+		}
+		hours = append(hours, h)
+	}
+	return hours, rows.Err()
+}
+
+// loadStoreExceptions busca as exceções de horário a partir de "from" (e até "to", se informado)
+func loadStoreExceptions(q queryer, storeID int, from, to string) ([]models.StoreException, error) {
+	rows, err := q.Query(`
+		SELECT to_char(date, 'YYYY-MM-DD'), is_closed, COALESCE(to_char(open_time, 'HH24:MI'), ''),
+			COALESCE(to_char(close_time, 'HH24:MI'), ''), reason
+		FROM store_hour_exceptions
+		WHERE store_id = $1 AND date >= $2 AND ($3 = '' OR date <= NULLIF($3, '')::DATE)
+		ORDER BY date
+	`, storeID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	exceptions := []models.StoreException{}
+	for rows.Next() {
+		var e models.StoreException
+		if err := rows.Scan(&e.Date, &e.IsClosed, &e.OpenTime, &e.CloseTime, &e.Reason); err != nil {
+			return nil, err
+		}
+		exceptions = append(exceptions, e)
+	}
+	return exceptions, rows.Err()
+}
+
+// loadStoreSchedule busca horário semanal, exceções próximas e pausa da loja
+func loadStoreSchedule(q queryer, now time.Time) (storeSchedule, error) {
+	storeID := config.StoreID()
+	schedule := storeSchedule{Exceptions: make(map[string]models.StoreException)}
+
+	hours, err := loadStoreHours(q, storeID)
+	if err != nil {
+		return schedule, err
+	}
+	schedule.Hours = hours
+
+	local := now.In(config.StoreLocation())
+	from := local.AddDate(0, 0, -1).Format("2006-01-02")
+	to := local.AddDate(0, 0, storeLookaheadDays+1).Format("2006-01-02")
+	exceptions, err := loadStoreExceptions(q, storeID, from, to)
+	if err != nil {
+		return schedule, err
+	}
+	for _, exception := range exceptions {
+		schedule.Exceptions[exception.Date] = exception
+	}
+
+	var pause models.StorePause
+	err = q.QueryRow(`SELECT reason, paused_at, resume_at FROM store_pauses WHERE store_id = $1`, storeID).
+		Scan(&pause.Reason, &pause.PausedAt, &pause.ResumeAt)
+	if err != nil && err != sql.ErrNoRows {
+		return schedule, err
+	}
+	if err == nil {
+		schedule.Pause = &pause
+	}
+	return schedule, nil
+}
+
+// loadStoreStatus calcula a situação da loja no momento
+func loadStoreStatus(q queryer, now time.Time) (models.StoreStatus, error) {
+	schedule, err := loadStoreSchedule(q, now)
+	if err != nil {
+		return models.StoreStatus{}, err
+	}
+	return schedule.status(now), nil
+}
+
+// respondStoreUnavailable recusa um pedido com a loja fechada ou pausada
+func respondStoreUnavailable(c *gin.Context, status models.StoreStatus) {
+	retry := status.ResumeAt
+	if status.State == storeClosed {
+		retry = status.NextOpenAt
+	}
+	if retry != nil {
+		if seconds := int(time.Until(*retry).Seconds()); seconds > 0 {
+			c.Header("Retry-After", strconv.Itoa(seconds))
+		}
+	}
+	c.JSON(http.StatusServiceUnavailable, gin.H{"error": status.Message, "store_status": status})
+}
+
+// status calcula a situação da loja num momento
+func (s storeSchedule) status(now time.Time) models.StoreStatus {
+	var status models.StoreStatus
+	today := now.In(config.StoreLocation()).Format("2006-01-02")
+	exception, hasException := s.Exceptions[today]
+
+	pause := s.activePause(now)
+	status.Pause = pause
+	closesAt, open := s.openAt(now)
+	switch {
+	case !open:
+		status.State = storeClosed
+		status.Message = "Loja fechada no momento"
+		if hasException && exception.IsClosed {
+			status.Reason = exception.Reason
+		}
+		status.NextOpenAt = s.nextOpen(now)
+	case pause != nil:
+		status.State = storePaused
+		status.Message = "Pedidos pausados no momento"
+		status.Reason = pause.Reason
+		status.ResumeAt = pause.ResumeAt
+		status.ClosesAt = closesAt
+	default:
+		status.State = storeOpen
+		status.AcceptingOrders = true
+		status.Message = "Loja aberta"
+		if hasException {
+			status.Reason = exception.Reason
+		}
+		status.ClosesAt = closesAt
+	}
+	return status
+}
+
+// activePause retorna a pausa em andamento (pausas com retomada já vencida são ignoradas)
+func (s storeSchedule) activePause(now time.Time) *models.StorePause {
+	if s.Pause == nil || (s.Pause.ResumeAt != nil && !now.Before(*s.Pause.ResumeAt)) {
+		return nil
+	}
+	return s.Pause
+}
+
+// openAt indica se a loja está no horário de funcionamento e quando o período atual termina
+// Períodos emendados (18:00-00:00 seguido de 00:00-02:00) contam como um só; closesAt fica vazio
+// quando a loja não fecha dentro dos próximos dias
+func (s storeSchedule) openAt(t time.Time) (closesAt *time.Time, open bool) {
+	intervals := s.intervalsAround(t)
+	for i, interval := range intervals {
+		if t.Before(interval.Start) || !t.Before(interval.End) {
+			continue
+		}
+		end := interval.End
+		for _, next := range intervals[i+1:] {
+			if next.Start.After(end) {
+				break
+			}
+			if next.End.After(end) {
+				end = next.End
+			}
+		}
+		if !end.Before(intervals[len(intervals)-1].End) {
+			return nil, true
+		}
+		return &end, true
+	}
+	return nil, false
+}
+
+// nextOpen retorna a próxima abertura depois do momento (nil = nenhuma nos próximos dias)
+func (s storeSchedule) nextOpen(t time.Time) *time.Time {
+	for _, interval := range s.intervalsAround(t) {
+		if interval.Start.After(t) {
+			start := interval.Start
+			return &start
+		}
+	}
+	return nil
+}
+
+// intervalsAround lista os períodos de funcionamento do dia anterior até storeLookaheadDays à frente,
+// em ordem de início
+func (s storeSchedule) intervalsAround(t time.Time) []openInterval {
+	local := t.In(config.StoreLocation())
+	var intervals []openInterval
+	for offset := -1; offset <= storeLookaheadDays; offset++ {
+		intervals = append(intervals, s.intervalsOn(local.AddDate(0, 0, offset))...)
+	}
+	return intervals
+}
+
+// intervalsOn retorna os períodos de funcionamento que começam no dia (data local)
+// Exceções substituem o horário semanal; sem horário semanal a loja funciona o dia todo
+func (s storeSchedule) intervalsOn(day time.Time) []openInterval {
+	midnight := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, day.Location())
+	if exception, ok := s.Exceptions[midnight.Format("2006-01-02")]; ok {
+		if exception.IsClosed {
+			return nil
+		}
+		return []openInterval{clockInterval(midnight, exception.OpenTime, exception.CloseTime)}
+	}
+	if len(s.Hours) == 0 {
+		return []openInterval{{Start: midnight, End: midnight.AddDate(0, 0, 1)}}
+	}
+	var intervals []openInterval
+	for _, hours := range s.Hours {
+		if hours.Weekday == int(midnight.Weekday()) {
+			intervals = append(intervals, clockInterval(midnight, hours.OpenTime, hours.CloseTime))
+		}
+	}
+	sort.Slice(intervals, func(i, j int) bool { return intervals[i].Start.Before(intervals[j].Start) })
+	return intervals
+}
+
+// clockInterval monta o período entre abertura e fechamento (HH:MM) no dia
+// Fechamento menor ou igual à abertura termina no dia seguinte
+func clockInterval(midnight time.Time, open, close string) openInterval {
+	from, _ := time.Parse("15:04", open)
+	to, _ := time.Parse("15:04", close)
+	start := time.Date(midnight.Year(), midnight.Month(), midnight.Day(), from.Hour(), from.Minute(), 0, 0, midnight.Location())
+	end := time.Date(midnight.Year(), midnight.Month(), midnight.Day(), to.Hour(), to.Minute(), 0, 0, midnight.Location())
+	if !end.After(start) {
+		end = end.AddDate(0, 0, 1)
+	}
+	return openInterval{Start: start, End: end}
+}
@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"backend-hamburgueria/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// weeklyDinner: terça a sábado, 18:00 às 23:00; sexta e sábado até 02:00
+func weeklyDinner() []models.StoreHours {
+	return []models.StoreHours{
+		{Weekday: 2, OpenTime: "18:00", CloseTime: "23:00"},
+		{Weekday: 3, OpenTime: "18:00", CloseTime: "23:00"},
+		{Weekday: 4, OpenTime: "18:00", CloseTime: "23:00"},
+		{Weekday: 5, OpenTime: "18:00", CloseTime: "02:00"},
+		{Weekday: 6, OpenTime: "18:00", CloseTime: "02:00"},
+	}
+}
+
+// Teste da situação da loja pelo horário semanal (inclusive depois da meia-noite)
+func TestStoreScheduleStatus(t *testing.T) {
+	t.Setenv("STORE_TIMEZONE", "UTC")
+	schedule := storeSchedule{Hours: weeklyDinner(), Exceptions: map[string]models.StoreException{}}
+
+	// Terça-feira, 19:00: aberta até 23:00
+	tuesday := time.Date(2026, 10, 20, 19, 0, 0, 0, time.UTC)
+	status := schedule.status(tuesday)
+	assert.Equal(t, storeOpen, status.State)
+	assert.True(t, status.AcceptingOrders)
+	assert.Equal(t, time.Date(2026, 10, 20, 23, 0, 0, 0, time.UTC), *status.ClosesAt)
+
+	// Sábado, 01:00: ainda no expediente de sexta
+	saturday := time.Date(2026, 10, 24, 1, 0, 0, 0, time.UTC)
+	assert.Equal(t, storeOpen, schedule.status(saturday).State)
+
+	// Segunda-feira: fechada, abre na terça às 18:00
+	monday := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	status = schedule.status(monday)
+	assert.Equal(t, storeClosed, status.State)
+	assert.False(t, status.AcceptingOrders)
+	assert.Equal(t, time.Date(2026, 10, 20, 18, 0, 0, 0, time.UTC), *status.NextOpenAt)
+}
+
+// Teste das exceções: feriado fechado e horário especial
+func TestStoreScheduleExceptions(t *testing.T) {
+	t.Setenv("STORE_TIMEZONE", "UTC")
+	schedule := storeSchedule{Hours: weeklyDinner(), Exceptions: map[string]models.StoreException{
+		"2026-10-20": {Date: "2026-10-20", IsClosed: true, Reason: "Feriado"},
+		"2026-10-21": {Date: "2026-10-21", OpenTime: "12:00", CloseTime: "16:00", Reason: "Evento"},
+	}}
+
+	status := schedule.status(time.Date(2026, 10, 20, 19, 0, 0, 0, time.UTC))
+	assert.Equal(t, storeClosed, status.State)
+	assert.Equal(t, "Feriado", status.Reason)
+	assert.Equal(t, time.Date(2026, 10, 21, 12, 0, 0, 0, time.UTC), *status.NextOpenAt)
+
+	status = schedule.status(time.Date(2026, 10, 21, 13, 0, 0, 0, time.UTC))
+	assert.Equal(t, storeOpen, status.State)
+	assert.Equal(t, "Evento", status.Reason)
+	assert.Equal(t, storeClosed, schedule.status(time.Date(2026, 10, 21, 19, 0, 0, 0, time.UTC)).State)
+}
+
+// Teste da pausa: bloqueia pedidos e expira na retomada automática; sem horário, a loja não fecha
+func TestStoreSchedulePause(t *testing.T) {
+	t.Setenv("STORE_TIMEZONE", "UTC")
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	resumeAt := now.Add(30 * time.Minute)
+	schedule := storeSchedule{Exceptions: map[string]models.StoreException{}, Pause: &models.StorePause{
+		Reason: "Falta de pão", PausedAt: now, ResumeAt: &resumeAt,
+	}}
+
+	status := schedule.status(now)
+	assert.Equal(t, storePaused, status.State)
+	assert.False(t, status.AcceptingOrders)
+	assert.Equal(t, "Falta de pão", status.Reason)
+	assert.Nil(t, status.ClosesAt)
+
+	status = schedule.status(resumeAt)
+	assert.Equal(t, storeOpen, status.State)
+	assert.Nil(t, status.Pause)
+}
+
+// Teste da retomada automática informada na pausa
+func TestPauseResumeAt(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	resumeAt, err := pauseResumeAt(models.PauseStoreRequest{DurationMinutes: 45}, now)
+	assert.NoError(t, err)
+	assert.Equal(t, now.Add(45*time.Minute), *resumeAt)
+
+	resumeAt, err = pauseResumeAt(models.PauseStoreRequest{}, now)
+	assert.NoError(t, err)
+	assert.Nil(t, resumeAt)
+
+	past := now.Add(-time.Minute)
+	_, err = pauseResumeAt(models.PauseStoreRequest{ResumeAt: &past}, now)
+	assert.Error(t, err)
+	_, err = pauseResumeAt(models.PauseStoreRequest{ResumeAt: &now, DurationMinutes: 10}, now)
+	assert.Error(t, err)
+}
+
+// Teste para SetStoreHours com horário inválido (recusado antes do banco)
+func TestSetStoreHoursInvalid(t *testing.T) {
+	router, mockDB := setupTest()
+
+	router.PUT("/store/hours", func(c *gin.Context) {
+		SetStoreHours(c, mockDB)
+	})
+
+	body := `{"hours": [{"weekday": 7, "open_time": "18:00", "close_time": "23:00"}]}`
+	req, _ := http.NewRequest("PUT", "/store/hours", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,317 @@
+package handlers
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+
+	// Configurações da aplicação (loja)
+	"backend-hamburgueria/config"
+	// Modelos de dados da aplicação
+	"backend-hamburgueria/models"
+
+	// Framework web Gin
+	"github.com/gin-gonic/gin"
+	// Driver do PostgreSQL (códigos de erro)
+	"github.com/lib/pq"
+)
+
+// ===== COMANDAS DE MESA =====
+
+// Status da comanda
+const (
+	tableSessionOpen   = "open"   // Mesa consumindo
+	tableSessionClosed = "closed" // Conta fechada
+	tableSessionMerged = "merged" // Juntada a outra comanda
+)
+
+// GetTableSessions godoc
+// @Summary      Lista as comandas de mesa
+// @Description  Retorna as comandas com consumo acumulado (padrão: somente as abertas; ?status=closed ou ?status=merged)
+// @Tags         Tables
+// @Produce      json
+// @Param        status  query     string  false  "open (padrão), closed ou merged"
+// @Success      200     {array}   models.TableSession
+// @Failure      400     {object}  models.ErrorResponse "Status inválido"
+// @Failure      500     {object}  models.ErrorResponse "Erro ao buscar comandas"
+// @Router       /api/table-sessions [get]
+func GetTableSessions(c *gin.Context, db DBInterface) {
+	status := c.DefaultQuery("status", tableSessionOpen)
+	if status != tableSessionOpen && status != tableSessionClosed && status != tableSessionMerged {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Status inválido (use open, closed ou merged)"})
+		return
+	}
+
+	rows, err := db.Query(`
+		SELECT `+tableSessionColumns+`
+		FROM table_sessions s
+		WHERE s.store_id = $1 AND s.status = $2
+		ORDER BY s.table_number, s.opened_at
+	`, config.StoreID(), status)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar comandas"})
+		return
+	}
+	defer rows.Close()
+
+	sessions := []models.TableSession{}
+	for rows.Next() {
+		var session models.TableSession
+		if err := scanTableSession(rows, &session); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao ler comanda"})
+			return
+		}
+		sessions = append(sessions, session)
+	}
+
+	c.JSON(http.StatusOK, sessions)
+}
+
+// OpenTableSession godoc
+// @Summary      Abre a comanda de uma mesa
+// @Description  Abre a comanda manualmente; pedidos no salão também abrem a comanda automaticamente
+// @Tags         Tables
+// @Accept       json
+// @Produce      json
+// @Param        body  body      models.OpenTableSessionRequest  true  "Mesa"
+// @Success      201   {object}  models.TableSession
+// @Failure      400   {object}  models.ErrorResponse "Número da mesa inválido"
+// @Failure      409   {object}  models.ErrorResponse "Mesa já possui comanda aberta"
+// @Router       /api/table-sessions [post]
+func OpenTableSession(c *gin.Context, db DBInterface) {
+	// ===== VALIDAR DADOS DE ENTRADA =====
+	var req models.OpenTableSessionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos"})
+		return
+	}
+	if req.TableNumber <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Número da mesa inválido"})
+		return
+	}
+
+	// ===== ABRIR COMANDA =====
+	// O índice único parcial garante uma única comanda aberta por mesa
+	var session models.TableSession
+	err := db.QueryRow(`
+		INSERT INTO table_sessions (store_id, table_number, notes)
+		VALUES ($1, $2, $3)
+		RETURNING id, table_number, status, notes, opened_at
+	`, config.StoreID(), req.TableNumber, req.Notes).Scan(&session.ID, &session.TableNumber, &session.Status, &session.Notes, &session.OpenedAt)
+	if isUniqueViolation(err) {
+		c.JSON(http.StatusConflict, gin.H{"error": "Mesa já possui comanda aberta"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao abrir comanda"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, session)
+}
+
+// GetTableSession godoc
+// @Summary      Detalhes da comanda
+// @Description  Retorna a comanda com todos os pedidos (rodadas) e o consumo acumulado
+// @Tags         Tables
+// @Produce      json
+// @Param        id   path      int  true  "ID da comanda"
+// @Success      200  {object}  models.TableSession
+// @Failure      400  {object}  models.ErrorResponse "ID da comanda inválido"
+// @Failure      404  {object}  models.ErrorResponse "Comanda não encontrada"
+// @Router       /api/table-sessions/{id} [get]
+func GetTableSession(c *gin.Context, db DBInterface) {
+	sessionID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID da comanda inválido"})
+		return
+	}
+
+	session, err := loadTableSession(db, sessionID)
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Comanda não encontrada"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar comanda"})
+		return
+	}
+
+	c.JSON(http.StatusOK, session)
+}
+
+// CloseTableSession godoc
+// @Summary      Fecha a conta da mesa
+// @Description  Fecha a comanda e grava o valor final; recusa enquanto houver pedidos não entregues ou divisão da conta pendente
+// @Tags         Tables
+// @Produce      json
+// @Param        id   path      int  true  "ID da comanda"
+// @Success      200  {object}  models.TableSession
+// @Failure      404  {object}  models.ErrorResponse "Comanda não encontrada"
+// @Failure      409  {object}  models.ErrorResponse "Comanda já fechada, com pedidos pendentes ou com divisão da conta pendente"
+// @Router       /api/table-sessions/{id}/close [post]
+func CloseTableSession(c *gin.Context, db DBInterface) {
+	sessionID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID da comanda inválido"})
+		return
+	}
+
+	// ===== INICIAR TRANSAÇÃO =====
+	tx, err := db.Begin()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao iniciar transação"})
+		return
+	}
+	defer tx.Rollback()
+
+	// Travar a comanda: nenhum pedido novo entra enquanto a conta fecha
+	var status string
+	err = tx.QueryRow(`SELECT status FROM table_sessions WHERE id = $1 FOR UPDATE`, sessionID).Scan(&status)
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Comanda não encontrada"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar comanda"})
+		return
+	}
+	if status != tableSessionOpen {
+		c.JSON(http.StatusConflict, gin.H{"error": "Comanda já está fechada"})
+		return
+	}
+
+	// ===== CONFERIR PEDIDOS =====
+	var pending int
+	err = tx.QueryRow(`
+		SELECT COUNT(*) FROM orders WHERE table_session_id = $1 AND status <> 'delivered'
+	`, sessionID).Scan(&pending)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao conferir pedidos da comanda"})
+		return
+	}
+	if pending > 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": "Ainda há pedidos não entregues nesta mesa", "pending_orders": pending})
+		return
+	}
+
+	// ===== CONFERIR DIVISÃO DA CONTA =====
+	if err := checkSessionSplit(tx, sessionID); err != nil {
+		if err == errSplitOpen || err == errSplitOutdated {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao conferir divisão da conta"})
+		}
+		return
+	}
+
+	// ===== FECHAR CONTA =====
+	_, err = tx.Exec(`
+		UPDATE table_sessions
+		SET status = $1, closed_at = CURRENT_TIMESTAMP,
+		    total_amount = (SELECT COALESCE(SUM(total_amount), 0) FROM orders WHERE table_session_id = $2)
+		WHERE id = $2
+	`, tableSessionClosed, sessionID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao fechar comanda"})
+		return
+	}
+	session, err := loadTableSession(tx, sessionID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao fechar comanda"})
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao fechar comanda"})
+		return
+	}
+
+	c.JSON(http.StatusOK, session)
+}
+
+// ===== FUNÇÕES AUXILIARES =====
+
+// tableSessionColumns lista as colunas (alias "s") na ordem lida por scanTableSession
+// Comandas abertas mostram o consumo corrente; fechadas, o valor gravado no fechamento
+const tableSessionColumns = `s.id, s.table_number, s.status, s.notes, s.opened_at, s.closed_at, s.merged_into,
+	(SELECT COUNT(*) FROM orders o WHERE o.table_session_id = s.id),
+	CASE WHEN s.status = 'open'
+		THEN (SELECT COALESCE(SUM(o.total_amount), 0) FROM orders o WHERE o.table_session_id = s.id)
+		ELSE s.total_amount END`
+
+// scanTableSession lê uma linha selecionada com tableSessionColumns
+func scanTableSession(row rowScanner, session *models.TableSession) error {
+	return row.Scan(&session.ID, &session.TableNumber, &session.Status, &session.Notes, &session.OpenedAt,
+		&session.ClosedAt, &session.MergedInto, &session.OrderCount, &session.TotalAmount)
+}
+
+// loadTableSession busca a comanda com seus pedidos
+func loadTableSession(q queryer, sessionID int) (models.TableSession, error) {
+	var session models.TableSession
+	err := scanTableSession(q.QueryRow(`SELECT `+tableSessionColumns+` FROM table_sessions s WHERE s.id = $1`, sessionID), &session)
+	if err != nil {
+		return session, err
+	}
+
+	rows, err := q.Query(`SELECT `+orderColumns+` FROM orders WHERE table_session_id = $1 ORDER BY created_at, id`, sessionID)
+	if err != nil {
+		return session, err
+	}
+	defer rows.Close()
+
+	session.Orders = []models.Order{}
+	for rows.Next() {
+		var order models.Order
+		if err := scanOrder(rows, &order); err != nil {
+			return session, err
+		}
+		session.Orders = append(session.Orders, order)
+	}
+	return session, rows.Err()
+}
+
+// attachTableSession retorna a comanda aberta da mesa, abrindo uma se necessário
+// Dois pedidos simultâneos para a mesma mesa acabam na mesma comanda: o índice único
+// parcial faz o segundo INSERT não fazer nada, e a nova busca encontra a comanda do primeiro.
+// A comanda fica travada (FOR SHARE) até o fim da transação, para não ser fechada no meio do pedido.
+func attachTableSession(q queryer, storeID, tableNumber int) (int, error) {
+	var sessionID int
+	var err error
+	for attempt := 0; attempt < 3; attempt++ {
+		err = q.QueryRow(`
+			WITH inserted AS (
+				INSERT INTO table_sessions (store_id, table_number)
+				VALUES ($1, $2)
+				ON CONFLICT (store_id, table_number) WHERE status = 'open' DO NOTHING
+				RETURNING id
+			)
+			SELECT id FROM inserted
+			UNION ALL
+			SELECT id FROM table_sessions WHERE store_id = $1 AND table_number = $2 AND status = 'open'
+			LIMIT 1
+		`, storeID, tableNumber).Scan(&sessionID)
+		if err == sql.ErrNoRows {
+			// A comanda concorrente foi gravada depois da foto deste comando: tentar de novo
+			continue
+		}
+		if err != nil {
+			return 0, err
+		}
+
+		err = q.QueryRow(`
+			SELECT id FROM table_sessions WHERE id = $1 AND status = 'open' FOR SHARE
+		`, sessionID).Scan(&sessionID)
+		if err != sql.ErrNoRows {
+			return sessionID, err
+		}
+		// Fechada enquanto o pedido era criado: a próxima volta abre uma comanda nova
+	}
+	return 0, err
+}
+
+// isUniqueViolation indica se o erro do Postgres é de violação de chave única
+func isUniqueViolation(err error) bool {
+	pqErr, ok := err.(*pq.Error)
+	return ok && pqErr.Code == "23505"
+}
@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+// Teste para GetTableSessions com status inválido e falha no banco
+func TestGetTableSessions(t *testing.T) {
+	router, mockDB := setupTest()
+
+	router.GET("/table-sessions", func(c *gin.Context) {
+		GetTableSessions(c, mockDB)
+	})
+
+	req, _ := http.NewRequest("GET", "/table-sessions?status=paid", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	mockDB.QueryFunc = func(query string, args ...interface{}) (*sql.Rows, error) {
+		return nil, sql.ErrConnDone
+	}
+	req, _ = http.NewRequest("GET", "/table-sessions", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+// Teste para OpenTableSession sem mesa
+func TestOpenTableSessionInvalidTable(t *testing.T) {
+	router, mockDB := setupTest()
+
+	router.POST("/table-sessions", func(c *gin.Context) {
+		OpenTableSession(c, mockDB)
+	})
+
+	req, _ := http.NewRequest("POST", "/table-sessions", strings.NewReader(`{"table_number": 0}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+// Teste para CloseTableSession com falha ao iniciar a transação
+func TestCloseTableSession(t *testing.T) {
+	router, mockDB := setupTest()
+
+	router.POST("/table-sessions/:id/close", func(c *gin.Context) {
+		CloseTableSession(c, mockDB)
+	})
+
+	mockDB.BeginFunc = func() (*sql.Tx, error) {
+		return nil, sql.ErrConnDone
+	}
+
+	req, _ := http.NewRequest("POST", "/table-sessions/3/close", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+// Teste da detecção de violação de chave única
+func TestIsUniqueViolation(t *testing.T) {
+	assert.True(t, isUniqueViolation(&pq.Error{Code: "23505"}))
+	assert.False(t, isUniqueViolation(&pq.Error{Code: "23503"}))
+	assert.False(t, isUniqueViolation(sql.ErrNoRows))
+	assert.False(t, isUniqueViolation(nil))
+}
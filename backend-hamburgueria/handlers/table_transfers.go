@@ -0,0 +1,616 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	// Configurações da aplicação (dia de operação)
+	"backend-hamburgueria/config"
+	// Modelos de dados da aplicação
+	"backend-hamburgueria/models"
+
+	// Framework web Gin
+	"github.com/gin-gonic/gin"
+	// Driver do PostgreSQL (arrays)
+	"github.com/lib/pq"
+)
+
+// ===== TRANSFERÊNCIA E JUNÇÃO DE MESAS =====
+
+// errTransferItem indica um item que não pertence à comanda ou quantidade acima da disponível
+var errTransferItem = errors.New("Item não pertence a esta comanda ou quantidade maior que a do pedido")
+
+// errTransferBundle indica parte de um combo selecionada para transferência
+var errTransferBundle = errors.New("Combos são transferidos inteiros: informe a quantidade total do combo")
+
+// errTransferOrder indica um pedido selecionado que não pertence à comanda de origem
+var errTransferOrder = errors.New("Pedido não pertence a esta comanda")
+
+// itemMove é um item (ou parte dele) que muda de mesa
+type itemMove struct {
+	ItemID    int
+	OrderID   int
+	Quantity  int // Quantidade movida
+	Available int // Quantidade atual do item
+}
+
+// TransferTableSession godoc
+// @Summary      Transfere a mesa
+// @Description  Move a comanda inteira, pedidos selecionados (order_ids) ou itens selecionados (items; combos vão inteiros) para outra mesa
+// @Tags         Tables
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                          true  "ID da comanda de origem"
+// @Param        body  body      models.TransferTableRequest  true  "Destino e o que mover"
+// @Success      200   {object}  models.TableSession "Comanda de destino"
+// @Failure      400   {object}  models.ErrorResponse "Dados inválidos"
+// @Failure      404   {object}  models.ErrorResponse "Comanda não encontrada"
+// @Failure      409   {object}  models.ErrorResponse "Mesa de destino já tem comanda aberta ou conta com parcelas pagas"
+// @Router       /api/table-sessions/{id}/transfer [post]
+func TransferTableSession(c *gin.Context, db DBInterface) {
+	// ===== VALIDAR ENTRADA =====
+	sessionID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID da comanda inválido"})
+		return
+	}
+	var req models.TransferTableRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos"})
+		return
+	}
+	if err := validateTransferRequest(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// ===== INICIAR TRANSAÇÃO =====
+	tx, err := db.Begin()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao iniciar transação"})
+		return
+	}
+	defer tx.Rollback()
+
+	// ===== CONFERIR ORIGEM E DESTINO =====
+	var storeID, fromTable int
+	var status string
+	err = tx.QueryRow(`
+		SELECT store_id, table_number, status FROM table_sessions WHERE id = $1 FOR UPDATE
+	`, sessionID).Scan(&storeID, &fromTable, &status)
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Comanda não encontrada"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar comanda"})
+		return
+	}
+	if status != tableSessionOpen {
+		c.JSON(http.StatusConflict, gin.H{"error": "Comanda não está aberta"})
+		return
+	}
+	if req.ToTableNumber == fromTable {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Mesa de destino igual à mesa atual"})
+		return
+	}
+	if err := checkOrderTable(tx, storeID, req.ToTableNumber); err != nil {
+		if err == errTableNotFound || err == errTableInactive {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao validar mesa"})
+		}
+		return
+	}
+	details := "mesa " + strconv.Itoa(fromTable) + " → mesa " + strconv.Itoa(req.ToTableNumber)
+
+	// ===== MOVER =====
+	var targetID int
+	var movedOrders []int
+	switch {
+	case len(req.OrderIDs) == 0 && len(req.Items) == 0:
+		// Comanda inteira: a mesa de destino precisa estar livre
+		targetID = sessionID
+		_, err = tx.Exec(`UPDATE table_sessions SET table_number = $1 WHERE id = $2`, req.ToTableNumber, sessionID)
+		if isUniqueViolation(err) {
+			c.JSON(http.StatusConflict, gin.H{"error": "Mesa de destino já tem comanda aberta, use a junção de mesas"})
+			return
+		}
+		if err == nil {
+			movedOrders, err = moveSessionOrders(tx, sessionID, sessionID, req.ToTableNumber)
+		}
+	case len(req.OrderIDs) > 0:
+		// Pedidos selecionados: vão para a comanda aberta do destino (aberta agora, se preciso)
+		targetID, err = attachTableSession(tx, storeID, req.ToTableNumber)
+		if err == nil {
+			err = cancelTransferSplits(tx, sessionID, targetID)
+		}
+		if err == nil {
+			movedOrders, err = moveSelectedOrders(tx, sessionID, targetID, req.ToTableNumber, req.OrderIDs)
+		}
+	default:
+		// Itens selecionados: viram pedidos novos na comanda do destino
+		targetID, err = attachTableSession(tx, storeID, req.ToTableNumber)
+		if err == nil {
+			err = cancelTransferSplits(tx, sessionID, targetID)
+		}
+		if err == nil {
+			movedOrders, err = moveSelectedItems(tx, storeID, sessionID, targetID, req.ToTableNumber, req.Items)
+		}
+	}
+	if err == errTransferItem || err == errTransferOrder || err == errTransferBundle {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err == errSplitPaidShares {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao transferir mesa"})
+		return
+	}
+
+	// ===== REGISTRAR NO HISTÓRICO =====
+	for _, orderID := range movedOrders {
+		if err := recordOrderEvent(tx, orderID, orderEventTransferred, "", details); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao registrar histórico do pedido"})
+			return
+		}
+	}
+
+	target, err := loadTableSession(tx, targetID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao transferir mesa"})
+		return
+	}
+
+	// ===== COMMIT DA TRANSAÇÃO =====
+	if err := tx.Commit(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao transferir mesa"})
+		return
+	}
+	for _, orderID := range movedOrders {
+		orderEvents.Publish(orderEvent{Type: eventOrderMoved, OrderID: orderID})
+	}
+
+	c.JSON(http.StatusOK, target)
+}
+
+// MergeTableSessions godoc
+// @Summary      Junta duas mesas
+// @Description  Incorpora os pedidos de outra comanda aberta nesta; a comanda de origem fica como merged
+// @Tags         Tables
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                               true  "ID da comanda que recebe os pedidos"
+// @Param        body  body      models.MergeTableSessionsRequest  true  "Comanda incorporada"
+// @Success      200   {object}  models.TableSession
+// @Failure      400   {object}  models.ErrorResponse "Dados inválidos"
+// @Failure      404   {object}  models.ErrorResponse "Comanda não encontrada"
+// @Failure      409   {object}  models.ErrorResponse "Comanda não está aberta ou conta com parcelas pagas"
+// @Router       /api/table-sessions/{id}/merge [post]
+func MergeTableSessions(c *gin.Context, db DBInterface) {
+	// ===== VALIDAR ENTRADA =====
+	targetID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID da comanda inválido"})
+		return
+	}
+	var req models.MergeTableSessionsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos"})
+		return
+	}
+	if req.SourceSessionID <= 0 || req.SourceSessionID == targetID {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Informe outra comanda para juntar"})
+		return
+	}
+
+	// ===== INICIAR TRANSAÇÃO =====
+	tx, err := db.Begin()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao iniciar transação"})
+		return
+	}
+	defer tx.Rollback()
+
+	// Travar as duas comandas sempre na mesma ordem (por ID) para evitar deadlock
+	rows, err := tx.Query(`
+		SELECT id, store_id, table_number, status FROM table_sessions
+		WHERE id = ANY($1) ORDER BY id FOR UPDATE
+	`, pq.Array([]int{targetID, req.SourceSessionID}))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar comandas"})
+		return
+	}
+	type lockedSession struct {
+		StoreID, Table int
+		Status         string
+	}
+	sessions := make(map[int]lockedSession)
+	for rows.Next() {
+		var id int
+		var session lockedSession
+		if err := rows.Scan(&id, &session.StoreID, &session.Table, &session.Status); err != nil {
+			rows.Close()
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao ler comanda"})
+			return
+		}
+		sessions[id] = session
+	}
+	rows.Close()
+
+	target, okTarget := sessions[targetID]
+	source, okSource := sessions[req.SourceSessionID]
+	if !okTarget || !okSource || target.StoreID != source.StoreID {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Comanda não encontrada"})
+		return
+	}
+	if target.Status != tableSessionOpen || source.Status != tableSessionOpen {
+		c.JSON(http.StatusConflict, gin.H{"error": "As duas comandas precisam estar abertas"})
+		return
+	}
+
+	// ===== JUNTAR =====
+	// As duas contas mudam: as divisões em vigor deixam de valer
+	if err := cancelTransferSplits(tx, req.SourceSessionID, targetID); err != nil {
+		if err == errSplitPaidShares {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao atualizar divisão da conta"})
+		}
+		return
+	}
+	movedOrders, err := moveSessionOrders(tx, req.SourceSessionID, targetID, target.Table)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao juntar mesas"})
+		return
+	}
+	if _, err := tx.Exec(`
+		UPDATE table_sessions SET status = $1, merged_into = $2, closed_at = CURRENT_TIMESTAMP WHERE id = $3
+	`, tableSessionMerged, targetID, req.SourceSessionID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao juntar mesas"})
+		return
+	}
+	details := "mesa " + strconv.Itoa(source.Table) + " juntada à mesa " + strconv.Itoa(target.Table)
+	for _, orderID := range movedOrders {
+		if err := recordOrderEvent(tx, orderID, orderEventMerged, "", details); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao registrar histórico do pedido"})
+			return
+		}
+	}
+
+	merged, err := loadTableSession(tx, targetID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao juntar mesas"})
+		return
+	}
+
+	// ===== COMMIT DA TRANSAÇÃO =====
+	if err := tx.Commit(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao juntar mesas"})
+		return
+	}
+	for _, orderID := range movedOrders {
+		orderEvents.Publish(orderEvent{Type: eventOrderMoved, OrderID: orderID})
+	}
+
+	c.JSON(http.StatusOK, merged)
+}
+
+// ===== FUNÇÕES AUXILIARES =====
+
+// validateTransferRequest confere o destino e o que será movido
+func validateTransferRequest(req models.TransferTableRequest) error {
+	if req.ToTableNumber <= 0 {
+		return errors.New("Mesa de destino inválida")
+	}
+	if len(req.OrderIDs) > 0 && len(req.Items) > 0 {
+		return errors.New("Informe pedidos ou itens, não os dois")
+	}
+	seen := make(map[int]bool)
+	for _, orderID := range req.OrderIDs {
+		if orderID <= 0 || seen[orderID] {
+			return errors.New("Lista de pedidos inválida")
+		}
+		seen[orderID] = true
+	}
+	seen = make(map[int]bool)
+	for _, item := range req.Items {
+		if item.OrderItemID <= 0 || item.Quantity <= 0 || seen[item.OrderItemID] {
+			return errors.New("Lista de itens inválida")
+		}
+		seen[item.OrderItemID] = true
+	}
+	return nil
+}
+
+// cancelTransferSplits cancela as divisões das duas comandas envolvidas numa transferência
+// Comandas com parcelas pagas não podem perder nem receber pedidos
+func cancelTransferSplits(q queryer, fromSessionID, toSessionID int) error {
+	if err := cancelSessionSplits(q, fromSessionID); err != nil {
+		return err
+	}
+	return cancelSessionSplits(q, toSessionID)
+}
+
+// moveSessionOrders move todos os pedidos de uma comanda para a comanda/mesa de destino
+func moveSessionOrders(q queryer, fromSessionID, toSessionID, toTable int) ([]int, error) {
+	rows, err := q.Query(`
+		UPDATE orders SET table_session_id = $1, table_number = $2, updated_at = CURRENT_TIMESTAMP
+		WHERE table_session_id = $3
+		RETURNING id
+	`, toSessionID, toTable, fromSessionID)
+	if err != nil {
+		return nil, err
+	}
+	return scanIDs(rows)
+}
+
+// moveSelectedOrders move os pedidos informados; todos precisam ser da comanda de origem
+func moveSelectedOrders(q queryer, fromSessionID, toSessionID, toTable int, orderIDs []int) ([]int, error) {
+	rows, err := q.Query(`
+		UPDATE orders SET table_session_id = $1, table_number = $2, updated_at = CURRENT_TIMESTAMP
+		WHERE table_session_id = $3 AND id = ANY($4)
+		RETURNING id
+	`, toSessionID, toTable, fromSessionID, pq.Array(orderIDs))
+	if err != nil {
+		return nil, err
+	}
+	moved, err := scanIDs(rows)
+	if err == nil && len(moved) != len(orderIDs) {
+		return nil, errTransferOrder
+	}
+	return moved, err
+}
+
+// moveSelectedItems move itens (ou parte deles) para pedidos novos na mesa de destino
+// Cada pedido de origem gera um pedido novo com os itens movidos; se todos os itens de um
+// pedido forem movidos por inteiro, o próprio pedido muda de mesa. Combos mudam de mesa inteiros,
+// com todos os seus produtos. Promoções acompanham os itens, o desconto do cupom é rateado pelo
+// subtotal, e taxa de serviço e total dos pedidos envolvidos são recalculados pela soma dos itens.
+func moveSelectedItems(q queryer, storeID, fromSessionID, toSessionID, toTable int, items []models.TransferItem) ([]int, error) {
+	// ===== CARREGAR E TRAVAR ITENS =====
+	movesByOrder := make(map[int][]itemMove)
+	selected := make(map[int]bool)
+	var orderIDs []int
+	for _, item := range items {
+		move := itemMove{ItemID: item.OrderItemID, Quantity: item.Quantity}
+		var bundleID, bundleSeq *int
+		err := q.QueryRow(`
+			SELECT oi.order_id, oi.quantity, oi.bundle_id, oi.bundle_seq
+			FROM order_items oi
+			JOIN orders o ON o.id = oi.order_id
+			WHERE oi.id = $1 AND o.table_session_id = $2
+			FOR UPDATE OF oi
+		`, item.OrderItemID, fromSessionID).Scan(&move.OrderID, &move.Available, &bundleID, &bundleSeq)
+		if err == sql.ErrNoRows || (err == nil && move.Quantity > move.Available) {
+			return nil, errTransferItem
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		// Produto de combo: os demais produtos do mesmo combo vão junto
+		group := []itemMove{move}
+		if bundleID != nil && bundleSeq != nil {
+			siblings, err := loadBundleSiblings(q, move, *bundleID, *bundleSeq)
+			if err != nil {
+				return nil, err
+			}
+			if group, err = bundleMoves(move, siblings); err != nil {
+				return nil, err
+			}
+		}
+		for _, m := range group {
+			if selected[m.ItemID] {
+				continue
+			}
+			selected[m.ItemID] = true
+			if _, ok := movesByOrder[m.OrderID]; !ok {
+				orderIDs = append(orderIDs, m.OrderID)
+			}
+			movesByOrder[m.OrderID] = append(movesByOrder[m.OrderID], m)
+		}
+	}
+
+	// ===== MOVER POR PEDIDO DE ORIGEM =====
+	serviceDay := config.ServiceDayStart(time.Now()).Format("2006-01-02")
+	var moved []int
+	for _, sourceID := range orderIDs {
+		moves := movesByOrder[sourceID]
+
+		var itemCount, totalQuantity int
+		if err := q.QueryRow(`
+			SELECT COUNT(*), COALESCE(SUM(quantity), 0) FROM order_items WHERE order_id = $1
+		`, sourceID).Scan(&itemCount, &totalQuantity); err != nil {
+			return nil, err
+		}
+		if movesWholeOrder(moves, itemCount, totalQuantity) {
+			ids, err := moveSelectedOrders(q, fromSessionID, toSessionID, toTable, []int{sourceID})
+			if err != nil {
+				return nil, err
+			}
+			moved = append(moved, ids...)
+			continue
+		}
+
+		// Pedido novo na mesa de destino, com os dados e o andamento do pedido de origem
+		// O desconto do pedido que não vem das promoções dos itens é o do cupom
+		var serviceRate, couponDiscount float64
+		if err := q.QueryRow(`
+			SELECT CASE WHEN subtotal > 0 THEN service_charge / subtotal ELSE 0 END,
+				COALESCE(discount_amount, 0) - COALESCE((SELECT SUM(discount_amount) FROM order_item_promotions WHERE order_id = $1), 0)
+			FROM orders WHERE id = $1
+		`, sourceID).Scan(&serviceRate, &couponDiscount); err != nil {
+			return nil, err
+		}
+		ticketNumber, err := nextTicketNumber(q, storeID, serviceDay)
+		if err != nil {
+			return nil, err
+		}
+		var newOrderID int
+		err = q.QueryRow(`
+			INSERT INTO orders (customer_name, table_number, total_amount, notes, status, release_at, estimated_ready_at,
+				store_id, service_day, ticket_number, order_type, table_session_id, allergies)
+			SELECT customer_name, $1, 0, notes, status, release_at, estimated_ready_at, store_id, $2, $3, order_type, $4, allergies
+			FROM orders WHERE id = $5
+			RETURNING id
+		`, toTable, serviceDay, ticketNumber, toSessionID, sourceID).Scan(&newOrderID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, move := range moves {
+			itemID := move.ItemID
+			// Parte do item: as unidades movidas viram um item novo, com a sua parte das promoções
+			if move.Quantity < move.Available {
+				if itemID, err = splitOrderItem(q, move.ItemID, move.Quantity); err != nil {
+					return nil, err
+				}
+			}
+			if err := moveOrderItem(q, itemID, newOrderID); err != nil {
+				return nil, err
+			}
+		}
+		// ===== RECALCULAR TOTAIS =====
+		// Desconto rateado entre os dois pedidos; ambos mantêm a proporção da taxa de serviço do pedido de origem
+		if err := splitOrderDiscount(q, sourceID, newOrderID, toCents(couponDiscount)); err != nil {
+			return nil, err
+		}
+		for _, orderID := range []int{sourceID, newOrderID} {
+			if err := repriceOrderItems(q, orderID, serviceRate); err != nil {
+				return nil, err
+			}
+		}
+		moved = append(moved, newOrderID)
+	}
+	return moved, nil
+}
+
+// loadBundleSiblings trava e lê os outros itens do mesmo combo no pedido (mesmo bundle_id e bundle_seq)
+func loadBundleSiblings(q queryer, move itemMove, bundleID, bundleSeq int) ([]itemMove, error) {
+	rows, err := q.Query(`
+		SELECT id, quantity FROM order_items
+		WHERE order_id = $1 AND bundle_id = $2 AND bundle_seq = $3 AND id <> $4
+		FOR UPDATE
+	`, move.OrderID, bundleID, bundleSeq, move.ItemID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var siblings []itemMove
+	for rows.Next() {
+		sibling := itemMove{OrderID: move.OrderID}
+		if err := rows.Scan(&sibling.ItemID, &sibling.Available); err != nil {
+			return nil, err
+		}
+		sibling.Quantity = sibling.Available
+		siblings = append(siblings, sibling)
+	}
+	return siblings, rows.Err()
+}
+
+// bundleMoves monta a transferência de um combo: o item selecionado e os demais produtos do combo, inteiros
+// Mover só parte da quantidade separaria os produtos do combo entre as mesas
+func bundleMoves(move itemMove, siblings []itemMove) ([]itemMove, error) {
+	if move.Quantity != move.Available {
+		return nil, errTransferBundle
+	}
+	return append([]itemMove{move}, siblings...), nil
+}
+
+// moveOrderItem passa o item (e as promoções aplicadas nele) para outro pedido
+func moveOrderItem(q queryer, itemID, orderID int) error {
+	if _, err := q.Exec(`UPDATE order_items SET order_id = $1 WHERE id = $2`, orderID, itemID); err != nil {
+		return err
+	}
+	_, err := q.Exec(`UPDATE order_item_promotions SET order_id = $1 WHERE order_item_id = $2`, orderID, itemID)
+	return err
+}
+
+// splitOrderDiscount refaz o desconto dos pedidos de origem e novo depois da transferência
+// Cada um fica com as promoções dos próprios itens mais a parte do cupom proporcional ao subtotal.
+// O uso do cupom continua registrado uma única vez, no pedido de origem
+func splitOrderDiscount(q queryer, sourceID, newOrderID int, couponDiscount int64) error {
+	var sourceSubtotal, movedSubtotal float64
+	if err := q.QueryRow(`
+		SELECT COALESCE(SUM(total_price) FILTER (WHERE order_id = $1), 0),
+			COALESCE(SUM(total_price) FILTER (WHERE order_id = $2), 0)
+		FROM order_items WHERE order_id IN ($1, $2)
+	`, sourceID, newOrderID).Scan(&sourceSubtotal, &movedSubtotal); err != nil {
+		return err
+	}
+	shares := couponDiscountShares(couponDiscount, sourceSubtotal, movedSubtotal)
+	for i, orderID := range []int{sourceID, newOrderID} {
+		if _, err := q.Exec(`
+			UPDATE orders SET discount_amount = $2 + COALESCE((SELECT SUM(discount_amount) FROM order_item_promotions WHERE order_id = $1), 0)
+			WHERE id = $1
+		`, orderID, fromCents(shares[i])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// couponDiscountShares rateia o desconto do cupom (em centavos) entre origem e pedido novo pelo subtotal
+func couponDiscountShares(couponDiscount int64, sourceSubtotal, movedSubtotal float64) []int64 {
+	if couponDiscount <= 0 {
+		return []int64{0, 0}
+	}
+	return allocateCents(couponDiscount, []int64{toCents(sourceSubtotal), toCents(movedSubtotal)})
+}
+
+// repriceOrderItems recalcula subtotal, taxa de serviço e total do pedido pela soma dos itens
+// O alerta de alergia acompanha os itens que ficaram no pedido
+// serviceRate é a fração do subtotal cobrada como taxa de serviço (ex.: 0.10)
+func repriceOrderItems(q queryer, orderID int, serviceRate float64) error {
+	_, err := q.Exec(`
+		UPDATE orders o
+		SET subtotal = items.subtotal,
+		    service_charge = ROUND(items.subtotal * $2, 2),
+		    total_amount = items.subtotal - o.discount_amount + ROUND(items.subtotal * $2, 2) + o.delivery_fee + o.tip_amount,
+		    allergy_alert = items.allergy_alert,
+		    updated_at = CURRENT_TIMESTAMP
+		FROM (
+			SELECT COALESCE(SUM(total_price), 0) AS subtotal, COALESCE(BOOL_OR(allergen_conflicts <> '{}'), FALSE) AS allergy_alert
+			FROM order_items WHERE order_id = $1
+		) items
+		WHERE o.id = $1
+	`, orderID, serviceRate)
+	return err
+}
+
+// movesWholeOrder indica se os itens movidos são todos os itens do pedido, por inteiro
+func movesWholeOrder(moves []itemMove, itemCount, totalQuantity int) bool {
+	if len(moves) != itemCount {
+		return false
+	}
+	quantity := 0
+	for _, move := range moves {
+		if move.Quantity != move.Available {
+			return false
+		}
+		quantity += move.Quantity
+	}
+	return quantity == totalQuantity
+}
+
+// scanIDs lê uma coluna de IDs e fecha as linhas
+func scanIDs(rows *sql.Rows) ([]int, error) {
+	defer rows.Close()
+	ids := []int{}
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
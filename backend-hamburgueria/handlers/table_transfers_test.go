@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"backend-hamburgueria/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// Teste da validação do pedido de transferência
+func TestValidateTransferRequest(t *testing.T) {
+	assert.NoError(t, validateTransferRequest(models.TransferTableRequest{ToTableNumber: 4}))
+	assert.NoError(t, validateTransferRequest(models.TransferTableRequest{ToTableNumber: 4, OrderIDs: []int{1, 2}}))
+	assert.NoError(t, validateTransferRequest(models.TransferTableRequest{ToTableNumber: 4, Items: []models.TransferItem{{OrderItemID: 7, Quantity: 1}}}))
+
+	assert.Error(t, validateTransferRequest(models.TransferTableRequest{ToTableNumber: 0}))
+	assert.Error(t, validateTransferRequest(models.TransferTableRequest{ToTableNumber: 4, OrderIDs: []int{1, 1}}))
+	assert.Error(t, validateTransferRequest(models.TransferTableRequest{ToTableNumber: 4, Items: []models.TransferItem{{OrderItemID: 7, Quantity: 0}}}))
+	assert.Error(t, validateTransferRequest(models.TransferTableRequest{
+		ToTableNumber: 4,
+		OrderIDs:      []int{1},
+		Items:         []models.TransferItem{{OrderItemID: 7, Quantity: 1}},
+	}))
+}
+
+// Teste para saber se os itens movidos esvaziam o pedido
+func TestMovesWholeOrder(t *testing.T) {
+	moves := []itemMove{{ItemID: 1, Quantity: 2, Available: 2}, {ItemID: 2, Quantity: 1, Available: 1}}
+	assert.True(t, movesWholeOrder(moves, 2, 3))
+	assert.False(t, movesWholeOrder(moves, 3, 4))
+
+	partial := []itemMove{{ItemID: 1, Quantity: 1, Available: 2}}
+	assert.False(t, movesWholeOrder(partial, 1, 2))
+}
+
+// Teste do desconto na transferência de parte de um item com promoção e de um pedido com cupom
+func TestTransferDiscountShares(t *testing.T) {
+	// Promoção de 3 unidades (R$ 10,00) em um item com 3 unidades: 1 unidade muda de mesa
+	quantity, discount := splitPromotionShare(3, 10, 1, 3)
+	assert.Equal(t, 1, quantity)
+	assert.Equal(t, 3.33, discount)
+	// A parte que fica soma o restante, sem perder centavos
+	quantity, discount = splitPromotionShare(3, 10, 2, 3)
+	assert.Equal(t, 2, quantity)
+	assert.Equal(t, 6.67, discount)
+
+	// Cupom de R$ 9,00 em um pedido de R$ 60,00 com R$ 30,00 movidos: metade para cada pedido
+	assert.Equal(t, []int64{450, 450}, couponDiscountShares(900, 30, 30))
+	assert.Equal(t, []int64{600, 300}, couponDiscountShares(900, 40, 20))
+	// Sem cupom
+	assert.Equal(t, []int64{0, 0}, couponDiscountShares(0, 40, 20))
+}
+
+// Teste da transferência de combo: os produtos do combo mudam de mesa juntos e inteiros
+func TestBundleMoves(t *testing.T) {
+	burger := itemMove{ItemID: 10, OrderID: 3, Quantity: 2, Available: 2}
+	siblings := []itemMove{
+		{ItemID: 11, OrderID: 3, Quantity: 2, Available: 2},
+		{ItemID: 12, OrderID: 3, Quantity: 2, Available: 2},
+	}
+	moves, err := bundleMoves(burger, siblings)
+	assert.NoError(t, err)
+	assert.Equal(t, []itemMove{burger, siblings[0], siblings[1]}, moves)
+	// Combo movido por inteiro esvazia o pedido que só tinha o combo
+	assert.True(t, movesWholeOrder(moves, 3, 6))
+
+	// Só um dos dois combos: recusado
+	_, err = bundleMoves(itemMove{ItemID: 10, OrderID: 3, Quantity: 1, Available: 2}, siblings)
+	assert.Equal(t, errTransferBundle, err)
+}
+
+// Teste para TransferTableSession com dados inválidos e falha ao iniciar a transação
+func TestTransferTableSession(t *testing.T) {
+	router, mockDB := setupTest()
+
+	router.POST("/table-sessions/:id/transfer", func(c *gin.Context) {
+		TransferTableSession(c, mockDB)
+	})
+
+	req, _ := http.NewRequest("POST", "/table-sessions/3/transfer", strings.NewReader(`{"to_table_number": 0}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	mockDB.BeginFunc = func() (*sql.Tx, error) {
+		return nil, sql.ErrConnDone
+	}
+	req, _ = http.NewRequest("POST", "/table-sessions/3/transfer", strings.NewReader(`{"to_table_number": 5}`))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+// Teste para MergeTableSessions juntando a comanda com ela mesma
+func TestMergeTableSessionsSameSession(t *testing.T) {
+	router, mockDB := setupTest()
+
+	router.POST("/table-sessions/:id/merge", func(c *gin.Context) {
+		MergeTableSessions(c, mockDB)
+	})
+
+	req, _ := http.NewRequest("POST", "/table-sessions/3/merge", strings.NewReader(`{"source_session_id": 3}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
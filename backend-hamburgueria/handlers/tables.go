@@ -0,0 +1,349 @@
+package handlers
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	// Configurações da aplicação (loja, cardápio público e chave dos QR codes)
+	"backend-hamburgueria/config"
+	// Modelos de dados da aplicação
+	"backend-hamburgueria/models"
+
+	// Framework web Gin
+	"github.com/gin-gonic/gin"
+	// Geração de QR codes
+	"github.com/skip2/go-qrcode"
+)
+
+// ===== MESAS E QR CODES =====
+
+// Erros da validação de mesa no pedido
+var (
+	errTableNotFound = errors.New("Mesa não cadastrada")
+	errTableInactive = errors.New("Mesa desativada")
+)
+
+// Limites do tamanho da imagem do QR code (pixels)
+const (
+	defaultQRSize = 256
+	maxQRSize     = 1024
+)
+
+// GetTables godoc
+// @Summary      Lista as mesas
+// @Description  Retorna as mesas cadastradas com o endereço assinado do QR code de cada uma
+// @Tags         Tables
+// @Produce      json
+// @Success      200  {array}   models.Table
+// @Failure      500  {object}  models.ErrorResponse "Erro ao buscar mesas"
+// @Router       /api/tables [get]
+func GetTables(c *gin.Context, db DBInterface) {
+	rows, err := db.Query(`SELECT `+tableColumns+` FROM tables WHERE store_id = $1 ORDER BY number`, config.StoreID())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar mesas"})
+		return
+	}
+	defer rows.Close()
+
+	secret := config.TableQRSecret()
+	tables := []models.Table{}
+	for rows.Next() {
+		var table models.Table
+		if err := scanTable(rows, &table); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao ler mesa"})
+			return
+		}
+		if secret != "" {
+			table.OrderURL = tableOrderURL(config.PublicMenuURL(), signTableToken(secret, config.StoreID(), table.Number))
+		}
+		tables = append(tables, table)
+	}
+
+	c.JSON(http.StatusOK, tables)
+}
+
+// CreateTable godoc
+// @Summary      Cadastra uma mesa
+// @Description  Cadastra uma mesa do salão (número único por loja)
+// @Tags         Tables
+// @Accept       json
+// @Produce      json
+// @Param        body  body      models.Table  true  "Mesa"
+// @Success      201   {object}  models.Table
+// @Failure      400   {object}  models.ErrorResponse "Dados inválidos"
+// @Failure      409   {object}  models.ErrorResponse "Mesa já cadastrada"
+// @Router       /api/tables [post]
+func CreateTable(c *gin.Context, db DBInterface) {
+	// ===== VALIDAR DADOS DE ENTRADA =====
+	var table models.Table
+	if err := c.ShouldBindJSON(&table); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos"})
+		return
+	}
+	if table.Number <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Número da mesa inválido"})
+		return
+	}
+	if table.Seats < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Quantidade de lugares inválida"})
+		return
+	}
+
+	// ===== INSERIR MESA =====
+	err := scanTable(db.QueryRow(`
+		INSERT INTO tables (store_id, number, area, seats, is_active)
+		VALUES ($1, $2, $3, $4, TRUE)
+		RETURNING `+tableColumns, config.StoreID(), table.Number, strings.TrimSpace(table.Area), table.Seats), &table)
+	if isUniqueViolation(err) {
+		c.JSON(http.StatusConflict, gin.H{"error": "Mesa já cadastrada"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao cadastrar mesa"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, table)
+}
+
+// UpdateTable godoc
+// @Summary      Atualiza uma mesa
+// @Description  Altera área, lugares ou ativa/desativa a mesa
+// @Tags         Tables
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                        true  "ID da mesa"
+// @Param        body  body      models.UpdateTableRequest  true  "Campos a alterar"
+// @Success      200   {object}  models.Table
+// @Failure      400   {object}  models.ErrorResponse "Dados inválidos"
+// @Failure      404   {object}  models.ErrorResponse "Mesa não encontrada"
+// @Router       /api/tables/{id} [put]
+func UpdateTable(c *gin.Context, db DBInterface) {
+	// ===== VALIDAR ENTRADA =====
+	tableID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID da mesa inválido"})
+		return
+	}
+	var req models.UpdateTableRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos"})
+		return
+	}
+	if req.Seats != nil && *req.Seats < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Quantidade de lugares inválida"})
+		return
+	}
+
+	// ===== ATUALIZAR MESA =====
+	var table models.Table
+	err = scanTable(db.QueryRow(`
+		UPDATE tables
+		SET area = COALESCE($1, area), seats = COALESCE($2, seats), is_active = COALESCE($3, is_active)
+		WHERE id = $4 AND store_id = $5
+		RETURNING `+tableColumns, req.Area, req.Seats, req.IsActive, tableID, config.StoreID()), &table)
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Mesa não encontrada"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao atualizar mesa"})
+		return
+	}
+
+	c.JSON(http.StatusOK, table)
+}
+
+// GetTableQRCode godoc
+// @Summary      QR code da mesa
+// @Description  Gera o QR code (PNG ou SVG) com o endereço assinado do cardápio para a mesa
+// @Tags         Tables
+// @Produce      png
+// @Produce      image/svg+xml
+// @Param        id      path      int     true   "ID da mesa"
+// @Param        format  query     string  false  "png (padrão) ou svg"
+// @Param        size    query     int     false  "Tamanho em pixels (padrão 256, máx. 1024)"
+// @Success      200
+// @Failure      400     {object}  models.ErrorResponse "Formato inválido"
+// @Failure      404     {object}  models.ErrorResponse "Mesa não encontrada"
+// @Failure      503     {object}  models.ErrorResponse "QR das mesas não configurado"
+// @Router       /api/tables/{id}/qr [get]
+func GetTableQRCode(c *gin.Context, db DBInterface) {
+	// ===== VALIDAR ENTRADA =====
+	tableID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID da mesa inválido"})
+		return
+	}
+	format := c.DefaultQuery("format", "png")
+	if format != "png" && format != "svg" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Formato inválido (use png ou svg)"})
+		return
+	}
+	size := defaultQRSize
+	if raw := c.Query("size"); raw != "" {
+		size, err = strconv.Atoi(raw)
+		if err != nil || size < 64 || size > maxQRSize {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Tamanho inválido (64 a 1024 pixels)"})
+			return
+		}
+	}
+	secret := config.TableQRSecret()
+	if secret == "" {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "QR das mesas não configurado (defina TABLE_QR_SECRET)"})
+		return
+	}
+
+	// ===== BUSCAR MESA =====
+	var table models.Table
+	err = scanTable(db.QueryRow(`SELECT `+tableColumns+` FROM tables WHERE id = $1 AND store_id = $2`, tableID, config.StoreID()), &table)
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Mesa não encontrada"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar mesa"})
+		return
+	}
+
+	// ===== GERAR QR CODE =====
+	orderURL := tableOrderURL(config.PublicMenuURL(), signTableToken(secret, config.StoreID(), table.Number))
+	qr, err := qrcode.New(orderURL, qrcode.Medium)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao gerar QR code"})
+		return
+	}
+
+	if format == "svg" {
+		c.Data(http.StatusOK, "image/svg+xml", []byte(qrSVG(qr.Bitmap(), size)))
+		return
+	}
+	png, err := qr.PNG(size)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao gerar QR code"})
+		return
+	}
+	c.Data(http.StatusOK, "image/png", png)
+}
+
+// ResolveTableToken godoc
+// @Summary      Identifica a mesa do QR code
+// @Description  Valida o token lido do QR code e retorna a mesa (usado pelo cardápio ao abrir pelo QR)
+// @Tags         Tables
+// @Produce      json
+// @Param        token  query     string  true  "Token do QR code"
+// @Success      200    {object}  models.Table
+// @Failure      400    {object}  models.ErrorResponse "QR da mesa inválido"
+// @Router       /api/tables/resolve [get]
+func ResolveTableToken(c *gin.Context, db DBInterface) {
+	tableNumber, ok := verifyTableToken(config.TableQRSecret(), config.StoreID(), c.Query("token"))
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "QR da mesa inválido"})
+		return
+	}
+
+	var table models.Table
+	err := scanTable(db.QueryRow(`SELECT `+tableColumns+` FROM tables WHERE store_id = $1 AND number = $2`, config.StoreID(), tableNumber), &table)
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusNotFound, gin.H{"error": errTableNotFound.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar mesa"})
+		return
+	}
+	if !table.IsActive {
+		c.JSON(http.StatusBadRequest, gin.H{"error": errTableInactive.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, table)
+}
+
+// ===== FUNÇÕES AUXILIARES =====
+
+// tableColumns lista as colunas de tables na ordem lida por scanTable
+const tableColumns = `id, number, area, seats, is_active, created_at`
+
+// scanTable lê uma linha de tables selecionada com tableColumns
+func scanTable(row rowScanner, table *models.Table) error {
+	return row.Scan(&table.ID, &table.Number, &table.Area, &table.Seats, &table.IsActive, &table.CreatedAt)
+}
+
+// checkOrderTable confere se a mesa do pedido está cadastrada e ativa
+func checkOrderTable(q queryer, storeID, tableNumber int) error {
+	var active bool
+	err := q.QueryRow(`SELECT is_active FROM tables WHERE store_id = $1 AND number = $2`, storeID, tableNumber).Scan(&active)
+	if err == sql.ErrNoRows {
+		return errTableNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if !active {
+		return errTableInactive
+	}
+	return nil
+}
+
+// signTableToken gera o token do QR da mesa: "<mesa>-<assinatura>"
+// A assinatura (HMAC-SHA256 truncado) impede que o cliente troque o número da mesa
+func signTableToken(secret string, storeID, tableNumber int) string {
+	return strconv.Itoa(tableNumber) + "-" + tableSignature(secret, storeID, tableNumber)
+}
+
+// verifyTableToken valida o token do QR e retorna o número da mesa
+func verifyTableToken(secret string, storeID int, token string) (int, bool) {
+	if secret == "" {
+		return 0, false
+	}
+	parts := strings.SplitN(token, "-", 2)
+	if len(parts) != 2 {
+		return 0, false
+	}
+	tableNumber, err := strconv.Atoi(parts[0])
+	if err != nil || tableNumber <= 0 {
+		return 0, false
+	}
+	expected := tableSignature(secret, storeID, tableNumber)
+	return tableNumber, hmac.Equal([]byte(parts[1]), []byte(expected))
+}
+
+// tableSignature calcula a assinatura da mesa (96 bits em hexadecimal)
+func tableSignature(secret string, storeID, tableNumber int) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "table:%d:%d", storeID, tableNumber)
+	return hex.EncodeToString(mac.Sum(nil)[:12])
+}
+
+// tableOrderURL monta o endereço do cardápio com o token da mesa
+func tableOrderURL(menuURL, token string) string {
+	return menuURL + "/?table_token=" + url.QueryEscape(token)
+}
+
+// qrSVG desenha o QR code como SVG: um retângulo por módulo escuro
+func qrSVG(bitmap [][]bool, size int) string {
+	modules := len(bitmap)
+	var svg strings.Builder
+	fmt.Fprintf(&svg, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`,
+		size, size, modules, modules)
+	fmt.Fprintf(&svg, `<rect width="%d" height="%d" fill="#fff"/>`, modules, modules)
+	svg.WriteString(`<path fill="#000" d="`)
+	for y, row := range bitmap {
+		for x, dark := range row {
+			if dark {
+				fmt.Fprintf(&svg, "M%d %dh1v1h-1z", x, y)
+			}
+		}
+	}
+	svg.WriteString(`"/></svg>`)
+	return svg.String()
+}
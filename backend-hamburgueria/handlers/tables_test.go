@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/skip2/go-qrcode"
+	"github.com/stretchr/testify/assert"
+)
+
+// Teste da assinatura dos QR codes das mesas
+func TestTableToken(t *testing.T) {
+	token := signTableToken("segredo", 1, 7)
+	assert.True(t, strings.HasPrefix(token, "7-"))
+
+	tableNumber, ok := verifyTableToken("segredo", 1, token)
+	assert.True(t, ok)
+	assert.Equal(t, 7, tableNumber)
+
+	// Trocar o número da mesa invalida a assinatura
+	_, ok = verifyTableToken("segredo", 1, "8"+token[1:])
+	assert.False(t, ok)
+	// Outra loja ou outra chave também
+	_, ok = verifyTableToken("segredo", 2, token)
+	assert.False(t, ok)
+	_, ok = verifyTableToken("outro", 1, token)
+	assert.False(t, ok)
+	// Sem chave configurada nada é aceito
+	_, ok = verifyTableToken("", 1, token)
+	assert.False(t, ok)
+	_, ok = verifyTableToken("segredo", 1, "lixo")
+	assert.False(t, ok)
+}
+
+// Teste do SVG do QR code
+func TestQRSVG(t *testing.T) {
+	qr, err := qrcode.New("http://localhost:5173/?table_token=7-abc", qrcode.Medium)
+	assert.NoError(t, err)
+
+	svg := qrSVG(qr.Bitmap(), 300)
+	assert.True(t, strings.HasPrefix(svg, `<svg xmlns="http://www.w3.org/2000/svg" width="300" height="300"`))
+	assert.Contains(t, svg, "h1v1h-1z")
+	assert.True(t, strings.HasSuffix(svg, "</svg>"))
+}
+
+// Teste para GetTableQRCode com formato inválido e sem chave configurada
+func TestGetTableQRCode(t *testing.T) {
+	router, mockDB := setupTest()
+
+	router.GET("/tables/:id/qr", func(c *gin.Context) {
+		GetTableQRCode(c, mockDB)
+	})
+
+	req, _ := http.NewRequest("GET", "/tables/1/qr?format=gif", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	t.Setenv("TABLE_QR_SECRET", "")
+	t.Setenv("JWT_SECRET", "")
+	req, _ = http.NewRequest("GET", "/tables/1/qr", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+}
+
+// Teste para CreateOrder com QR de mesa adulterado
+func TestCreateOrderInvalidTableToken(t *testing.T) {
+	router, mockDB := setupTest()
+
+	router.POST("/orders", func(c *gin.Context) {
+		CreateOrder(c, mockDB)
+	})
+	mockDB.BeginFunc = func() (*sql.Tx, error) {
+		return nil, sql.ErrConnDone
+	}
+
+	t.Setenv("TABLE_QR_SECRET", "segredo")
+	body := `{"table_token": "7-0000", "items": [{"product_id": 2, "quantity": 1}]}`
+	req, _ := http.NewRequest("POST", "/orders", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
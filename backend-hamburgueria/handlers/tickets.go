@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	// Configurações da aplicação (loja e dia de operação)
+	"backend-hamburgueria/config"
+
+	// Framework web Gin
+	"github.com/gin-gonic/gin"
+)
+
+// ===== SENHAS DO DIA =====
+
+// nextTicketNumber reserva a próxima senha da loja no dia de operação
+// O INSERT ... ON CONFLICT trava a linha do contador até o fim da transação,
+// então pedidos simultâneos nunca recebem a mesma senha
+func nextTicketNumber(q queryer, storeID int, serviceDay string) (int, error) {
+	var ticketNumber int
+	err := q.QueryRow(`
+		INSERT INTO ticket_counters (store_id, service_day, last_number)
+		VALUES ($1, $2, 1)
+		ON CONFLICT (store_id, service_day)
+		DO UPDATE SET last_number = ticket_counters.last_number + 1
+		RETURNING last_number
+	`, storeID, serviceDay).Scan(&ticketNumber)
+	return ticketNumber, err
+}
+
+// GetOrderByTicket godoc
+// @Summary      Busca pedido pela senha do dia
+// @Description  Retorna os detalhes do pedido com a senha informada no dia de operação atual (ou no dia indicado em ?day=YYYY-MM-DD)
+// @Tags         Orders
+// @Produce      json
+// @Param        number  path      int     true   "Senha do pedido"
+// @Param        day     query     string  false  "Dia de operação (YYYY-MM-DD)"
+// @Success      200     {object}  models.Order
+// @Failure      400     {object}  models.ErrorResponse "Senha inválida"
+// @Failure      404     {object}  models.ErrorResponse "Pedido não encontrado"
+// @Router       /api/orders/ticket/{number} [get]
+func GetOrderByTicket(c *gin.Context, db DBInterface) {
+	// ===== VALIDAR SENHA E DIA =====
+	ticketNumber, err := strconv.Atoi(c.Param("number"))
+	if err != nil || ticketNumber <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Senha inválida"})
+		return
+	}
+	serviceDay := config.ServiceDayStart(time.Now()).Format("2006-01-02")
+	if day := c.Query("day"); day != "" {
+		if _, err := time.Parse("2006-01-02", day); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Dia inválido, use o formato YYYY-MM-DD"})
+			return
+		}
+		serviceDay = day
+	}
+
+	// ===== BUSCAR PEDIDO =====
+	var orderID int
+	err = db.QueryRow(`
+		SELECT id FROM orders WHERE store_id = $1 AND service_day = $2 AND ticket_number = $3
+	`, config.StoreID(), serviceDay, ticketNumber).Scan(&orderID)
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Pedido não encontrado"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar pedido"})
+		return
+	}
+
+	respondOrderDetails(c, db, orderID)
+}
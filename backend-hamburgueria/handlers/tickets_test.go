@@ -0,0 +1,27 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// Teste para GetOrderByTicket com senha ou dia inválidos
+func TestGetOrderByTicketInvalid(t *testing.T) {
+	router, mockDB := setupTest()
+
+	router.GET("/orders/ticket/:number", func(c *gin.Context) {
+		GetOrderByTicket(c, mockDB)
+	})
+
+	for _, path := range []string{"/orders/ticket/abc", "/orders/ticket/0", "/orders/ticket/12?day=18-10-2026"} {
+		req, _ := http.NewRequest("GET", path, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, path)
+	}
+}
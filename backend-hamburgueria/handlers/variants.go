@@ -0,0 +1,292 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	// Modelos de dados da aplicação
+	"backend-hamburgueria/models"
+
+	// Framework web Gin
+	"github.com/gin-gonic/gin"
+)
+
+// ===== VARIAÇÕES DE PRODUTO =====
+
+// Erros da variação informada no pedido
+var (
+	errVariantRequired    = errors.New("Escolha uma variação do produto")
+	errVariantNotFound    = errors.New("Variação inválida para o produto")
+	errVariantUnavailable = errors.New("Variação indisponível")
+)
+
+// variantColumns são as colunas lidas por scanVariant
+const variantColumns = `id, product_id, name, sku, price, is_available, position, created_at`
+
+// GetProductVariants godoc
+// @Summary      Variações de um produto
+// @Description  Retorna os tamanhos/versões do produto, incluindo os indisponíveis
+// @Tags         Products
+// @Produce      json
+// @Param        id   path      int  true  "ID do produto"
+// @Success      200  {array}   models.ProductVariant
+// @Failure      400  {object}  models.ErrorResponse "ID inválido"
+// @Router       /api/products/{id}/variants [get]
+func GetProductVariants(c *gin.Context, db DBInterface) {
+	productID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do produto inválido"})
+		return
+	}
+
+	rows, err := db.Query(`
+		SELECT `+variantColumns+` FROM product_variants WHERE product_id = $1 ORDER BY position, price, id
+	`, productID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar variações"})
+		return
+	}
+	defer rows.Close()
+
+	variants := []models.ProductVariant{}
+	for rows.Next() {
+		variant, err := scanVariant(rows)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao ler variação"})
+			return
+		}
+		variants = append(variants, variant)
+	}
+	if err := rows.Err(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar variações"})
+		return
+	}
+	c.JSON(http.StatusOK, variants)
+}
+
+// CreateProductVariant godoc
+// @Summary      Cria uma variação
+// @Description  Cadastra um tamanho/versão do produto; a partir daí o pedido precisa informar a variação
+// @Tags         Products
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                           true  "ID do produto"
+// @Param        body  body      models.ProductVariantRequest  true  "Dados da variação"
+// @Success      201   {object}  models.ProductVariant
+// @Failure      400   {object}  models.ErrorResponse "Dados inválidos"
+// @Failure      404   {object}  models.ErrorResponse "Produto não encontrado"
+// @Failure      409   {object}  models.ErrorResponse "Nome ou SKU já cadastrado"
+// @Router       /api/products/{id}/variants [post]
+func CreateProductVariant(c *gin.Context, db DBInterface) {
+	// ===== VALIDAR DADOS DE ENTRADA =====
+	productID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do produto inválido"})
+		return
+	}
+	var req models.ProductVariantRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos"})
+		return
+	}
+	if err := validateVariantRequest(productID, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Combos têm preço pelas etapas, não por variação; produtos das etapas de um combo também não,
+	// porque o combo não tem escolha de variação
+	var isBundle, inBundle bool
+	err = db.QueryRow(`
+		SELECT is_bundle, EXISTS (SELECT 1 FROM bundle_slot_options WHERE product_id = $1) FROM products WHERE id = $1
+	`, productID).Scan(&isBundle, &inBundle)
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Produto não encontrado"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao criar variação"})
+		return
+	}
+	if isBundle {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Combos não têm variações"})
+		return
+	}
+	if inBundle {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Produto faz parte de um combo; retire-o das etapas antes de cadastrar variações"})
+		return
+	}
+
+	// ===== INSERIR VARIAÇÃO =====
+	variant, err := scanVariant(db.QueryRow(`
+		INSERT INTO product_variants (product_id, name, sku, price, is_available, position)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING `+variantColumns,
+		productID, req.Name, req.SKU, req.Price, *req.IsAvailable, req.Position))
+	if isUniqueViolation(err) {
+		c.JSON(http.StatusConflict, gin.H{"error": "Já existe uma variação com este nome ou SKU"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao criar variação"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, variant)
+}
+
+// UpdateProductVariant godoc
+// @Summary      Altera uma variação
+// @Description  Substitui nome, SKU, preço, disponibilidade e ordem da variação; pedidos já feitos mantêm os dados da época
+// @Tags         Products
+// @Accept       json
+// @Produce      json
+// @Param        id          path      int                           true  "ID do produto"
+// @Param        variant_id  path      int                           true  "ID da variação"
+// @Param        body        body      models.ProductVariantRequest  true  "Dados da variação"
+// @Success      200         {object}  models.ProductVariant
+// @Failure      400         {object}  models.ErrorResponse "Dados inválidos"
+// @Failure      404         {object}  models.ErrorResponse "Variação não encontrada"
+// @Failure      409         {object}  models.ErrorResponse "Nome ou SKU já cadastrado"
+// @Router       /api/products/{id}/variants/{variant_id} [put]
+func UpdateProductVariant(c *gin.Context, db DBInterface) {
+	// ===== VALIDAR DADOS DE ENTRADA =====
+	productID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do produto inválido"})
+		return
+	}
+	variantID, err := strconv.Atoi(c.Param("variant_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID da variação inválido"})
+		return
+	}
+	var req models.ProductVariantRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos"})
+		return
+	}
+	if err := validateVariantRequest(productID, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// ===== ATUALIZAR VARIAÇÃO =====
+	variant, err := scanVariant(db.QueryRow(`
+		UPDATE product_variants SET name = $3, sku = $4, price = $5, is_available = $6, position = $7
+		WHERE id = $1 AND product_id = $2
+		RETURNING `+variantColumns,
+		variantID, productID, req.Name, req.SKU, req.Price, *req.IsAvailable, req.Position))
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Variação não encontrada"})
+		return
+	}
+	if isUniqueViolation(err) {
+		c.JSON(http.StatusConflict, gin.H{"error": "Já existe uma variação com este nome ou SKU"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao atualizar variação"})
+		return
+	}
+
+	c.JSON(http.StatusOK, variant)
+}
+
+// ===== FUNÇÕES AUXILIARES =====
+
+// validateVariantRequest normaliza nome e SKU e confere o preço
+func validateVariantRequest(productID int, req *models.ProductVariantRequest) error {
+	if productID == customBurgerProductID {
+		return errors.New("O lanche personalizado não tem variações")
+	}
+	req.Name = strings.TrimSpace(req.Name)
+	req.SKU = strings.ToUpper(strings.TrimSpace(req.SKU))
+	if req.Name == "" {
+		return errors.New("Nome da variação é obrigatório")
+	}
+	if req.SKU == "" || strings.ContainsAny(req.SKU, " \t") {
+		return errors.New("SKU da variação inválido")
+	}
+	if req.Price < 0 {
+		return errors.New("Preço não pode ser negativo")
+	}
+	if req.IsAvailable == nil {
+		available := true
+		req.IsAvailable = &available
+	}
+	return nil
+}
+
+// scanVariant lê uma variação nas colunas de variantColumns
+func scanVariant(row rowScanner) (models.ProductVariant, error) {
+	var variant models.ProductVariant
+	err := row.Scan(&variant.ID, &variant.ProductID, &variant.Name, &variant.SKU, &variant.Price,
+		&variant.IsAvailable, &variant.Position, &variant.CreatedAt)
+	return variant, err
+}
+
+// loadAvailableVariants busca as variações disponíveis de todos os produtos, agrupadas por produto
+func loadAvailableVariants(q queryer) (map[int][]models.ProductVariant, error) {
+	rows, err := q.Query(`
+		SELECT ` + variantColumns + ` FROM product_variants WHERE is_available ORDER BY product_id, position, price, id
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	variants := make(map[int][]models.ProductVariant)
+	for rows.Next() {
+		variant, err := scanVariant(rows)
+		if err != nil {
+			return nil, err
+		}
+		variants[variant.ProductID] = append(variants[variant.ProductID], variant)
+	}
+	return variants, rows.Err()
+}
+
+// priceVariant aplica a variação escolhida ao item: o preço passa a ser o da variação
+// Produtos com variações cadastradas exigem a escolha; os demais não aceitam variação
+func priceVariant(q queryer, line *pricedLine) error {
+	item := line.Item
+	if item.VariantID == nil {
+		var count int
+		err := q.QueryRow("SELECT COUNT(*) FROM product_variants WHERE product_id = $1", item.ProductID).Scan(&count)
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			return errVariantRequired
+		}
+		return nil
+	}
+
+	variant, err := scanVariant(q.QueryRow(`
+		SELECT `+variantColumns+` FROM product_variants WHERE id = $1 AND product_id = $2
+	`, *item.VariantID, item.ProductID))
+	if err == sql.ErrNoRows {
+		return errVariantNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if !variant.IsAvailable {
+		return errVariantUnavailable
+	}
+	line.Variant = &variant
+	line.UnitPrice = variant.Price
+	return nil
+}
+
+// variantSnapshot são os dados da variação gravados no item do pedido (nil = sem variação)
+func variantSnapshot(line pricedLine) (id *int, name, sku *string) {
+	if v := line.Variant; v != nil {
+		return &v.ID, &v.Name, &v.SKU
+	}
+	return nil, nil, nil
+}
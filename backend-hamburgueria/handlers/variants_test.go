@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"backend-hamburgueria/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// Teste da validação do cadastro de variações
+func TestValidateVariantRequest(t *testing.T) {
+	req := models.ProductVariantRequest{Name: " G ", SKU: " batata-g ", Price: 18}
+	assert.NoError(t, validateVariantRequest(5, &req))
+	assert.Equal(t, "G", req.Name)
+	assert.Equal(t, "BATATA-G", req.SKU)
+	assert.True(t, *req.IsAvailable)
+
+	invalid := []models.ProductVariantRequest{
+		{Name: "", SKU: "BATATA-G", Price: 18},
+		{Name: "G", SKU: "", Price: 18},
+		{Name: "G", SKU: "BATATA G", Price: 18},
+		{Name: "G", SKU: "BATATA-G", Price: -1},
+	}
+	for _, req := range invalid {
+		assert.Error(t, validateVariantRequest(5, &req))
+	}
+	assert.Error(t, validateVariantRequest(customBurgerProductID, &models.ProductVariantRequest{Name: "G", SKU: "X"}))
+}
+
+// Teste dos dados da variação gravados no item do pedido
+func TestVariantSnapshot(t *testing.T) {
+	id, name, sku := variantSnapshot(pricedLine{})
+	assert.Nil(t, id)
+	assert.Nil(t, name)
+	assert.Nil(t, sku)
+
+	line := pricedLine{Variant: &models.ProductVariant{ID: 4, Name: "M", SKU: "BATATA-M"}}
+	id, name, sku = variantSnapshot(line)
+	assert.Equal(t, 4, *id)
+	assert.Equal(t, "M", *name)
+	assert.Equal(t, "BATATA-M", *sku)
+}
+
+// Teste para CreateProductVariant com dados inválidos (recusado antes do banco)
+func TestCreateProductVariantInvalid(t *testing.T) {
+	router, mockDB := setupTest()
+
+	router.POST("/products/:id/variants", func(c *gin.Context) {
+		CreateProductVariant(c, mockDB)
+	})
+
+	body := `{"name": "G", "sku": "", "price": 18}`
+	req, _ := http.NewRequest("POST", "/products/5/variants", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -4,6 +4,7 @@ package main
 import (
 	"log"
 	"os"
+	"time"
 
 	// Framework web Gin para criar a API REST
 	"github.com/gin-gonic/gin"
@@ -16,6 +17,7 @@ import (
 	// Pacotes internos do projeto
 	"backend-hamburgueria/config"
 	"backend-hamburgueria/database"
+	"backend-hamburgueria/handlers"
 	"backend-hamburgueria/routes"
 )
 
@@ -58,6 +60,18 @@ func main() {
 	// Rota para documentação Swagger
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
+	// ===== TAREFAS EM SEGUNDO PLANO =====
+	// Liberar para a cozinha os pedidos agendados cujo horário chegou
+	go func() {
+		ticker := time.NewTicker(30 * time.Second)
+		defer ticker.Stop()
+		for range ticker.C {
+			if _, err := handlers.ReleaseScheduledOrders(db); err != nil {
+				log.Println("Erro ao liberar pedidos agendados:", err)
+			}
+		}
+	}()
+
 	// ===== INICIALIZAÇÃO DO SERVIDOR =====
 	// Obter porta do ambiente ou usar 8080 como padrão
 	port := os.Getenv("PORT")
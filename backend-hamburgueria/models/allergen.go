@@ -0,0 +1,31 @@
+package models
+
+// ===== MODELOS DE ALÉRGENOS E RECEITAS =====
+
+// RecipeIngredient é um ingrediente da receita de um produto
+type RecipeIngredient struct {
+	IngredientID int      `json:"ingredient_id"`       // Ingrediente
+	Name         string   `json:"name,omitempty"`      // Nome do ingrediente
+	Quantity     int      `json:"quantity"`            // Porções do ingrediente no produto
+	Allergens    []string `json:"allergens,omitempty"` // Alérgenos do ingrediente
+}
+
+// SetRecipeRequest substitui a receita de um produto
+type SetRecipeRequest struct {
+	Ingredients []RecipeIngredient `json:"ingredients"` // Ingredientes (lista vazia = sem receita)
+}
+
+// IngredientDietaryRequest define alérgenos, dietas e calorias de um ingrediente
+type IngredientDietaryRequest struct {
+	Allergens []string `json:"allergens"` // Alérgenos (gluten, lactose, nuts...)
+	DietTags  []string `json:"diet_tags"` // Dietas atendidas (vegan, vegetarian)
+	Calories  *int     `json:"calories"`  // Calorias por porção (kcal; vazio = não informado)
+}
+
+// AllergyConflict é um item do pedido que contém alérgenos declarados pelo cliente
+type AllergyConflict struct {
+	Line      int           `json:"line"`                // Posição do item no pedido enviado (1, 2, 3...)
+	ProductID int           `json:"product_id"`          // Produto do item
+	Component *BundleChoice `json:"component,omitempty"` // Produto do combo que contém os alérgenos
+	Allergens []string      `json:"allergens"`           // Alérgenos declarados presentes no item
+}
@@ -0,0 +1,53 @@
+package models
+
+import "time"
+
+// ===== MODELOS DE DIVISÃO DA CONTA =====
+
+// BillSplit é a divisão da conta de uma comanda (ou de um pedido) em parcelas pagas separadamente
+type BillSplit struct {
+	ID             int         `json:"id"`                         // ID único da divisão
+	TableSessionID *int        `json:"table_session_id,omitempty"` // Comanda dividida
+	OrderID        *int        `json:"order_id,omitempty"`         // Pedido dividido (fora de comanda)
+	Mode           string      `json:"mode"`                       // Modo: equal, items ou custom
+	Status         string      `json:"status"`                     // Status: open, paid ou cancelled
+	Subtotal       float64     `json:"subtotal"`                   // Valor da conta sem taxa de serviço
+	ServiceCharge  float64     `json:"service_charge"`             // Taxa de serviço somada às parcelas
+	Total          float64     `json:"total"`                      // Total da conta (soma das parcelas)
+	PaidAmount     float64     `json:"paid_amount"`                // Soma das parcelas já pagas
+	CreatedAt      time.Time   `json:"created_at"`                 // Criação da divisão
+	Shares         []BillShare `json:"shares"`                     // Parcelas
+}
+
+// BillShare é a parte de uma pessoa na conta
+type BillShare struct {
+	ID            int        `json:"id"`                       // ID único da parcela
+	Label         string     `json:"label"`                    // Identificação (ex.: "Pessoa 1", nome)
+	Subtotal      float64    `json:"subtotal"`                 // Consumo da parcela
+	ServiceCharge float64    `json:"service_charge"`           // Taxa de serviço da parcela
+	Amount        float64    `json:"amount"`                   // Valor a pagar
+	ItemIDs       []int      `json:"item_ids,omitempty"`       // Itens da parcela (modo items)
+	PaymentMethod string     `json:"payment_method,omitempty"` // Forma de pagamento (cash, card, pix)
+	PaidAt        *time.Time `json:"paid_at,omitempty"`        // Pagamento da parcela
+}
+
+// BillShareRequest descreve uma parcela nos modos items e custom
+type BillShareRequest struct {
+	Label   string  `json:"label"`    // Identificação (opcional)
+	ItemIDs []int   `json:"item_ids"` // Itens consumidos (modo items); item em várias parcelas é dividido entre elas
+	Amount  float64 `json:"amount"`   // Valor do consumo sem taxa (modo custom)
+}
+
+// CreateBillSplitRequest cria a divisão da conta
+// equal: divide por people; items: cada parcela paga seus itens; custom: valores informados
+type CreateBillSplitRequest struct {
+	Mode                 string             `json:"mode"`                   // equal, items ou custom
+	People               int                `json:"people"`                 // Número de pessoas (modo equal)
+	Shares               []BillShareRequest `json:"shares"`                 // Parcelas (modos items e custom)
+	IncludeServiceCharge *bool              `json:"include_service_charge"` // Cobrar a taxa de serviço dos pedidos (padrão: sim)
+}
+
+// PayBillShareRequest registra o pagamento de uma parcela
+type PayBillShareRequest struct {
+	PaymentMethod string `json:"payment_method"` // cash, card ou pix
+}
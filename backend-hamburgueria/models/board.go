@@ -0,0 +1,23 @@
+package models
+
+import (
+	"time"
+)
+
+// ===== MODELOS DO PAINEL DE PEDIDOS =====
+
+// BoardTicket é uma senha exibida no painel do balcão
+// Contém apenas o número e o status - nada de nome do cliente ou valores
+type BoardTicket struct {
+	TicketNumber int    `json:"ticket_number"` // Senha do pedido no dia de operação
+	Status       string `json:"status"`        // preparing ou ready
+	Label        string `json:"label"`         // Texto para a TV: Preparando ou Pronto
+}
+
+// OrderBoard é o conteúdo do painel "Preparando" / "Pronto"
+type OrderBoard struct {
+	ServiceDay string        `json:"service_day"` // Dia de operação (YYYY-MM-DD)
+	Preparing  []BoardTicket `json:"preparing"`   // Senhas em preparo
+	Ready      []BoardTicket `json:"ready"`       // Senhas prontas para retirada
+	UpdatedAt  time.Time     `json:"updated_at"`  // Momento da consulta
+}
@@ -0,0 +1,54 @@
+package models
+
+// ===== MODELOS DE COMBOS =====
+
+// BundleSlot é uma etapa do combo em que o cliente escolhe um produto (ex.: "Bebida")
+type BundleSlot struct {
+	ID         int            `json:"id"`          // ID da etapa
+	Name       string         `json:"name"`        // Nome exibido (Lanche, Acompanhamento, Bebida)
+	Position   int            `json:"position"`    // Ordem no combo
+	IsRequired bool           `json:"is_required"` // Escolha obrigatória
+	Options    []BundleOption `json:"options"`     // Produtos aceitos na etapa
+}
+
+// BundleOption é um produto aceito numa etapa do combo
+type BundleOption struct {
+	ProductID   int     `json:"product_id"`             // Produto
+	ProductName string  `json:"product_name,omitempty"` // Nome do produto
+	Upcharge    float64 `json:"upcharge"`               // Acréscimo ao preço do combo
+	IsDefault   bool    `json:"is_default"`             // Escolha usada quando o cliente não informa
+}
+
+// Bundle é um produto vendido como combo, com suas etapas
+type Bundle struct {
+	Product Product      `json:"product"` // Produto do combo (preço base)
+	Slots   []BundleSlot `json:"slots"`   // Etapas de escolha
+}
+
+// BundleSlotRequest define uma etapa do combo no cadastro
+type BundleSlotRequest struct {
+	Name       string         `json:"name"`        // Nome da etapa
+	IsRequired *bool          `json:"is_required"` // Obrigatória (padrão: sim)
+	Options    []BundleOption `json:"options"`     // Produtos aceitos
+}
+
+// SetBundleRequest substitui as etapas do combo (lista vazia deixa de ser combo)
+type SetBundleRequest struct {
+	Slots []BundleSlotRequest `json:"slots"`
+}
+
+// BundleChoice é a escolha do cliente numa etapa do combo
+type BundleChoice struct {
+	SlotID    int `json:"slot_id"`    // Etapa
+	ProductID int `json:"product_id"` // Produto escolhido
+}
+
+// BundleComponent é um produto que compõe o combo pedido (vai para a cozinha como item próprio)
+type BundleComponent struct {
+	SlotID      int     `json:"slot_id"`      // Etapa
+	SlotName    string  `json:"slot_name"`    // Nome da etapa
+	ProductID   int     `json:"product_id"`   // Produto escolhido
+	ProductName string  `json:"product_name"` // Nome do produto
+	Upcharge    float64 `json:"upcharge"`     // Acréscimo cobrado
+	UnitPrice   float64 `json:"unit_price"`   // Parte do preço do combo atribuída ao produto
+}
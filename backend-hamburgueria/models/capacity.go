@@ -0,0 +1,23 @@
+package models
+
+// ===== MODELOS DE CAPACIDADE DA COZINHA =====
+
+// StationLoad mostra a ocupação de uma estação da cozinha
+type StationLoad struct {
+	Station   string `json:"station"`    // Estação: grill, fryer, bar...
+	OpenItems int    `json:"open_items"` // Itens na fila (pendentes, em preparo e agendados)
+	Capacity  int    `json:"capacity"`   // Itens simultâneos suportados
+}
+
+// KitchenWaitResponse é a resposta pública com a espera estimada
+// Usada na tela do cardápio para avisar o cliente antes de pedir
+type KitchenWaitResponse struct {
+	AcceptingOrders      bool          `json:"accepting_orders"`       // false quando pedidos novos seriam recusados
+	OverloadMode         string        `json:"overload_mode"`          // reject ou schedule
+	EstimatedWaitMinutes int           `json:"estimated_wait_minutes"` // Espera estimada para um pedido novo
+	QueueDelayMinutes    int           `json:"queue_delay_minutes"`    // Parte da espera causada pela fila
+	RecentOrders         int           `json:"recent_orders"`          // Pedidos que entraram na janela atual
+	MaxOrdersPerWindow   int           `json:"max_orders_per_window"`  // Limite da janela (0 = sem limite)
+	WindowMinutes        int           `json:"window_minutes"`         // Tamanho da janela em minutos
+	Stations             []StationLoad `json:"stations"`               // Ocupação por estação
+}
@@ -0,0 +1,56 @@
+package models
+
+import "time"
+
+// ===== MODELOS DE CARRINHO =====
+
+// CartDetails são os dados do pedido guardados no carrinho até o fechamento
+// Os campos têm o mesmo significado dos de CreateOrderRequest
+type CartDetails struct {
+	OrderType             string     `json:"order_type"`              // dine_in, takeaway ou delivery
+	CustomerName          string     `json:"customer_name"`           // Nome do cliente
+	TableNumber           int        `json:"table_number"`            // Número da mesa
+	TableToken            string     `json:"table_token,omitempty"`   // Token assinado do QR da mesa
+	PickupName            string     `json:"pickup_name"`             // Nome para retirada
+	CustomerPhone         string     `json:"customer_phone"`          // Telefone
+	DeliveryAddress       string     `json:"delivery_address"`        // Endereço de entrega
+	DeliveryAddressID     int        `json:"delivery_address_id"`     // Endereço salvo
+	DeliveryLatitude      *float64   `json:"delivery_latitude"`       // Latitude do endereço avulso
+	DeliveryLongitude     *float64   `json:"delivery_longitude"`      // Longitude do endereço avulso
+	Notes                 string     `json:"notes"`                   // Observações do pedido
+	IncludeServiceCharge  *bool      `json:"include_service_charge"`  // Cobrar a taxa de serviço
+	Tip                   float64    `json:"tip"`                     // Gorjeta
+	CouponCode            string     `json:"coupon_code"`             // Cupom aplicado
+	ScheduledFor          *time.Time `json:"scheduled_for,omitempty"` // Retirada/entrega agendada
+	Allergies             []string   `json:"allergies,omitempty"`     // Alergias do cliente
+	AllowAllergyConflicts bool       `json:"allow_allergy_conflicts"` // Confirmação dos itens com alérgenos declarados
+}
+
+// Cart é um carrinho compartilhado pelo totem e pelo app, guardado no servidor
+type Cart struct {
+	ID string `json:"id"` // Identificador do carrinho (token aleatório)
+	CartDetails
+	Status    string     `json:"status"`             // Status: open ou checked_out
+	OrderID   *int       `json:"order_id,omitempty"` // Pedido gerado no fechamento
+	Items     []CartItem `json:"items"`              // Itens do carrinho
+	ExpiresAt time.Time  `json:"expires_at"`         // Expiração (renovada a cada alteração)
+	CreatedAt time.Time  `json:"created_at"`         // Criação do carrinho
+	UpdatedAt time.Time  `json:"updated_at"`         // Última alteração
+}
+
+// CartItem é um item do carrinho, com as escolhas do cliente
+type CartItem struct {
+	ID          int            `json:"id"`                   // ID do item no carrinho
+	ProductID   int            `json:"product_id"`           // Produto
+	Ingredients string         `json:"ingredients"`          // JSON com os ingredientes escolhidos (lanche personalizado)
+	Quantity    int            `json:"quantity"`             // Quantidade
+	Notes       string         `json:"notes"`                // Observações do item
+	Choices     []BundleChoice `json:"choices,omitempty"`    // Escolhas das etapas (combos)
+	VariantID   *int           `json:"variant_id,omitempty"` // Variação escolhida
+	Allergies   []string       `json:"allergies,omitempty"`  // Alergias de quem vai consumir o item
+}
+
+// ApplyCouponRequest aplica um cupom ao carrinho
+type ApplyCouponRequest struct {
+	Code string `json:"code"` // Código do cupom
+}
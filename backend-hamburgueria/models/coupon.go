@@ -0,0 +1,51 @@
+package models
+
+import "time"
+
+// ===== MODELOS DE CUPONS =====
+
+// Coupon é um cupom de desconto (ex.: "BURGER5", "10% às terças")
+type Coupon struct {
+	ID                 int        `json:"id"`                              // ID único do cupom
+	Code               string     `json:"code"`                            // Código digitado pelo cliente (maiúsculas)
+	Description        string     `json:"description"`                     // Descrição da campanha
+	Kind               string     `json:"kind"`                            // Tipo: percentage (%) ou fixed (R$)
+	Value              float64    `json:"value"`                           // Percentual ou valor do desconto
+	MinOrder           float64    `json:"min_order"`                       // Subtotal mínimo do pedido
+	MaxUses            *int       `json:"max_uses,omitempty"`              // Limite de usos no total (vazio = sem limite)
+	MaxUsesPerCustomer *int       `json:"max_uses_per_customer,omitempty"` // Limite de usos por cliente (pelo telefone)
+	StartsAt           *time.Time `json:"starts_at,omitempty"`             // Início da validade
+	EndsAt             *time.Time `json:"ends_at,omitempty"`               // Fim da validade
+	Weekdays           []int      `json:"weekdays"`                        // Dias da semana válidos (0 = domingo; vazio = todos)
+	ProductIDs         []int      `json:"product_ids"`                     // Produtos participantes (vazio = todos)
+	CategoryIDs        []int      `json:"category_ids"`                    // Categorias participantes (vazio = todas)
+	IsActive           bool       `json:"is_active"`                       // Cupom ativo
+	UsesCount          int        `json:"uses_count"`                      // Usos registrados
+	CreatedAt          time.Time  `json:"created_at"`                      // Data de criação
+}
+
+// CouponRequest cria ou altera um cupom
+type CouponRequest struct {
+	Code               string     `json:"code"`                  // Código (gravado em maiúsculas)
+	Description        string     `json:"description"`           // Descrição
+	Kind               string     `json:"kind"`                  // percentage ou fixed
+	Value              float64    `json:"value"`                 // Percentual (0-100) ou valor em R$
+	MinOrder           float64    `json:"min_order"`             // Subtotal mínimo
+	MaxUses            *int       `json:"max_uses"`              // Limite total
+	MaxUsesPerCustomer *int       `json:"max_uses_per_customer"` // Limite por cliente
+	StartsAt           *time.Time `json:"starts_at"`             // Início da validade (RFC 3339)
+	EndsAt             *time.Time `json:"ends_at"`               // Fim da validade (RFC 3339)
+	Weekdays           []int      `json:"weekdays"`              // Dias da semana (0 = domingo)
+	ProductIDs         []int      `json:"product_ids"`           // Produtos participantes
+	CategoryIDs        []int      `json:"category_ids"`          // Categorias participantes
+	IsActive           *bool      `json:"is_active"`             // Ativo (padrão: sim)
+}
+
+// AppliedDiscount é um desconto aplicado no preço do pedido
+type AppliedDiscount struct {
+	Source      string  `json:"source"`                 // Origem: coupon ou promotion
+	Code        string  `json:"code,omitempty"`         // Código do cupom
+	PromotionID int     `json:"promotion_id,omitempty"` // Promoção automática aplicada
+	Name        string  `json:"name"`                   // Descrição exibida ao cliente
+	Amount      float64 `json:"amount"`                 // Valor do desconto
+}
@@ -0,0 +1,52 @@
+package models
+
+import "time"
+
+// ===== MODELOS DE ENTREGA =====
+
+// GeoPoint é uma coordenada geográfica em graus decimais
+type GeoPoint struct {
+	Latitude  float64 `json:"latitude"`  // Latitude (-90 a 90)
+	Longitude float64 `json:"longitude"` // Longitude (-180 a 180)
+}
+
+// CustomerAddress é um endereço de entrega salvo pelo cliente
+// O cliente é identificado pelo telefone (somente dígitos)
+type CustomerAddress struct {
+	ID            int       `json:"id"`             // ID único do endereço
+	CustomerPhone string    `json:"customer_phone"` // Telefone do cliente dono do endereço
+	Label         string    `json:"label"`          // Apelido: Casa, Trabalho...
+	Street        string    `json:"street"`         // Rua / avenida
+	Number        string    `json:"number"`         // Número
+	Complement    string    `json:"complement"`     // Apartamento, bloco...
+	Neighborhood  string    `json:"neighborhood"`   // Bairro
+	City          string    `json:"city"`           // Cidade
+	Reference     string    `json:"reference"`      // Ponto de referência para o entregador
+	Latitude      float64   `json:"latitude"`       // Latitude do endereço
+	Longitude     float64   `json:"longitude"`      // Longitude do endereço
+	CreatedAt     time.Time `json:"created_at"`     // Data de criação
+}
+
+// DeliveryZone é uma área de entrega com taxa e pedido mínimo próprios
+// Tipo "radius": raio em km a partir da loja; tipo "polygon": área desenhada no mapa
+type DeliveryZone struct {
+	ID          int        `json:"id"`                  // ID único da zona
+	Name        string     `json:"name"`                // Nome da zona (ex.: Centro, Até 3 km)
+	Kind        string     `json:"kind"`                // radius ou polygon
+	RadiusKm    float64    `json:"radius_km,omitempty"` // Raio em km (somente radius)
+	Polygon     []GeoPoint `json:"polygon,omitempty"`   // Vértices da área (somente polygon)
+	DeliveryFee float64    `json:"delivery_fee"`        // Taxa de entrega
+	MinOrder    float64    `json:"min_order"`           // Valor mínimo do pedido (sem a taxa)
+	SortOrder   int        `json:"sort_order"`          // Prioridade quando zonas se sobrepõem (menor primeiro)
+	IsActive    bool       `json:"is_active"`           // Se a zona está atendendo
+	CreatedAt   time.Time  `json:"created_at"`          // Data de criação
+}
+
+// DeliveryQuote é a taxa de entrega calculada para um ponto
+type DeliveryQuote struct {
+	ZoneID      int     `json:"zone_id"`      // Zona que atende o endereço
+	ZoneName    string  `json:"zone_name"`    // Nome da zona
+	DeliveryFee float64 `json:"delivery_fee"` // Taxa de entrega
+	MinOrder    float64 `json:"min_order"`    // Valor mínimo do pedido
+	DistanceKm  float64 `json:"distance_km"`  // Distância em linha reta até a loja (0 se a loja não tem coordenadas)
+}
@@ -0,0 +1,63 @@
+package models
+
+import "time"
+
+// ===== MODELOS DE ENTREGADORES E RASTREAMENTO =====
+
+// Driver representa um entregador
+type Driver struct {
+	ID         int        `json:"id"`                     // ID único do entregador
+	Name       string     `json:"name"`                   // Nome do entregador
+	Phone      string     `json:"phone"`                  // Telefone (somente dígitos)
+	Vehicle    string     `json:"vehicle"`                // Veículo: moto, bike, carro...
+	Status     string     `json:"status"`                 // Status: offline, available, on_delivery
+	Latitude   *float64   `json:"latitude,omitempty"`     // Última posição conhecida
+	Longitude  *float64   `json:"longitude,omitempty"`    // Última posição conhecida
+	LastSeenAt *time.Time `json:"last_seen_at,omitempty"` // Horário da última posição
+	IsActive   bool       `json:"is_active"`              // Se o entregador está cadastrado/ativo
+	CreatedAt  time.Time  `json:"created_at"`             // Data de criação
+}
+
+// DriverPositionRequest é a posição enviada periodicamente pelo app do entregador
+type DriverPositionRequest struct {
+	Latitude  float64 `json:"latitude"`  // Latitude atual
+	Longitude float64 `json:"longitude"` // Longitude atual
+}
+
+// DriverStatusRequest altera a disponibilidade do entregador
+type DriverStatusRequest struct {
+	Status string `json:"status"` // available ou offline
+}
+
+// DispatchOrderRequest atribui um pedido de entrega a um entregador
+type DispatchOrderRequest struct {
+	DriverID int `json:"driver_id"` // Entregador escolhido (0 = o disponível mais próximo da loja)
+}
+
+// DeliveryStatusRequest registra o andamento da entrega
+type DeliveryStatusRequest struct {
+	Status string `json:"status"` // picked_up (saiu da loja) ou delivered (entregue)
+}
+
+// TrackedDriver é o entregador como o cliente o vê no rastreamento
+type TrackedDriver struct {
+	ID         int        `json:"id"`                     // ID do entregador
+	Name       string     `json:"name"`                   // Nome do entregador
+	Vehicle    string     `json:"vehicle"`                // Veículo
+	Latitude   *float64   `json:"latitude,omitempty"`     // Última posição conhecida
+	Longitude  *float64   `json:"longitude,omitempty"`    // Última posição conhecida
+	LastSeenAt *time.Time `json:"last_seen_at,omitempty"` // Horário da última posição
+}
+
+// OrderTracking é o rastreamento de um pedido de entrega
+type OrderTracking struct {
+	OrderID            int            `json:"order_id"`                       // ID do pedido
+	Status             string         `json:"status"`                         // Status do pedido
+	DeliveryStatus     string         `json:"delivery_status"`                // "", assigned, picked_up ou delivered
+	Driver             *TrackedDriver `json:"driver,omitempty"`               // Entregador atribuído
+	Destination        *GeoPoint      `json:"destination,omitempty"`          // Destino da entrega
+	DistanceKm         float64        `json:"distance_km"`                    // Distância restante estimada
+	EtaMinutes         int            `json:"eta_minutes"`                    // Minutos estimados até a chegada
+	EstimatedArrivalAt *time.Time     `json:"estimated_arrival_at,omitempty"` // Previsão de chegada
+	UpdatedAt          time.Time      `json:"updated_at"`                     // Momento do cálculo
+}
@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+)
+
+// ===== MODELOS DE HISTÓRICO =====
+
+// OrderHistoryEntry representa um evento no histórico de um pedido
+// Exemplo: criação, mudança de status para "preparing", "ready"...
+type OrderHistoryEntry struct {
+	ID        int       `json:"id"`         // ID único do evento
+	OrderID   int       `json:"order_id"`   // ID do pedido (FK)
+	Event     string    `json:"event"`      // Tipo do evento: created, status
+	Status    string    `json:"status"`     // Status do pedido após o evento
+	Details   string    `json:"details"`    // Detalhes adicionais (texto livre)
+	CreatedAt time.Time `json:"created_at"` // Quando o evento aconteceu
+}
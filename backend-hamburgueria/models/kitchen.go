@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+)
+
+// ===== MODELOS DA COZINHA =====
+
+// AllDayCount agrupa os itens em aberto de um mesmo produto e customização
+// Exemplo: "12x Classic Burger" somando todos os pedidos pendentes/em preparo
+type AllDayCount struct {
+	ProductID         int       `json:"product_id"`                   // ID do produto
+	VariantID         *int      `json:"variant_id,omitempty"`         // Variação (tamanho) - usar no bump
+	ProductName       string    `json:"product_name"`                 // Nome do produto (com a variação)
+	Ingredients       string    `json:"ingredients"`                  // Customização normalizada (JSON) - usar no bump
+	Customization     string    `json:"customization"`                // Customização legível: "Pão Brioche, Carne Angus"
+	AllergenConflicts []string  `json:"allergen_conflicts,omitempty"` // Alérgenos declarados pelo cliente presentes no item (preparo separado) - usar no bump
+	Quantity          int       `json:"quantity"`                     // Quantidade total em aberto
+	OrderIDs          []int     `json:"order_ids"`                    // Pedidos que contêm o item
+	OldestAt          time.Time `json:"oldest_at"`                    // Criação do pedido mais antigo do grupo
+}
+
+// BumpBatchRequest representa a requisição para finalizar um lote de itens iguais
+// Quantity limita quantas unidades finalizar (0 = todas do grupo)
+type BumpBatchRequest struct {
+	ProductID         int      `json:"product_id"`         // ID do produto
+	VariantID         *int     `json:"variant_id"`         // Variação do grupo (como retornada no all-day)
+	Ingredients       string   `json:"ingredients"`        // Customização do grupo (como retornada no all-day)
+	AllergenConflicts []string `json:"allergen_conflicts"` // Alerta de alergia do grupo (como retornado no all-day)
+	Quantity          int      `json:"quantity"`           // Unidades a finalizar (0 = todas)
+}
+
+// BumpBatchResponse resume o resultado de um bump em lote
+type BumpBatchResponse struct {
+	Message       string `json:"message"`         // Mensagem de sucesso
+	BumpedItemIDs []int  `json:"bumped_item_ids"` // Itens marcados como preparados
+	BumpedUnits   int    `json:"bumped_units"`    // Unidades finalizadas
+	ReadyOrderIDs []int  `json:"ready_order_ids"` // Pedidos que ficaram prontos com o bump
+}
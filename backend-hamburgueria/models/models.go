@@ -19,55 +19,94 @@ type Category struct {
 // Product representa um produto do menu
 // Exemplo: Classic Burger, Bacon Deluxe, Refrigerante
 type Product struct {
-	ID          int       `json:"id"`                 // ID único do produto
-	Name        string    `json:"name"`               // Nome do produto
-	Description string    `json:"description"`        // Descrição do produto
-	Price       float64   `json:"price"`              // Preço do produto
-	CategoryID  int       `json:"category_id"`        // ID da categoria (FK)
-	Category    Category  `json:"category,omitempty"` // Categoria completa (opcional)
-	ImageURL    string    `json:"image_url"`          // URL da imagem do produto
-	IsAvailable bool      `json:"is_available"`       // Se o produto está disponível
-	CreatedAt   time.Time `json:"created_at"`         // Data de criação
+	ID          int              `json:"id"`                 // ID único do produto
+	Name        string           `json:"name"`               // Nome do produto
+	Description string           `json:"description"`        // Descrição do produto
+	Price       float64          `json:"price"`              // Preço do produto
+	CategoryID  int              `json:"category_id"`        // ID da categoria (FK)
+	Category    Category         `json:"category,omitempty"` // Categoria completa (opcional)
+	ImageURL    string           `json:"image_url"`          // URL da imagem do produto
+	IsAvailable bool             `json:"is_available"`       // Se o produto está disponível
+	Station     string           `json:"station"`            // Estação da cozinha: grill, fryer, bar...
+	PrepMinutes int              `json:"prep_minutes"`       // Tempo de preparo esperado (minutos)
+	IsBundle    bool             `json:"is_bundle"`          // Combo com etapas de escolha (GET /api/products/:id/bundle)
+	CreatedAt   time.Time        `json:"created_at"`         // Data de criação
+	Variants    []ProductVariant `json:"variants,omitempty"` // Tamanhos/versões disponíveis (escolha obrigatória no pedido)
+	Allergens   []string         `json:"allergens"`          // Alérgenos dos ingredientes da receita
+	DietTags    []string         `json:"diet_tags"`          // Dietas atendidas por todos os ingredientes (vegan, vegetarian)
+	Calories    *int             `json:"calories,omitempty"` // Calorias da receita (kcal; vazio = não informado)
 }
 
 // Ingredient representa um ingrediente para montagem de lanches
 // Exemplo: Pão Australiano, Carne Angus, Queijo Cheddar
 type Ingredient struct {
-	ID          int       `json:"id"`           // ID único do ingrediente
-	Name        string    `json:"name"`         // Nome do ingrediente
-	Price       float64   `json:"price"`        // Preço adicional do ingrediente
-	Category    string    `json:"category"`     // Tipo: pão, carne, queijo, vegetais, molhos
-	IsAvailable bool      `json:"is_available"` // Se o ingrediente está disponível
-	CreatedAt   time.Time `json:"created_at"`   // Data de criação
+	ID          int       `json:"id"`                 // ID único do ingrediente
+	Name        string    `json:"name"`               // Nome do ingrediente
+	Price       float64   `json:"price"`              // Preço adicional do ingrediente
+	Category    string    `json:"category"`           // Tipo: pão, carne, queijo, vegetais, molhos
+	IsAvailable bool      `json:"is_available"`       // Se o ingrediente está disponível
+	Allergens   []string  `json:"allergens"`          // Alérgenos: gluten, lactose, nuts...
+	DietTags    []string  `json:"diet_tags"`          // Dietas atendidas: vegan, vegetarian
+	Calories    *int      `json:"calories,omitempty"` // Calorias por porção (kcal)
+	CreatedAt   time.Time `json:"created_at"`         // Data de criação
 }
 
 // Order representa um pedido
 // Contém informações do cliente e status do pedido
 type Order struct {
-	ID           int         `json:"id"`              // ID único do pedido
-	CustomerName string      `json:"customer_name"`   // Nome do cliente
-	TableNumber  int         `json:"table_number"`    // Número da mesa
-	TotalAmount  float64     `json:"total_amount"`    // Valor total do pedido
-	Status       string      `json:"status"`          // Status: pending, preparing, ready, delivered
-	Notes        string      `json:"notes"`           // Observações do pedido
-	CreatedAt    time.Time   `json:"created_at"`      // Data de criação
-	UpdatedAt    time.Time   `json:"updated_at"`      // Data de última atualização
-	Items        []OrderItem `json:"items,omitempty"` // Itens do pedido (opcional)
+	ID                int            `json:"id"`                            // ID único do pedido
+	TicketNumber      int            `json:"ticket_number"`                 // Senha curta do pedido no dia de operação (1, 2, 3...)
+	ServiceDay        string         `json:"service_day"`                   // Dia de operação da senha (YYYY-MM-DD)
+	OrderType         string         `json:"order_type"`                    // Tipo: dine_in (salão), takeaway (retirada), delivery (entrega)
+	CustomerName      string         `json:"customer_name"`                 // Nome do cliente
+	TableNumber       int            `json:"table_number"`                  // Número da mesa
+	TableSessionID    *int           `json:"table_session_id,omitempty"`    // Comanda da mesa (pedidos no salão)
+	PickupName        string         `json:"pickup_name,omitempty"`         // Nome para chamar na retirada
+	CustomerPhone     string         `json:"customer_phone,omitempty"`      // Telefone do cliente (somente dígitos)
+	DeliveryAddress   string         `json:"delivery_address,omitempty"`    // Endereço de entrega
+	DeliveryAddressID *int           `json:"delivery_address_id,omitempty"` // Endereço salvo usado na entrega
+	DeliveryLatitude  *float64       `json:"delivery_latitude,omitempty"`   // Coordenadas do destino da entrega
+	DeliveryLongitude *float64       `json:"delivery_longitude,omitempty"`
+	DeliveryZoneID    *int           `json:"delivery_zone_id,omitempty"`   // Zona de entrega aplicada
+	DeliveryFee       float64        `json:"delivery_fee"`                 // Taxa de entrega (já incluída em total_amount)
+	DriverID          *int           `json:"driver_id,omitempty"`          // Entregador atribuído
+	DeliveryStatus    string         `json:"delivery_status,omitempty"`    // Andamento da entrega: assigned, picked_up, delivered
+	TotalAmount       float64        `json:"total_amount"`                 // Valor total do pedido
+	Pricing           PriceBreakdown `json:"pricing"`                      // Composição do total (subtotal, descontos, taxas, gorjeta)
+	Status            string         `json:"status"`                       // Status: scheduled, pending, preparing, ready, delivered
+	Notes             string         `json:"notes"`                        // Observações do pedido
+	ReleaseAt         *time.Time     `json:"release_at,omitempty"`         // Quando um pedido agendado entra na fila da cozinha
+	ScheduledFor      *time.Time     `json:"scheduled_for,omitempty"`      // Horário de retirada/entrega escolhido pelo cliente
+	Allergies         []string       `json:"allergies,omitempty"`          // Alergias declaradas pelo cliente (valem para todos os itens)
+	AllergyAlert      bool           `json:"allergy_alert"`                // Algum item contém alérgeno declarado (confirmado pelo cliente)
+	EstimatedReadyAt  *time.Time     `json:"estimated_ready_at,omitempty"` // Previsão de pronto (refinada a cada mudança de status)
+	CreatedAt         time.Time      `json:"created_at"`                   // Data de criação
+	UpdatedAt         time.Time      `json:"updated_at"`                   // Data de última atualização
+	Items             []OrderItem    `json:"items,omitempty"`              // Itens do pedido (opcional)
 }
 
 // OrderItem representa um item de um pedido
 // Cada pedido pode ter múltiplos itens
 type OrderItem struct {
-	ID          int       `json:"id"`                // ID único do item
-	OrderID     int       `json:"order_id"`          // ID do pedido (FK)
-	ProductID   int       `json:"product_id"`        // ID do produto (FK)
-	Product     Product   `json:"product,omitempty"` // Produto completo (opcional)
-	Ingredients string    `json:"ingredients"`       // JSON string com ingredientes customizados
-	Quantity    int       `json:"quantity"`          // Quantidade do item
-	UnitPrice   float64   `json:"unit_price"`        // Preço unitário
-	TotalPrice  float64   `json:"total_price"`       // Preço total do item
-	Notes       string    `json:"notes"`             // Observações do item
-	CreatedAt   time.Time `json:"created_at"`        // Data de criação
+	ID                int        `json:"id"`                           // ID único do item
+	OrderID           int        `json:"order_id"`                     // ID do pedido (FK)
+	ProductID         int        `json:"product_id"`                   // ID do produto (FK)
+	Product           Product    `json:"product,omitempty"`            // Produto completo (opcional)
+	Ingredients       string     `json:"ingredients"`                  // JSON string com ingredientes customizados
+	Quantity          int        `json:"quantity"`                     // Quantidade do item
+	UnitPrice         float64    `json:"unit_price"`                   // Preço unitário
+	TotalPrice        float64    `json:"total_price"`                  // Preço total do item
+	Notes             string     `json:"notes"`                        // Observações do item
+	PreparedAt        *time.Time `json:"prepared_at,omitempty"`        // Quando a cozinha finalizou o item (nil = em aberto)
+	BundleID          *int       `json:"bundle_id,omitempty"`          // Combo do qual o item faz parte
+	BundleSeq         *int       `json:"bundle_seq,omitempty"`         // Combo do pedido (itens do mesmo combo têm o mesmo número)
+	BundleSlot        string     `json:"bundle_slot,omitempty"`        // Etapa do combo
+	VariantID         *int       `json:"variant_id,omitempty"`         // Variação escolhida
+	VariantName       string     `json:"variant_name,omitempty"`       // Nome da variação no momento do pedido
+	VariantSKU        string     `json:"variant_sku,omitempty"`        // SKU da variação no momento do pedido
+	Allergies         []string   `json:"allergies,omitempty"`          // Alergias declaradas para o item (do pedido e do próprio item)
+	AllergenConflicts []string   `json:"allergen_conflicts,omitempty"` // Alérgenos declarados presentes no item (destaque na cozinha)
+	CreatedAt         time.Time  `json:"created_at"`                   // Data de criação
 }
 
 // ===== MODELOS DE REQUISIÇÃO =====
@@ -75,30 +114,92 @@ type OrderItem struct {
 // CreateOrderRequest representa a requisição para criar um pedido
 // Usado quando o frontend envia dados para criar um novo pedido
 type CreateOrderRequest struct {
-	CustomerName string             `json:"customer_name"` // Nome do cliente
-	TableNumber  int                `json:"table_number"`  // Número da mesa
-	Items        []OrderItemRequest `json:"items"`         // Lista de itens do pedido
-	Notes        string             `json:"notes"`         // Observações do pedido
+	OrderType             string             `json:"order_type"`              // dine_in, takeaway ou delivery (vazio: salão se houver mesa, senão retirada)
+	CustomerName          string             `json:"customer_name"`           // Nome do cliente
+	TableNumber           int                `json:"table_number"`            // Número da mesa
+	TableToken            string             `json:"table_token"`             // Token assinado do QR da mesa (define a mesa e o tipo dine_in)
+	PickupName            string             `json:"pickup_name"`             // Nome para retirada (padrão: customer_name)
+	CustomerPhone         string             `json:"customer_phone"`          // Telefone (obrigatório na entrega)
+	DeliveryAddress       string             `json:"delivery_address"`        // Endereço (obrigatório na entrega)
+	DeliveryAddressID     int                `json:"delivery_address_id"`     // Endereço salvo (substitui endereço e coordenadas)
+	DeliveryLatitude      *float64           `json:"delivery_latitude"`       // Latitude do endereço avulso
+	DeliveryLongitude     *float64           `json:"delivery_longitude"`      // Longitude do endereço avulso
+	Items                 []OrderItemRequest `json:"items"`                   // Lista de itens do pedido
+	Notes                 string             `json:"notes"`                   // Observações do pedido
+	IncludeServiceCharge  *bool              `json:"include_service_charge"`  // Cobrar a taxa de serviço (padrão: conforme o tipo do pedido)
+	Tip                   float64            `json:"tip"`                     // Gorjeta opcional
+	CouponCode            string             `json:"coupon_code"`             // Cupom de desconto (opcional)
+	ScheduledFor          *time.Time         `json:"scheduled_for,omitempty"` // Retirada/entrega agendada (RFC 3339; vazio = o quanto antes)
+	Allergies             []string           `json:"allergies,omitempty"`     // Alergias do cliente (gluten, lactose, nuts...), conferidas em todos os itens
+	AllowAllergyConflicts bool               `json:"allow_allergy_conflicts"` // Confirma o pedido mesmo com itens que contêm os alérgenos declarados
 }
 
 // OrderItemRequest representa um item de pedido na requisição
 // Usado dentro de CreateOrderRequest para especificar os itens
 type OrderItemRequest struct {
-	ProductID   int    `json:"product_id"`  // ID do produto
-	Ingredients string `json:"ingredients"` // JSON string com ingredientes customizados
-	Quantity    int    `json:"quantity"`    // Quantidade
-	Notes       string `json:"notes"`       // Observações do item
+	ProductID   int            `json:"product_id"`           // ID do produto
+	Ingredients string         `json:"ingredients"`          // JSON string com ingredientes customizados
+	Quantity    int            `json:"quantity"`             // Quantidade
+	Notes       string         `json:"notes"`                // Observações do item
+	Choices     []BundleChoice `json:"choices,omitempty"`    // Escolhas das etapas (combos)
+	VariantID   *int           `json:"variant_id,omitempty"` // Variação (obrigatória quando o produto tem variações)
+	Allergies   []string       `json:"allergies,omitempty"`  // Alergias de quem vai consumir o item (somadas às do pedido)
+}
+
+// PriceBreakdown é a composição do valor do pedido, gravada junto com o pedido
+// total = subtotal - discount + service_charge + delivery_fee + tip
+type PriceBreakdown struct {
+	Subtotal      float64 `json:"subtotal"`       // Soma dos itens
+	Discount      float64 `json:"discount"`       // Descontos aplicados
+	ServiceCharge float64 `json:"service_charge"` // Taxa de serviço (10% no salão, por padrão)
+	DeliveryFee   float64 `json:"delivery_fee"`   // Taxa de entrega
+	Tip           float64 `json:"tip"`            // Gorjeta
+	Total         float64 `json:"total"`          // Valor final cobrado
 }
 
 // UpdateOrderStatusRequest representa a requisição para atualizar status do pedido
 // Usado quando a cozinha atualiza o status de um pedido
 type UpdateOrderStatusRequest struct {
-	Status string `json:"status"` // Novo status: pending, preparing, ready, delivered
+	Status string `json:"status"` // Novo status: scheduled, pending, preparing, ready, delivered
 }
 type StatusResponse struct {
-		Message string `json: "message"`
+	Message string `json:"message"`
 }
 
 type ErrorResponse struct {
-		Error string `json: "error"`
+	Error string `json:"error"`
+}
+
+// PriceModifier é um ingrediente escolhido que compõe o preço do item (lanche personalizado)
+type PriceModifier struct {
+	IngredientID int     `json:"ingredient_id"` // Ingrediente
+	Name         string  `json:"name"`          // Nome do ingrediente
+	Price        float64 `json:"price"`         // Preço do ingrediente
+}
+
+// QuoteLine é o preço calculado de um item do pedido
+type QuoteLine struct {
+	ProductID         int               `json:"product_id"`                   // Produto
+	VariantID         *int              `json:"variant_id,omitempty"`         // Variação escolhida
+	VariantName       string            `json:"variant_name,omitempty"`       // Nome da variação
+	Quantity          int               `json:"quantity"`                     // Quantidade
+	UnitPrice         float64           `json:"unit_price"`                   // Preço unitário (com os ingredientes escolhidos)
+	TotalPrice        float64           `json:"total_price"`                  // Preço da linha
+	Modifiers         []PriceModifier   `json:"modifiers,omitempty"`          // Ingredientes que compõem o preço
+	Allergens         []string          `json:"allergens,omitempty"`          // Alérgenos do item (receita, ingredientes escolhidos ou produtos do combo)
+	AllergenConflicts []string          `json:"allergen_conflicts,omitempty"` // Alérgenos do item que o cliente declarou ter alergia
+	Discount          float64           `json:"discount"`                     // Desconto das promoções no item
+	Promotions        []LinePromotion   `json:"promotions,omitempty"`         // Promoções aplicadas no item
+	Components        []BundleComponent `json:"components,omitempty"`         // Produtos do combo
+	ComboSaving       float64           `json:"combo_saving,omitempty"`       // Economia do combo montado automaticamente com itens avulsos
+}
+
+// OrderQuote é a cotação de um pedido: os mesmos valores que CreateOrder gravaria
+type OrderQuote struct {
+	OrderType    string            `json:"order_type"`              // Tipo do pedido
+	Lines        []QuoteLine       `json:"lines"`                   // Itens com preço
+	Pricing      PriceBreakdown    `json:"pricing"`                 // Composição do total
+	Discounts    []AppliedDiscount `json:"discounts,omitempty"`     // Descontos aplicados (promoções e cupom)
+	ComboSaving  float64           `json:"combo_saving,omitempty"`  // Economia dos itens reagrupados em combos
+	DeliveryZone string            `json:"delivery_zone,omitempty"` // Zona de entrega aplicada
 }
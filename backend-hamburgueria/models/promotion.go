@@ -0,0 +1,35 @@
+package models
+
+import "time"
+
+// ===== MODELOS DE PROMOÇÕES AUTOMÁTICAS =====
+
+// Promotion é uma regra de promoção aplicada sem código no cálculo do pedido
+// (happy hour, "leve 2 lanches e ganhe a batata", desconto por categoria em dias da semana)
+type Promotion struct {
+	ID                int        `json:"id"`                   // ID único da promoção
+	Name              string     `json:"name"`                 // Nome exibido ao cliente
+	Kind              string     `json:"kind"`                 // percentage, fixed_price ou buy_x_get_y
+	Value             float64    `json:"value"`                // Percentual, preço promocional ou % de desconto no brinde
+	ProductIDs        []int      `json:"product_ids"`          // Produtos participantes (vazio = todos)
+	CategoryIDs       []int      `json:"category_ids"`         // Categorias participantes (vazio = todas)
+	BuyQuantity       int        `json:"buy_quantity"`         // buy_x_get_y: quantidade comprada
+	GetQuantity       int        `json:"get_quantity"`         // buy_x_get_y: quantidade ganha
+	RewardProductIDs  []int      `json:"reward_product_ids"`   // buy_x_get_y: produtos do brinde (vazio = participantes)
+	RewardCategoryIDs []int      `json:"reward_category_ids"`  // buy_x_get_y: categorias do brinde
+	Weekdays          []int      `json:"weekdays"`             // Dias da semana (0 = domingo; vazio = todos)
+	StartTime         string     `json:"start_time,omitempty"` // Início do horário (HH:MM, horário da loja)
+	EndTime           string     `json:"end_time,omitempty"`   // Fim do horário (HH:MM)
+	StartsAt          *time.Time `json:"starts_at,omitempty"`  // Início da campanha
+	EndsAt            *time.Time `json:"ends_at,omitempty"`    // Fim da campanha
+	IsActive          bool       `json:"is_active"`            // Promoção ativa
+	CreatedAt         time.Time  `json:"created_at"`           // Data de criação
+}
+
+// LinePromotion é uma promoção aplicada a um item do pedido
+type LinePromotion struct {
+	PromotionID int     `json:"promotion_id"` // Promoção
+	Name        string  `json:"name"`         // Nome da promoção
+	Quantity    int     `json:"quantity"`     // Unidades do item usadas pela promoção
+	Discount    float64 `json:"discount"`     // Desconto no item (zero nas unidades que só ativam a promoção)
+}
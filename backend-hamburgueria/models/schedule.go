@@ -0,0 +1,19 @@
+package models
+
+// ===== MODELOS DE HORÁRIOS DO CARDÁPIO =====
+
+// AvailabilitySchedule é uma janela de venda de um produto ou categoria (ex.: café da manhã)
+// Campos vazios não restringem; horários que atravessam a meia-noite valem até o fim no dia seguinte
+type AvailabilitySchedule struct {
+	ID        int    `json:"id"`                   // ID da janela
+	Weekdays  []int  `json:"weekdays"`             // Dias da semana (0 = domingo; vazio = todos)
+	StartTime string `json:"start_time,omitempty"` // Início do horário (HH:MM, horário da loja)
+	EndTime   string `json:"end_time,omitempty"`   // Fim do horário (HH:MM, exclusivo)
+	StartDate string `json:"start_date,omitempty"` // Primeiro dia (YYYY-MM-DD)
+	EndDate   string `json:"end_date,omitempty"`   // Último dia (YYYY-MM-DD, inclusivo)
+}
+
+// SetSchedulesRequest substitui as janelas de venda de um produto ou categoria
+type SetSchedulesRequest struct {
+	Schedules []AvailabilitySchedule `json:"schedules"` // Janelas (lista vazia = vendido o dia todo)
+}
@@ -0,0 +1,58 @@
+package models
+
+import "time"
+
+// ===== MODELOS DE FUNCIONAMENTO DA LOJA =====
+
+// StoreHours é um período de funcionamento num dia da semana (pode haver mais de um, ex.: almoço e jantar)
+type StoreHours struct {
+	Weekday   int    `json:"weekday"`    // Dia da semana (0 = domingo)
+	OpenTime  string `json:"open_time"`  // Abertura (HH:MM, horário da loja)
+	CloseTime string `json:"close_time"` // Fechamento (HH:MM; menor ou igual à abertura = depois da meia-noite)
+}
+
+// StoreException substitui o horário semanal numa data (feriado fechado ou horário especial)
+type StoreException struct {
+	Date      string `json:"date"`                 // Data (YYYY-MM-DD)
+	IsClosed  bool   `json:"is_closed"`            // Loja fechada o dia todo
+	OpenTime  string `json:"open_time,omitempty"`  // Abertura especial (HH:MM)
+	CloseTime string `json:"close_time,omitempty"` // Fechamento especial (HH:MM)
+	Reason    string `json:"reason"`               // Motivo exibido ao cliente (ex.: "Natal")
+}
+
+// StorePause é a pausa manual dos pedidos, com retomada automática opcional
+type StorePause struct {
+	Reason   string     `json:"reason"`              // Motivo exibido ao cliente
+	PausedAt time.Time  `json:"paused_at"`           // Início da pausa
+	ResumeAt *time.Time `json:"resume_at,omitempty"` // Retomada automática (vazio = até retomar manualmente)
+}
+
+// StoreHoursConfig é o horário semanal com as exceções a partir de hoje
+type StoreHoursConfig struct {
+	Hours      []StoreHours     `json:"hours"`      // Horário semanal
+	Exceptions []StoreException `json:"exceptions"` // Feriados e horários especiais (de hoje em diante)
+}
+
+// SetStoreHoursRequest substitui o horário semanal
+type SetStoreHoursRequest struct {
+	Hours []StoreHours `json:"hours"` // Períodos (lista vazia = aberta o dia todo)
+}
+
+// PauseStoreRequest pausa os pedidos
+type PauseStoreRequest struct {
+	Reason          string     `json:"reason"`           // Motivo
+	ResumeAt        *time.Time `json:"resume_at"`        // Retomada automática (RFC 3339)
+	DurationMinutes int        `json:"duration_minutes"` // Alternativa ao resume_at: retomar após N minutos
+}
+
+// StoreStatus é a situação atual da loja para receber pedidos
+type StoreStatus struct {
+	State           string      `json:"state"`                  // open, closed ou paused
+	AcceptingOrders bool        `json:"accepting_orders"`       // Se novos pedidos são aceitos agora
+	Message         string      `json:"message"`                // Mensagem para o cliente
+	Reason          string      `json:"reason,omitempty"`       // Motivo da pausa ou do fechamento especial
+	ClosesAt        *time.Time  `json:"closes_at,omitempty"`    // Fechamento do período atual
+	NextOpenAt      *time.Time  `json:"next_open_at,omitempty"` // Próxima abertura (loja fechada)
+	ResumeAt        *time.Time  `json:"resume_at,omitempty"`    // Retomada automática da pausa
+	Pause           *StorePause `json:"pause,omitempty"`        // Pausa em andamento
+}
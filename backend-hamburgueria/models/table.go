@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// ===== MODELOS DE MESAS =====
+
+// Table representa uma mesa cadastrada do salão
+type Table struct {
+	ID        int       `json:"id"`                  // ID único da mesa
+	Number    int       `json:"number"`              // Número exibido na mesa
+	Area      string    `json:"area"`                // Área: salão, terraço, varanda...
+	Seats     int       `json:"seats"`               // Lugares
+	IsActive  bool      `json:"is_active"`           // Se a mesa está em uso
+	OrderURL  string    `json:"order_url,omitempty"` // Endereço assinado aberto pelo QR code da mesa
+	CreatedAt time.Time `json:"created_at"`          // Data de criação
+}
+
+// UpdateTableRequest altera os dados de uma mesa (campos ausentes ficam como estão)
+type UpdateTableRequest struct {
+	Area     *string `json:"area"`      // Nova área
+	Seats    *int    `json:"seats"`     // Novos lugares
+	IsActive *bool   `json:"is_active"` // Ativar/desativar a mesa
+}
@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+// ===== MODELOS DE COMANDAS DE MESA =====
+
+// TableSession é a comanda de uma mesa: agrupa todas as rodadas até o fechamento da conta
+type TableSession struct {
+	ID          int        `json:"id"`                    // ID único da comanda
+	TableNumber int        `json:"table_number"`          // Número da mesa
+	Status      string     `json:"status"`                // Status: open (aberta), closed (conta fechada) ou merged (juntada a outra)
+	OrderCount  int        `json:"order_count"`           // Quantidade de pedidos (rodadas)
+	TotalAmount float64    `json:"total_amount"`          // Consumo acumulado (valor final após o fechamento)
+	Notes       string     `json:"notes"`                 // Observações da comanda
+	OpenedAt    time.Time  `json:"opened_at"`             // Abertura da comanda
+	ClosedAt    *time.Time `json:"closed_at,omitempty"`   // Fechamento da conta
+	MergedInto  *int       `json:"merged_into,omitempty"` // Comanda que incorporou esta (status merged)
+	Orders      []Order    `json:"orders,omitempty"`      // Pedidos da comanda (somente nos detalhes)
+}
+
+// OpenTableSessionRequest abre uma comanda manualmente (ex.: clientes sentaram e ainda não pediram)
+type OpenTableSessionRequest struct {
+	TableNumber int    `json:"table_number"` // Número da mesa
+	Notes       string `json:"notes"`        // Observações
+}
+
+// TransferItem é a quantidade de um item de pedido movida para outra mesa
+type TransferItem struct {
+	OrderItemID int `json:"order_item_id"` // Item do pedido de origem
+	Quantity    int `json:"quantity"`      // Quantidade a mover (até a quantidade do item)
+}
+
+// TransferTableRequest move a comanda inteira, alguns pedidos ou alguns itens para outra mesa
+// Sem order_ids e sem items, a comanda inteira muda de mesa
+type TransferTableRequest struct {
+	ToTableNumber int            `json:"to_table_number"` // Mesa de destino
+	OrderIDs      []int          `json:"order_ids"`       // Pedidos a mover (opcional)
+	Items         []TransferItem `json:"items"`           // Itens a mover (opcional)
+}
+
+// MergeTableSessionsRequest junta outra comanda aberta nesta
+type MergeTableSessionsRequest struct {
+	SourceSessionID int `json:"source_session_id"` // Comanda que será incorporada (fica como merged)
+}
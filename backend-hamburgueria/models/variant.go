@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// ===== MODELOS DE VARIAÇÕES =====
+
+// ProductVariant é um tamanho ou versão de um produto (ex.: Batata "M"), com preço próprio
+type ProductVariant struct {
+	ID          int       `json:"id"`           // ID único da variação
+	ProductID   int       `json:"product_id"`   // Produto (FK)
+	Name        string    `json:"name"`         // Nome exibido (P, M, G, 500ml...)
+	SKU         string    `json:"sku"`          // Código único da variação
+	Price       float64   `json:"price"`        // Preço da variação (substitui o preço do produto)
+	IsAvailable bool      `json:"is_available"` // Se a variação está disponível
+	Position    int       `json:"position"`     // Ordem de exibição
+	CreatedAt   time.Time `json:"created_at"`   // Data de criação
+}
+
+// ProductVariantRequest cria ou altera uma variação
+type ProductVariantRequest struct {
+	Name        string  `json:"name"`         // Nome exibido
+	SKU         string  `json:"sku"`          // Código único (gravado em maiúsculas)
+	Price       float64 `json:"price"`        // Preço
+	IsAvailable *bool   `json:"is_available"` // Disponível (padrão: true)
+	Position    int     `json:"position"`     // Ordem de exibição
+}
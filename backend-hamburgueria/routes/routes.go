@@ -24,24 +24,89 @@ func SetupRoutes(r *gin.Engine, db *sql.DB) {
 			handlers.GetProducts(c, db)
 		})
 
+		// GET /api/products/:id/bundle - Etapas de escolha de um combo
+		api.GET("/products/:id/bundle", func(c *gin.Context) {
+			handlers.GetBundle(c, db)
+		})
+
+		// PUT /api/products/:id/bundle - Definir as etapas do combo
+		api.PUT("/products/:id/bundle", func(c *gin.Context) {
+			handlers.SetBundle(c, db)
+		})
+
+		// GET /api/products/:id/variants - Tamanhos/versões do produto
+		api.GET("/products/:id/variants", func(c *gin.Context) {
+			handlers.GetProductVariants(c, db)
+		})
+
+		// POST /api/products/:id/variants - Cadastrar variação
+		api.POST("/products/:id/variants", func(c *gin.Context) {
+			handlers.CreateProductVariant(c, db)
+		})
+
+		// PUT /api/products/:id/variants/:variant_id - Alterar variação
+		api.PUT("/products/:id/variants/:variant_id", func(c *gin.Context) {
+			handlers.UpdateProductVariant(c, db)
+		})
+
+		// GET /api/products/:id/recipe - Receita do produto (origem dos alérgenos)
+		api.GET("/products/:id/recipe", func(c *gin.Context) {
+			handlers.GetProductRecipe(c, db)
+		})
+
+		// PUT /api/products/:id/recipe - Definir a receita do produto
+		api.PUT("/products/:id/recipe", func(c *gin.Context) {
+			handlers.SetProductRecipe(c, db)
+		})
+
+		// GET /api/products/:id/schedules - Horários de venda do produto
+		api.GET("/products/:id/schedules", func(c *gin.Context) {
+			handlers.GetProductSchedules(c, db)
+		})
+
+		// PUT /api/products/:id/schedules - Definir os horários de venda do produto
+		api.PUT("/products/:id/schedules", func(c *gin.Context) {
+			handlers.SetProductSchedules(c, db)
+		})
+
 		// ===== ROTAS DE CATEGORIAS =====
 		// GET /api/categories - Listar todas as categorias
 		api.GET("/categories", func(c *gin.Context) {
 			handlers.GetCategories(c, db)
 		})
 
+		// GET /api/categories/:id/schedules - Horários de venda da categoria
+		api.GET("/categories/:id/schedules", func(c *gin.Context) {
+			handlers.GetCategorySchedules(c, db)
+		})
+
+		// PUT /api/categories/:id/schedules - Definir os horários de venda da categoria
+		api.PUT("/categories/:id/schedules", func(c *gin.Context) {
+			handlers.SetCategorySchedules(c, db)
+		})
+
 		// ===== ROTAS DE INGREDIENTES =====
 		// GET /api/ingredients - Listar todos os ingredientes para montagem
 		api.GET("/ingredients", func(c *gin.Context) {
 			handlers.GetIngredients(c, db)
 		})
 
+		// PUT /api/ingredients/:id/dietary - Alérgenos, dietas e calorias do ingrediente
+		api.PUT("/ingredients/:id/dietary", func(c *gin.Context) {
+			handlers.SetIngredientDietary(c, db)
+		})
+
 		// ===== ROTAS DE PEDIDOS =====
 		// POST /api/orders - Criar um novo pedido
 		api.POST("/orders", func(c *gin.Context) {
 			handlers.CreateOrder(c, db)
 		})
 
+		// POST /api/orders/quote - Cotar um pedido sem gravar (mesmo cálculo da criação)
+		api.POST("/orders/quote", func(c *gin.Context) {
+			handlers.QuoteOrder(c, db)
+		})
+
 		// GET /api/orders - Listar todos os pedidos
 		// Suporta filtro: GET /api/orders?status=preparing
 		api.GET("/orders", func(c *gin.Context) {
@@ -53,11 +118,324 @@ func SetupRoutes(r *gin.Engine, db *sql.DB) {
 			handlers.GetOrderDetails(c, db)
 		})
 
+		// GET /api/orders/ticket/:number - Buscar pedido pela senha do dia
+		// Suporta outro dia de operação: GET /api/orders/ticket/17?day=2026-10-18
+		api.GET("/orders/ticket/:number", func(c *gin.Context) {
+			handlers.GetOrderByTicket(c, db)
+		})
+
 		// PUT /api/orders/:id/status - Atualizar status de um pedido
 		// Usado pela cozinha para marcar pedidos como pronto/entregue
 		api.PUT("/orders/:id/status", func(c *gin.Context) {
 			handlers.UpdateOrderStatus(c, db)
 		})
+
+		// GET /api/orders/:id/history - Histórico de eventos do pedido
+		api.GET("/orders/:id/history", func(c *gin.Context) {
+			handlers.GetOrderHistory(c, db)
+		})
+
+		// GET /api/orders/:id/kitchen-ticket - Ticket da cozinha em texto (impressora térmica)
+		api.GET("/orders/:id/kitchen-ticket", func(c *gin.Context) {
+			handlers.GetKitchenTicket(c, db)
+		})
+
+		// ===== ROTAS DE CARRINHO =====
+		// POST /api/carts - Criar carrinho (totem e app)
+		api.POST("/carts", func(c *gin.Context) {
+			handlers.CreateCart(c, db)
+		})
+
+		// GET /api/carts/:id - Carrinho com itens
+		api.GET("/carts/:id", func(c *gin.Context) {
+			handlers.GetCart(c, db)
+		})
+
+		// PUT /api/carts/:id - Dados do pedido (tipo, cliente, mesa, entrega, gorjeta)
+		api.PUT("/carts/:id", func(c *gin.Context) {
+			handlers.UpdateCart(c, db)
+		})
+
+		// POST /api/carts/:id/items - Adicionar item
+		api.POST("/carts/:id/items", func(c *gin.Context) {
+			handlers.AddCartItem(c, db)
+		})
+
+		// PUT /api/carts/:id/items/:item_id - Alterar quantidade, ingredientes ou observações
+		api.PUT("/carts/:id/items/:item_id", func(c *gin.Context) {
+			handlers.UpdateCartItem(c, db)
+		})
+
+		// DELETE /api/carts/:id/items/:item_id - Remover item
+		api.DELETE("/carts/:id/items/:item_id", func(c *gin.Context) {
+			handlers.RemoveCartItem(c, db)
+		})
+
+		// POST /api/carts/:id/coupon - Aplicar cupom
+		api.POST("/carts/:id/coupon", func(c *gin.Context) {
+			handlers.ApplyCartCoupon(c, db)
+		})
+
+		// DELETE /api/carts/:id/coupon - Remover cupom
+		api.DELETE("/carts/:id/coupon", func(c *gin.Context) {
+			handlers.RemoveCartCoupon(c, db)
+		})
+
+		// GET /api/carts/:id/quote - Cotar o carrinho
+		api.GET("/carts/:id/quote", func(c *gin.Context) {
+			handlers.QuoteCart(c, db)
+		})
+
+		// POST /api/carts/:id/checkout - Fechar o carrinho e criar o pedido
+		api.POST("/carts/:id/checkout", func(c *gin.Context) {
+			handlers.CheckoutCart(c, db)
+		})
+
+		// ===== ROTAS DA COZINHA =====
+		// GET /api/kitchen/all-day - Itens em aberto agrupados por produto e customização
+		api.GET("/kitchen/all-day", func(c *gin.Context) {
+			handlers.GetAllDayCounts(c, db)
+		})
+
+		// POST /api/kitchen/all-day/bump - Finalizar um lote de itens iguais em vários pedidos
+		api.POST("/kitchen/all-day/bump", func(c *gin.Context) {
+			handlers.BumpAllDayBatch(c, db)
+		})
+
+		// GET /api/kitchen/wait - Espera estimada para um pedido novo (público, tela do cardápio)
+		api.GET("/kitchen/wait", func(c *gin.Context) {
+			handlers.GetKitchenWait(c, db)
+		})
+
+		// ===== ROTAS DE MESAS =====
+		// GET /api/tables - Mesas cadastradas com o endereço do QR code
+		api.GET("/tables", func(c *gin.Context) {
+			handlers.GetTables(c, db)
+		})
+
+		// POST /api/tables - Cadastrar mesa
+		api.POST("/tables", func(c *gin.Context) {
+			handlers.CreateTable(c, db)
+		})
+
+		// GET /api/tables/resolve?token=... - Mesa do QR code lido pelo cliente
+		api.GET("/tables/resolve", func(c *gin.Context) {
+			handlers.ResolveTableToken(c, db)
+		})
+
+		// PUT /api/tables/:id - Alterar área, lugares ou ativar/desativar
+		api.PUT("/tables/:id", func(c *gin.Context) {
+			handlers.UpdateTable(c, db)
+		})
+
+		// GET /api/tables/:id/qr - QR code da mesa (?format=svg, ?size=512)
+		api.GET("/tables/:id/qr", func(c *gin.Context) {
+			handlers.GetTableQRCode(c, db)
+		})
+
+		// ===== ROTAS DE COMANDAS DE MESA =====
+		// GET /api/table-sessions - Mesas abertas e consumo (?status=closed para as fechadas)
+		api.GET("/table-sessions", func(c *gin.Context) {
+			handlers.GetTableSessions(c, db)
+		})
+
+		// POST /api/table-sessions - Abrir comanda de uma mesa
+		api.POST("/table-sessions", func(c *gin.Context) {
+			handlers.OpenTableSession(c, db)
+		})
+
+		// GET /api/table-sessions/:id - Comanda com todas as rodadas
+		api.GET("/table-sessions/:id", func(c *gin.Context) {
+			handlers.GetTableSession(c, db)
+		})
+
+		// POST /api/table-sessions/:id/close - Fechar a conta
+		api.POST("/table-sessions/:id/close", func(c *gin.Context) {
+			handlers.CloseTableSession(c, db)
+		})
+
+		// POST /api/table-sessions/:id/transfer - Trocar de mesa (comanda, pedidos ou itens)
+		api.POST("/table-sessions/:id/transfer", func(c *gin.Context) {
+			handlers.TransferTableSession(c, db)
+		})
+
+		// POST /api/table-sessions/:id/merge - Juntar outra comanda nesta
+		api.POST("/table-sessions/:id/merge", func(c *gin.Context) {
+			handlers.MergeTableSessions(c, db)
+		})
+
+		// ===== ROTAS DE DIVISÃO DA CONTA =====
+		// POST /api/table-sessions/:id/split - Dividir a conta da mesa (equal, items ou custom)
+		api.POST("/table-sessions/:id/split", func(c *gin.Context) {
+			handlers.CreateTableSessionSplit(c, db)
+		})
+
+		// GET /api/table-sessions/:id/split - Divisão atual da conta da mesa
+		api.GET("/table-sessions/:id/split", func(c *gin.Context) {
+			handlers.GetTableSessionSplit(c, db)
+		})
+
+		// POST /api/orders/:id/split - Dividir a conta de um pedido fora de comanda
+		api.POST("/orders/:id/split", func(c *gin.Context) {
+			handlers.CreateOrderSplit(c, db)
+		})
+
+		// GET /api/bill-splits/:id - Divisão com parcelas e pagamentos
+		api.GET("/bill-splits/:id", func(c *gin.Context) {
+			handlers.GetBillSplit(c, db)
+		})
+
+		// POST /api/bill-splits/:id/shares/:share_id/pay - Pagar uma parcela
+		api.POST("/bill-splits/:id/shares/:share_id/pay", func(c *gin.Context) {
+			handlers.PayBillShare(c, db)
+		})
+
+		// ===== ROTAS DE CUPONS =====
+		// GET /api/coupons - Cupons cadastrados
+		api.GET("/coupons", func(c *gin.Context) {
+			handlers.GetCoupons(c, db)
+		})
+
+		// POST /api/coupons - Cadastrar cupom
+		api.POST("/coupons", func(c *gin.Context) {
+			handlers.CreateCoupon(c, db)
+		})
+
+		// PUT /api/coupons/:id - Alterar cupom
+		api.PUT("/coupons/:id", func(c *gin.Context) {
+			handlers.UpdateCoupon(c, db)
+		})
+
+		// ===== ROTAS DE PROMOÇÕES =====
+		// GET /api/promotions - Promoções automáticas
+		api.GET("/promotions", func(c *gin.Context) {
+			handlers.GetPromotions(c, db)
+		})
+
+		// POST /api/promotions - Cadastrar promoção
+		api.POST("/promotions", func(c *gin.Context) {
+			handlers.CreatePromotion(c, db)
+		})
+
+		// PUT /api/promotions/:id - Alterar promoção
+		api.PUT("/promotions/:id", func(c *gin.Context) {
+			handlers.UpdatePromotion(c, db)
+		})
+
+		// ===== ROTAS DE ENTREGA =====
+		// GET /api/delivery/zones - Zonas de entrega com taxa e pedido mínimo
+		api.GET("/delivery/zones", func(c *gin.Context) {
+			handlers.GetDeliveryZones(c, db)
+		})
+
+		// POST /api/delivery/zones - Cadastrar zona (raio ou polígono)
+		api.POST("/delivery/zones", func(c *gin.Context) {
+			handlers.CreateDeliveryZone(c, db)
+		})
+
+		// GET /api/delivery/quote - Taxa de entrega para um endereço
+		// Exemplo: GET /api/delivery/quote?latitude=-23.55&longitude=-46.63 ou ?address_id=3
+		api.GET("/delivery/quote", func(c *gin.Context) {
+			handlers.QuoteDelivery(c, db)
+		})
+
+		// GET /api/addresses?phone=... - Endereços salvos do cliente
+		api.GET("/addresses", func(c *gin.Context) {
+			handlers.GetCustomerAddresses(c, db)
+		})
+
+		// POST /api/addresses - Salvar endereço de entrega com coordenadas
+		api.POST("/addresses", func(c *gin.Context) {
+			handlers.CreateCustomerAddress(c, db)
+		})
+
+		// ===== ROTAS DE ENTREGADORES E RASTREAMENTO =====
+		// GET /api/drivers - Entregadores ativos (filtro: ?status=available)
+		api.GET("/drivers", func(c *gin.Context) {
+			handlers.GetDrivers(c, db)
+		})
+
+		// POST /api/drivers - Cadastrar entregador
+		api.POST("/drivers", func(c *gin.Context) {
+			handlers.CreateDriver(c, db)
+		})
+
+		// PUT /api/drivers/:id/status - Entregador disponível ou offline
+		api.PUT("/drivers/:id/status", func(c *gin.Context) {
+			handlers.UpdateDriverStatus(c, db)
+		})
+
+		// POST /api/drivers/:id/position - Posição atual enviada pelo app do entregador
+		api.POST("/drivers/:id/position", func(c *gin.Context) {
+			handlers.UpdateDriverPosition(c, db)
+		})
+
+		// POST /api/orders/:id/dispatch - Atribuir entregador (sem driver_id: o mais próximo)
+		api.POST("/orders/:id/dispatch", func(c *gin.Context) {
+			handlers.DispatchOrder(c, db)
+		})
+
+		// PUT /api/orders/:id/delivery-status - Saiu da loja (picked_up) ou entregue (delivered)
+		api.PUT("/orders/:id/delivery-status", func(c *gin.Context) {
+			handlers.UpdateDeliveryStatus(c, db)
+		})
+
+		// GET /api/orders/:id/tracking - Entregador, distância e previsão de chegada
+		api.GET("/orders/:id/tracking", func(c *gin.Context) {
+			handlers.GetOrderTracking(c, db)
+		})
+
+		// GET /api/orders/:id/tracking/stream - Stream SSE do rastreamento para o cliente
+		api.GET("/orders/:id/tracking/stream", func(c *gin.Context) {
+			handlers.StreamOrderTracking(c, db)
+		})
+
+		// ===== ROTAS DO PAINEL DE PEDIDOS =====
+		// GET /api/board - Senhas "Preparando" e "Pronto" do dia (público, TV do balcão)
+		api.GET("/board", func(c *gin.Context) {
+			handlers.GetBoard(c, db)
+		})
+
+		// GET /api/board/stream - Stream SSE do painel, atualizado a cada mudança
+		api.GET("/board/stream", func(c *gin.Context) {
+			handlers.StreamBoard(c, db)
+		})
+
+		// ===== ROTAS DE FUNCIONAMENTO DA LOJA =====
+		// GET /api/store/status - Loja aberta, fechada ou com pedidos pausados (público)
+		api.GET("/store/status", func(c *gin.Context) {
+			handlers.GetStoreStatus(c, db)
+		})
+
+		// GET /api/store/hours - Horário semanal e exceções
+		api.GET("/store/hours", func(c *gin.Context) {
+			handlers.GetStoreHours(c, db)
+		})
+
+		// PUT /api/store/hours - Definir o horário semanal
+		api.PUT("/store/hours", func(c *gin.Context) {
+			handlers.SetStoreHours(c, db)
+		})
+
+		// PUT /api/store/exceptions/:date - Feriado fechado ou horário especial na data
+		api.PUT("/store/exceptions/:date", func(c *gin.Context) {
+			handlers.SetStoreException(c, db)
+		})
+
+		// DELETE /api/store/exceptions/:date - Voltar ao horário semanal na data
+		api.DELETE("/store/exceptions/:date", func(c *gin.Context) {
+			handlers.DeleteStoreException(c, db)
+		})
+
+		// PUT /api/store/pause - Pausar os pedidos (motivo e retomada automática)
+		api.PUT("/store/pause", func(c *gin.Context) {
+			handlers.PauseStore(c, db)
+		})
+
+		// DELETE /api/store/pause - Retomar os pedidos
+		api.DELETE("/store/pause", func(c *gin.Context) {
+			handlers.ResumeStore(c, db)
+		})
 	}
 
 	// ===== ROTA DE HEALTH CHECK =====
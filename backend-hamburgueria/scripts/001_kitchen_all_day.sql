@@ -0,0 +1,8 @@
+-- ===== COZINHA: CONTAGEM ALL-DAY E BUMP EM LOTE =====
+-- Marca quando cada item foi finalizado pela cozinha (NULL = ainda em aberto)
+ALTER TABLE order_items ADD COLUMN IF NOT EXISTS prepared_at TIMESTAMP;
+
+-- Acelera a busca de itens em aberto por produto
+CREATE INDEX IF NOT EXISTS idx_order_items_open_product
+    ON order_items (product_id)
+    WHERE prepared_at IS NULL;
@@ -0,0 +1,16 @@
+-- ===== COZINHA: CAPACIDADE E PEDIDOS AGENDADOS =====
+-- Estação da cozinha responsável pelo produto (usada nos limites de capacidade)
+ALTER TABLE products ADD COLUMN IF NOT EXISTS station VARCHAR(30) NOT NULL DEFAULT 'grill';
+
+-- Sugestão inicial de estações por categoria (ajuste conforme a operação)
+UPDATE products SET station = 'fryer'
+    WHERE category_id IN (SELECT id FROM categories WHERE name ILIKE 'acompanhamento%');
+UPDATE products SET station = 'bar'
+    WHERE category_id IN (SELECT id FROM categories WHERE name ILIKE 'bebida%');
+
+-- Quando um pedido com status 'scheduled' entra na fila da cozinha
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS release_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_orders_scheduled_release
+    ON orders (release_at)
+    WHERE status = 'scheduled';
@@ -0,0 +1,19 @@
+-- ===== PREVISÃO DE PREPARO (ETA) E HISTÓRICO DE PEDIDOS =====
+-- Tempo de preparo esperado de cada produto (ponto de partida do aprendizado)
+ALTER TABLE products ADD COLUMN IF NOT EXISTS prep_minutes INTEGER NOT NULL DEFAULT 8;
+
+-- Previsão de pronto do pedido, refinada a cada mudança de status
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS estimated_ready_at TIMESTAMP;
+
+-- Histórico de eventos dos pedidos (criação, mudanças de status...)
+-- Os horários de "preparing" e "ready" alimentam a média real de preparo por produto
+CREATE TABLE IF NOT EXISTS order_history (
+    id SERIAL PRIMARY KEY,
+    order_id INTEGER NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    event VARCHAR(30) NOT NULL,
+    status VARCHAR(50) NOT NULL DEFAULT '',
+    details TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_history_order ON order_history (order_id, created_at);
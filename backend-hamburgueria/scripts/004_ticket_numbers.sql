@@ -0,0 +1,36 @@
+-- ===== SENHAS DO DIA =====
+-- Loja, dia de operação e senha curta de cada pedido
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS store_id INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS service_day DATE;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS ticket_number INTEGER;
+
+-- Contador atômico por loja e dia de operação
+CREATE TABLE IF NOT EXISTS ticket_counters (
+    store_id INTEGER NOT NULL,
+    service_day DATE NOT NULL,
+    last_number INTEGER NOT NULL,
+    PRIMARY KEY (store_id, service_day)
+);
+
+-- Pedidos antigos: senha pela ordem de criação no dia do calendário
+-- (aproximação - a virada configurada em SERVICE_DAY_START só vale para pedidos novos)
+UPDATE orders o
+SET service_day = numbered.day, ticket_number = numbered.n
+FROM (
+    SELECT id, created_at::date AS day,
+           ROW_NUMBER() OVER (PARTITION BY store_id, created_at::date ORDER BY created_at, id) AS n
+    FROM orders
+    WHERE ticket_number IS NULL
+) numbered
+WHERE o.id = numbered.id;
+
+INSERT INTO ticket_counters (store_id, service_day, last_number)
+SELECT store_id, service_day, MAX(ticket_number)
+FROM orders
+WHERE service_day IS NOT NULL
+GROUP BY store_id, service_day
+ON CONFLICT (store_id, service_day) DO NOTHING;
+
+-- Uma senha não se repete na mesma loja e dia
+CREATE UNIQUE INDEX IF NOT EXISTS idx_orders_ticket
+    ON orders (store_id, service_day, ticket_number);
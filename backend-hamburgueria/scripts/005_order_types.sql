@@ -0,0 +1,21 @@
+-- ===== TIPOS DE PEDIDO =====
+-- Salão (dine_in), retirada (takeaway) ou entrega (delivery), com os dados de cada tipo
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS order_type VARCHAR(20) NOT NULL DEFAULT 'dine_in';
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS pickup_name VARCHAR(255);
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS customer_phone VARCHAR(20);
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS delivery_address TEXT;
+
+-- Pedidos antigos lançados como "mesa 0" eram retiradas no balcão
+UPDATE orders SET order_type = 'takeaway', pickup_name = customer_name
+WHERE table_number = 0 AND order_type = 'dine_in';
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'orders_order_type_check') THEN
+        ALTER TABLE orders ADD CONSTRAINT orders_order_type_check
+            CHECK (order_type IN ('dine_in', 'takeaway', 'delivery'));
+    END IF;
+END $$;
+
+-- Filtro por tipo na listagem de pedidos (ex.: cozinha vendo só entregas em preparo)
+CREATE INDEX IF NOT EXISTS idx_orders_type_status ON orders (order_type, status);
@@ -0,0 +1,48 @@
+-- ===== ENTREGA: ENDEREÇOS, ZONAS E TAXAS =====
+
+-- Endereços salvos dos clientes (identificados pelo telefone)
+CREATE TABLE IF NOT EXISTS customer_addresses (
+    id SERIAL PRIMARY KEY,
+    customer_phone VARCHAR(20) NOT NULL,
+    label VARCHAR(50) NOT NULL DEFAULT '',
+    street VARCHAR(255) NOT NULL,
+    number VARCHAR(20) NOT NULL DEFAULT '',
+    complement VARCHAR(100) NOT NULL DEFAULT '',
+    neighborhood VARCHAR(100) NOT NULL DEFAULT '',
+    city VARCHAR(100) NOT NULL DEFAULT '',
+    reference VARCHAR(255) NOT NULL DEFAULT '',
+    latitude DOUBLE PRECISION NOT NULL,
+    longitude DOUBLE PRECISION NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_customer_addresses_phone ON customer_addresses (customer_phone);
+
+-- Zonas de entrega: raio a partir da loja ou polígono [{latitude, longitude}, ...]
+CREATE TABLE IF NOT EXISTS delivery_zones (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('radius', 'polygon')),
+    radius_km DECIMAL(6,2),
+    polygon JSONB,
+    delivery_fee DECIMAL(10,2) NOT NULL DEFAULT 0,
+    min_order DECIMAL(10,2) NOT NULL DEFAULT 0,
+    sort_order INTEGER NOT NULL DEFAULT 0,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Destino e taxa aplicados em cada pedido de entrega
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS delivery_address_id INTEGER REFERENCES customer_addresses(id);
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS delivery_latitude DOUBLE PRECISION;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS delivery_longitude DOUBLE PRECISION;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS delivery_zone_id INTEGER REFERENCES delivery_zones(id);
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS delivery_fee DECIMAL(10,2) NOT NULL DEFAULT 0;
+
+-- Zonas iniciais por raio (ajustar conforme a operação)
+INSERT INTO delivery_zones (name, kind, radius_km, delivery_fee, min_order, sort_order)
+SELECT * FROM (VALUES
+    ('Até 3 km', 'radius', 3.0, 5.00, 20.00, 1),
+    ('Até 6 km', 'radius', 6.0, 9.00, 35.00, 2)
+) AS seed(name, kind, radius_km, delivery_fee, min_order, sort_order)
+WHERE NOT EXISTS (SELECT 1 FROM delivery_zones);
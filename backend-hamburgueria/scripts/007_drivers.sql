@@ -0,0 +1,34 @@
+-- ===== ENTREGADORES E RASTREAMENTO =====
+
+CREATE TABLE IF NOT EXISTS drivers (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    phone VARCHAR(20) NOT NULL,
+    vehicle VARCHAR(50) NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL DEFAULT 'offline' CHECK (status IN ('offline', 'available', 'on_delivery')),
+    latitude DOUBLE PRECISION,
+    longitude DOUBLE PRECISION,
+    last_seen_at TIMESTAMP,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Trilha de posições enviadas pelos entregadores
+CREATE TABLE IF NOT EXISTS driver_positions (
+    id BIGSERIAL PRIMARY KEY,
+    driver_id INTEGER NOT NULL REFERENCES drivers(id) ON DELETE CASCADE,
+    latitude DOUBLE PRECISION NOT NULL,
+    longitude DOUBLE PRECISION NOT NULL,
+    recorded_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_driver_positions_driver ON driver_positions (driver_id, recorded_at);
+
+-- Entregador e andamento da entrega de cada pedido
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS driver_id INTEGER REFERENCES drivers(id);
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS delivery_status VARCHAR(20);
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS assigned_at TIMESTAMP;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS picked_up_at TIMESTAMP;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS delivered_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_orders_driver_active ON orders (driver_id) WHERE delivery_status IN ('assigned', 'picked_up');
@@ -0,0 +1,20 @@
+-- ===== COMANDAS DE MESA =====
+-- Agrupa as rodadas de uma mesa até o fechamento da conta
+
+CREATE TABLE IF NOT EXISTS table_sessions (
+    id SERIAL PRIMARY KEY,
+    store_id INTEGER NOT NULL DEFAULT 1,
+    table_number INTEGER NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'closed')),
+    notes TEXT NOT NULL DEFAULT '',
+    total_amount DECIMAL(10,2) NOT NULL DEFAULT 0,
+    opened_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    closed_at TIMESTAMP
+);
+
+-- Uma única comanda aberta por mesa
+CREATE UNIQUE INDEX IF NOT EXISTS idx_table_sessions_open
+    ON table_sessions (store_id, table_number) WHERE status = 'open';
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS table_session_id INTEGER REFERENCES table_sessions(id);
+CREATE INDEX IF NOT EXISTS idx_orders_table_session ON orders (table_session_id);
@@ -0,0 +1,25 @@
+-- ===== MESAS =====
+-- Cadastro das mesas do salão; pedidos dine_in só aceitam mesas ativas
+
+CREATE TABLE IF NOT EXISTS tables (
+    id SERIAL PRIMARY KEY,
+    store_id INTEGER NOT NULL DEFAULT 1,
+    number INTEGER NOT NULL CHECK (number > 0),
+    area VARCHAR(50) NOT NULL DEFAULT '',
+    seats INTEGER NOT NULL DEFAULT 4 CHECK (seats >= 0),
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (store_id, number)
+);
+
+-- Mesas iniciais: as já usadas em pedidos e comandas, ou 1 a 10 numa base nova
+INSERT INTO tables (store_id, number, area)
+SELECT DISTINCT store_id, table_number, 'salão' FROM orders WHERE table_number > 0
+UNION
+SELECT DISTINCT store_id, table_number, 'salão' FROM table_sessions
+ON CONFLICT (store_id, number) DO NOTHING;
+
+INSERT INTO tables (store_id, number, area)
+SELECT 1, n, 'salão' FROM generate_series(1, 10) AS n
+WHERE NOT EXISTS (SELECT 1 FROM tables)
+ON CONFLICT (store_id, number) DO NOTHING;
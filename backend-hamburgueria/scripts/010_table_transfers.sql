@@ -0,0 +1,8 @@
+-- ===== TRANSFERÊNCIA E JUNÇÃO DE MESAS =====
+-- Comanda juntada a outra fica com status 'merged' e aponta para a comanda que a recebeu
+
+ALTER TABLE table_sessions ADD COLUMN IF NOT EXISTS merged_into INTEGER REFERENCES table_sessions(id);
+
+ALTER TABLE table_sessions DROP CONSTRAINT IF EXISTS table_sessions_status_check;
+ALTER TABLE table_sessions ADD CONSTRAINT table_sessions_status_check
+    CHECK (status IN ('open', 'closed', 'merged'));
@@ -0,0 +1,31 @@
+-- ===== DIVISÃO DA CONTA =====
+-- Divide a conta de uma comanda (ou de um pedido) em parcelas pagas separadamente
+-- Valores em centavos para que a soma das parcelas feche exatamente com o total
+
+CREATE TABLE IF NOT EXISTS bill_splits (
+    id SERIAL PRIMARY KEY,
+    table_session_id INTEGER REFERENCES table_sessions(id),
+    order_id INTEGER REFERENCES orders(id),
+    mode VARCHAR(20) NOT NULL CHECK (mode IN ('equal', 'items', 'custom')),
+    status VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'paid', 'cancelled')),
+    subtotal_cents INTEGER NOT NULL,
+    service_charge_cents INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK ((table_session_id IS NULL) <> (order_id IS NULL))
+);
+
+CREATE INDEX IF NOT EXISTS idx_bill_splits_session ON bill_splits (table_session_id);
+CREATE INDEX IF NOT EXISTS idx_bill_splits_order ON bill_splits (order_id);
+
+CREATE TABLE IF NOT EXISTS bill_split_shares (
+    id SERIAL PRIMARY KEY,
+    split_id INTEGER NOT NULL REFERENCES bill_splits(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    label VARCHAR(100) NOT NULL DEFAULT '',
+    subtotal_cents INTEGER NOT NULL,
+    service_charge_cents INTEGER NOT NULL DEFAULT 0,
+    item_ids INTEGER[] NOT NULL DEFAULT '{}',
+    payment_method VARCHAR(20),
+    paid_at TIMESTAMP,
+    UNIQUE (split_id, position)
+);
@@ -0,0 +1,11 @@
+-- ===== COMPOSIÇÃO DO PREÇO DO PEDIDO =====
+-- total_amount = subtotal - discount_amount + service_charge + delivery_fee + tip_amount
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS subtotal DECIMAL(10,2) NOT NULL DEFAULT 0;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS discount_amount DECIMAL(10,2) NOT NULL DEFAULT 0;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS service_charge DECIMAL(10,2) NOT NULL DEFAULT 0;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS tip_amount DECIMAL(10,2) NOT NULL DEFAULT 0;
+
+-- Pedidos antigos: tudo o que não é taxa de entrega era consumo
+UPDATE orders SET subtotal = total_amount - COALESCE(delivery_fee, 0)
+WHERE subtotal = 0 AND total_amount > 0;
@@ -0,0 +1,27 @@
+-- ===== CARRINHOS =====
+-- Carrinho do totem e do app guardado no servidor; o fechamento gera um pedido
+
+CREATE TABLE IF NOT EXISTS carts (
+    id VARCHAR(32) PRIMARY KEY,
+    store_id INTEGER NOT NULL DEFAULT 1,
+    details JSONB NOT NULL DEFAULT '{}',
+    status VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'checked_out')),
+    order_id INTEGER REFERENCES orders(id),
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_carts_expires ON carts (expires_at) WHERE status = 'open';
+
+CREATE TABLE IF NOT EXISTS cart_items (
+    id SERIAL PRIMARY KEY,
+    cart_id VARCHAR(32) NOT NULL REFERENCES carts(id) ON DELETE CASCADE,
+    product_id INTEGER NOT NULL REFERENCES products(id),
+    ingredients TEXT NOT NULL DEFAULT '',
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    notes TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_cart_items_cart ON cart_items (cart_id);
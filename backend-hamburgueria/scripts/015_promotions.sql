@@ -0,0 +1,35 @@
+-- ===== PROMOÇÕES AUTOMÁTICAS =====
+-- Regras avaliadas no cálculo do pedido: percentual, preço promocional (happy hour) e "leve X ganhe Y"
+
+CREATE TABLE IF NOT EXISTS promotions (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(120) NOT NULL,
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('percentage', 'fixed_price', 'buy_x_get_y')),
+    value DECIMAL(10,2) NOT NULL DEFAULT 0,
+    product_ids INTEGER[] NOT NULL DEFAULT '{}',
+    category_ids INTEGER[] NOT NULL DEFAULT '{}',
+    buy_quantity INTEGER NOT NULL DEFAULT 0,
+    get_quantity INTEGER NOT NULL DEFAULT 0,
+    reward_product_ids INTEGER[] NOT NULL DEFAULT '{}',
+    reward_category_ids INTEGER[] NOT NULL DEFAULT '{}',
+    weekdays INTEGER[] NOT NULL DEFAULT '{}',
+    start_time TIME,
+    end_time TIME,
+    starts_at TIMESTAMPTZ,
+    ends_at TIMESTAMPTZ,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Promoções aplicadas em cada item do pedido
+CREATE TABLE IF NOT EXISTS order_item_promotions (
+    id SERIAL PRIMARY KEY,
+    order_id INTEGER NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    order_item_id INTEGER NOT NULL REFERENCES order_items(id) ON DELETE CASCADE,
+    promotion_id INTEGER NOT NULL REFERENCES promotions(id),
+    name VARCHAR(120) NOT NULL,
+    quantity INTEGER NOT NULL,
+    discount_amount DECIMAL(10,2) NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_item_promotions_order ON order_item_promotions (order_id);
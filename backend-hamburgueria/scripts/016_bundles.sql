@@ -0,0 +1,31 @@
+-- ===== COMBOS =====
+-- Produtos vendidos como combo: etapas com escolha entre produtos (com acréscimo opcional)
+-- No pedido, o combo vira um item por produto escolhido, para a cozinha preparar cada um na sua estação
+
+ALTER TABLE products ADD COLUMN IF NOT EXISTS is_bundle BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE IF NOT EXISTS bundle_slots (
+    id SERIAL PRIMARY KEY,
+    bundle_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    position INTEGER NOT NULL,
+    is_required BOOLEAN NOT NULL DEFAULT TRUE
+);
+
+CREATE INDEX IF NOT EXISTS idx_bundle_slots_bundle ON bundle_slots (bundle_id, position);
+
+CREATE TABLE IF NOT EXISTS bundle_slot_options (
+    slot_id INTEGER NOT NULL REFERENCES bundle_slots(id) ON DELETE CASCADE,
+    product_id INTEGER NOT NULL REFERENCES products(id),
+    upcharge DECIMAL(10,2) NOT NULL DEFAULT 0 CHECK (upcharge >= 0),
+    is_default BOOLEAN NOT NULL DEFAULT FALSE,
+    PRIMARY KEY (slot_id, product_id)
+);
+
+-- Itens do pedido que vieram de um combo
+ALTER TABLE order_items ADD COLUMN IF NOT EXISTS bundle_id INTEGER REFERENCES products(id);
+ALTER TABLE order_items ADD COLUMN IF NOT EXISTS bundle_seq INTEGER;
+ALTER TABLE order_items ADD COLUMN IF NOT EXISTS bundle_slot VARCHAR(100);
+
+-- Escolhas das etapas nos itens do carrinho
+ALTER TABLE cart_items ADD COLUMN IF NOT EXISTS choices JSONB NOT NULL DEFAULT '[]';
@@ -0,0 +1,25 @@
+-- ===== VARIAÇÕES DE PRODUTO =====
+-- Tamanhos e versões de um produto (ex.: Batata P/M/G), cada um com SKU, preço e disponibilidade
+-- Produtos com variações exigem a escolha da variação no pedido
+
+CREATE TABLE IF NOT EXISTS product_variants (
+    id SERIAL PRIMARY KEY,
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    name VARCHAR(50) NOT NULL,
+    sku VARCHAR(50) NOT NULL UNIQUE,
+    price DECIMAL(10,2) NOT NULL CHECK (price >= 0),
+    is_available BOOLEAN NOT NULL DEFAULT TRUE,
+    position INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (product_id, name)
+);
+
+CREATE INDEX IF NOT EXISTS idx_product_variants_product ON product_variants (product_id, position);
+
+-- Variação escolhida no item do pedido (nome gravado como estava no momento do pedido)
+ALTER TABLE order_items ADD COLUMN IF NOT EXISTS variant_id INTEGER REFERENCES product_variants(id) ON DELETE SET NULL;
+ALTER TABLE order_items ADD COLUMN IF NOT EXISTS variant_name VARCHAR(50);
+ALTER TABLE order_items ADD COLUMN IF NOT EXISTS variant_sku VARCHAR(50);
+
+-- Variação escolhida nos itens do carrinho
+ALTER TABLE cart_items ADD COLUMN IF NOT EXISTS variant_id INTEGER;
@@ -0,0 +1,18 @@
+-- ===== HORÁRIOS DO CARDÁPIO =====
+-- Janelas de venda de produtos e categorias (café da manhã, madrugada, cardápio de temporada)
+-- Sem janelas cadastradas o item é vendido o dia todo; com várias, basta uma valer
+
+CREATE TABLE IF NOT EXISTS availability_schedules (
+    id SERIAL PRIMARY KEY,
+    product_id INTEGER REFERENCES products(id) ON DELETE CASCADE,
+    category_id INTEGER REFERENCES categories(id) ON DELETE CASCADE,
+    weekdays INTEGER[] NOT NULL DEFAULT '{}',
+    start_time TIME,
+    end_time TIME,
+    start_date DATE,
+    end_date DATE,
+    CHECK ((product_id IS NULL) <> (category_id IS NULL))
+);
+
+CREATE INDEX IF NOT EXISTS idx_availability_schedules_product ON availability_schedules (product_id);
+CREATE INDEX IF NOT EXISTS idx_availability_schedules_category ON availability_schedules (category_id);
@@ -0,0 +1,33 @@
+-- ===== HORÁRIO DE FUNCIONAMENTO =====
+-- Horário semanal da loja, exceções por data (feriados, horários especiais) e pausa manual dos pedidos
+-- Sem horário semanal cadastrado a loja funciona o dia todo (exceto nas datas fechadas)
+
+CREATE TABLE IF NOT EXISTS store_hours (
+    id SERIAL PRIMARY KEY,
+    store_id INTEGER NOT NULL DEFAULT 1,
+    weekday INTEGER NOT NULL CHECK (weekday BETWEEN 0 AND 6),
+    open_time TIME NOT NULL,
+    close_time TIME NOT NULL -- Menor ou igual à abertura: fecha depois da meia-noite
+);
+
+CREATE INDEX IF NOT EXISTS idx_store_hours_store ON store_hours (store_id, weekday);
+
+CREATE TABLE IF NOT EXISTS store_hour_exceptions (
+    id SERIAL PRIMARY KEY,
+    store_id INTEGER NOT NULL DEFAULT 1,
+    date DATE NOT NULL,
+    is_closed BOOLEAN NOT NULL DEFAULT TRUE,
+    open_time TIME,
+    close_time TIME,
+    reason VARCHAR(120) NOT NULL DEFAULT '',
+    UNIQUE (store_id, date),
+    CHECK (is_closed OR (open_time IS NOT NULL AND close_time IS NOT NULL))
+);
+
+-- Pausa dos pedidos (cozinha cheia, falta de insumo...); sem resume_at, até ser retomada
+CREATE TABLE IF NOT EXISTS store_pauses (
+    store_id INTEGER PRIMARY KEY,
+    reason VARCHAR(200) NOT NULL DEFAULT '',
+    paused_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    resume_at TIMESTAMPTZ
+);
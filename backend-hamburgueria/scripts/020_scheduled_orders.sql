@@ -0,0 +1,7 @@
+-- ===== PEDIDOS AGENDADOS =====
+-- Horário de retirada/entrega escolhido pelo cliente; o pedido fica "scheduled" e entra na fila
+-- da cozinha no release_at (horário menos a antecedência configurada)
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS scheduled_for TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_orders_scheduled_for ON orders (store_id, scheduled_for) WHERE scheduled_for IS NOT NULL;
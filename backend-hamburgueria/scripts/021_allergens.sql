@@ -0,0 +1,18 @@
+-- ===== ALÉRGENOS, DIETAS E INFORMAÇÃO NUTRICIONAL =====
+-- Alérgenos, dietas e calorias ficam nos ingredientes; os dos produtos vêm da receita
+-- Produtos sem receita cadastrada não declaram alérgenos nem dietas
+
+ALTER TABLE ingredients
+    ADD COLUMN IF NOT EXISTS allergens TEXT[] NOT NULL DEFAULT '{}',
+    ADD COLUMN IF NOT EXISTS diet_tags TEXT[] NOT NULL DEFAULT '{}',
+    ADD COLUMN IF NOT EXISTS calories INTEGER CHECK (calories >= 0);
+
+-- Receita dos produtos: ingredientes e porções
+CREATE TABLE IF NOT EXISTS product_ingredients (
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    ingredient_id INTEGER NOT NULL REFERENCES ingredients(id) ON DELETE RESTRICT,
+    quantity INTEGER NOT NULL DEFAULT 1 CHECK (quantity > 0),
+    PRIMARY KEY (product_id, ingredient_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_product_ingredients_ingredient ON product_ingredients (ingredient_id);
@@ -0,0 +1,12 @@
+-- ===== ALERGIAS DO CLIENTE =====
+-- Alergias declaradas no pedido (todos os itens) e em cada item; itens que contêm algum
+-- dos alérgenos declarados guardam o conflito para destaque na cozinha e no ticket impresso
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS allergies TEXT[] NOT NULL DEFAULT '{}';
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS allergy_alert BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE order_items ADD COLUMN IF NOT EXISTS allergies TEXT[] NOT NULL DEFAULT '{}';
+ALTER TABLE order_items ADD COLUMN IF NOT EXISTS allergen_conflicts TEXT[] NOT NULL DEFAULT '{}';
+
+-- Alergias declaradas em cada item do carrinho, levadas para o item do pedido no fechamento
+ALTER TABLE cart_items ADD COLUMN IF NOT EXISTS allergies TEXT[] NOT NULL DEFAULT '{}';
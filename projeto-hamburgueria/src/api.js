@@ -6,3 +6,15 @@ const API_URL = "http://localhost:8080/api";
 // Exporta a URL da API para ser usada em outros componentes
 // Outros arquivos podem importar esta constante para fazer requisições
 export default API_URL;
+
+// Descreve de onde vem/para onde vai o pedido conforme o tipo
+// dine_in: mesa; takeaway: retirada no balcão; delivery: entrega
+export function orderOrigin(order) {
+  if (order.order_type === "delivery") {
+    return `Entrega - ${order.customer_name}`;
+  }
+  if (order.order_type === "takeaway") {
+    return `Retirada - ${order.pickup_name || order.customer_name}`;
+  }
+  return `Mesa ${order.table_number} - ${order.customer_name}`;
+}